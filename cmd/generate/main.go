@@ -6,7 +6,7 @@
 //
 // Usage:
 //
-//	go run github.com/modern-dev/go-lsp/cmd/generate [-o dir] [-model path] [-ref tag]
+//	go run github.com/modern-dev/go-lsp/cmd/generate [-o dir] [-model path] [-ref tag] [-json-number] [-spec-names] [-overrides file] [-concrete-errors]
 package main
 
 import (
@@ -34,6 +34,36 @@ func main() {
 	outDir := flag.String("o", "protocol", "Output directory for generated files")
 	modelPath := flag.String("model", "", "Path to a local metaModel.json (skips download)")
 	ref := flag.String("ref", defaultRef, "Git ref / tag to fetch metaModel.json from")
+	jsonNumber := flag.Bool(
+		"json-number",
+		false,
+		"Emit numeric fields as json.Number with typed accessors",
+	)
+	specNames := flag.Bool(
+		"spec-names",
+		false,
+		"Use spec-derived method names instead of the go.lsp.dev/protocol v0.12.0 names",
+	)
+	overridesPath := flag.String(
+		"overrides",
+		"",
+		"Path to a JSON file mapping LSP method names to Go method names",
+	)
+	concreteErrors := flag.Bool(
+		"concrete-errors",
+		false,
+		"Return *ResponseError instead of error from Server request methods",
+	)
+	sealedUnions := flag.Bool(
+		"sealed-unions",
+		false,
+		"Emit closed struct unions such as documentChanges as sealed interfaces",
+	)
+	unionTypes := flag.Bool(
+		"union-types",
+		true,
+		"Emit unions with several members as named wrapper structs instead of any",
+	)
 
 	flag.Parse()
 
@@ -55,6 +85,18 @@ func main() {
 	fmt.Printf("Notifications: %d\n", len(model.Notifications))
 
 	gen := generate.NewGenerator(&model)
+	gen.Options.JSONNumber = *jsonNumber
+	gen.Options.SpecNames = *specNames
+	gen.Options.ConcreteErrors = *concreteErrors
+	gen.Options.SealedUnions = *sealedUnions
+	gen.Options.UnionTypes = *unionTypes
+
+	if *overridesPath != "" {
+		gen.Options.MethodNameOverrides, err = loadOverrides(*overridesPath)
+		if err != nil {
+			log.Fatalf("load overrides: %v", err)
+		}
+	}
 
 	out, err := gen.Generate()
 	if err != nil {
@@ -74,6 +116,7 @@ func main() {
 		{"types_gen.go", out.Types},
 		{"server_gen.go", out.Server},
 		{"client_gen.go", out.Client},
+		{"types_roundtrip_gen_test.go", out.RoundTrip},
 	}
 
 	for _, fil := range files {
@@ -90,6 +133,22 @@ func main() {
 	}
 }
 
+// loadOverrides reads a JSON object mapping LSP method names to Go method
+// names, e.g. {"myServer/reindex": "Reindex"}.
+func loadOverrides(path string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var overrides map[string]string
+	if err := json.Unmarshal(data, &overrides); err != nil { //nolint:noinlineerr
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	return overrides, nil
+}
+
 // loadModel returns the raw bytes of metaModel.json, either from a local file
 // or by downloading it from the vscode-languageserver-node repository.
 func loadModel(localPath, ref string) ([]byte, error) {
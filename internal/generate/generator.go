@@ -5,14 +5,60 @@ package generate
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
 )
 
 type (
+	// Options controls optional code generation behaviour. The zero value
+	// produces the default output.
+	Options struct {
+		// JSONNumber emits integer, uinteger and decimal base types as
+		// json.Number instead of int32/uint32/float64, together with typed
+		// accessor methods for each numeric field.
+		JSONNumber bool
+
+		// SpecNames disables methodNameOverrides, so every method gets its
+		// spec-derived Go name (fully qualified only on collision) instead of
+		// the legacy go.lsp.dev/protocol v0.12.0 name.
+		SpecNames bool
+
+		// MethodNameOverrides maps LSP methods (typically custom extension
+		// methods) to the Go method name to use for them. Entries are merged
+		// over methodNameOverrides and pinned like the built-in ones; they
+		// also apply under SpecNames.
+		MethodNameOverrides map[string]string
+
+		// ConcreteErrors makes Server request methods return *ResponseError
+		// instead of error, so the LSP error code a handler replies with is
+		// explicit in its signature. Notifications and Client methods keep
+		// returning error.
+		ConcreteErrors bool
+
+		// SealedUnions emits the closed struct unions listed in sealedUnions
+		// (such as the WorkspaceEdit.documentChanges entries) as a sealed
+		// interface implemented by each variant, instead of any. Structures
+		// holding such a union get an UnmarshalJSON method that picks the
+		// variant by its discriminator property.
+		SealedUnions bool
+
+		// UnionTypes emits every union with more than one non-null member as
+		// a named wrapper struct instead of any, e.g. OrLocationLocationArray
+		// for `Location | Location[]`. Each wrapper has an As and a Set method
+		// per member, and UnmarshalJSON decodes the first member, in
+		// specification order, that accepts the data. Sealed unions keep
+		// their interface, and `boolean | X` settings use BoolOrOptions, as
+		// they do without UnionTypes. NewGenerator turns it on.
+		UnionTypes bool
+	}
+
 	// Generator holds the parsed model and lookup indices used during code generation.
 	Generator struct {
-		Model *Model
+		Model   *Model
+		Options Options
 
 		// Lookup indices built from the model.
 		structs  map[string]*Structure
@@ -25,8 +71,29 @@ type (
 		// named Go structs. Key is the generated name, value is the literal type.
 		namedLiterals map[string]*LiteralType
 
-		// literalCounter disambiguate anonymous literal names.
-		literalCounter int
+		// literalNames maps a literal's structural key to its promoted Go name,
+		// so identical literals share a single declaration.
+		literalNames map[string]string
+
+		// literalOwners maps a literal's structural key to the set of
+		// declarations (structures, aliases, methods) referencing it.
+		literalOwners map[string]map[string]bool
+
+		// literalOrder lists the structural keys of the literals in the order
+		// indexLiterals first meets them, and literalBases holds the name
+		// derived from that first use, e.g. InitializeParamsClientInfo for the
+		// clientInfo property of InitializeParams.
+		literalOrder []string
+		literalBases map[string]string
+
+		// sealed holds the unions emitted under Options.SealedUnions;
+		// sealedUnions unless overridden by tests.
+		sealed []sealedUnion
+
+		// unions holds the wrapper structs promoted under Options.UnionTypes,
+		// keyed by name; unionErr records the first name clash among them.
+		unions   map[string]*unionType
+		unionErr error
 	}
 
 	abbreviation struct {
@@ -66,12 +133,18 @@ var (
 func NewGenerator(model *Model) *Generator {
 	gen := &Generator{ //nolint:exhaustruct
 		Model:         model,
+		Options:       Options{UnionTypes: true}, //nolint:exhaustruct
 		structs:       make(map[string]*Structure, len(model.Structures)),
 		enums:         make(map[string]*Enumeration, len(model.Enumerations)),
 		aliases:       make(map[string]*TypeAlias, len(model.TypeAliases)),
 		requests:      make(map[string]*Request, len(model.Requests)),
 		notifs:        make(map[string]*Notification, len(model.Notifications)),
 		namedLiterals: make(map[string]*LiteralType),
+		literalNames:  make(map[string]string),
+		literalOwners: make(map[string]map[string]bool),
+		literalBases:  make(map[string]string),
+		sealed:        sealedUnions,
+		unions:        make(map[string]*unionType),
 	}
 
 	for idx := range model.Structures {
@@ -94,9 +167,129 @@ func NewGenerator(model *Model) *Generator {
 		gen.notifs[model.Notifications[idx].Method] = &model.Notifications[idx]
 	}
 
+	gen.indexLiterals()
+
 	return gen
 }
 
+// indexLiterals records which declarations reference each anonymous literal
+// type, and names each literal after its first use in the model. A literal
+// owned by a single declaration is promoted to an unexported struct so it
+// does not clutter the exported surface of the package.
+func (g *Generator) indexLiterals() {
+	for _, strc := range g.Model.Structures {
+		if strc.Proposed {
+			continue
+		}
+
+		for _, prop := range strc.Properties {
+			if !prop.Proposed {
+				g.indexLiteralUses(&prop.Type, strc.Name, strc.Name+GoFieldName(prop.Name))
+			}
+		}
+	}
+
+	for _, alias := range g.Model.TypeAliases {
+		if !alias.Proposed {
+			g.indexLiteralUses(&alias.Type, alias.Name, alias.Name+"Literal")
+		}
+	}
+
+	for _, req := range g.Model.Requests {
+		if !req.Proposed {
+			name := GoMethodNameFull(req.Method)
+			g.indexLiteralUses(req.Params, req.Method, name+"Params")
+			g.indexLiteralUses(req.Result, req.Method, name+"Result")
+		}
+	}
+
+	for _, notif := range g.Model.Notifications {
+		if !notif.Proposed {
+			g.indexLiteralUses(notif.Params, notif.Method, GoMethodNameFull(notif.Method)+"Params")
+		}
+	}
+
+	g.nameLiterals()
+}
+
+// indexLiteralUses records the literals in typ as used by owner. base is the
+// name of a literal typ itself is, or holds.
+func (g *Generator) indexLiteralUses(typ *Type, owner, base string) {
+	if typ == nil {
+		return
+	}
+
+	switch typ.Kind {
+	case "array":
+		g.indexLiteralUses(typ.Element, owner, base)
+	case "map":
+		g.indexLiteralUses(typ.Key, owner, base)
+		g.indexLiteralUses(typ.MapValue, owner, base)
+	case "or", "and", "tuple":
+		for idx := range typ.Items {
+			g.indexLiteralUses(&typ.Items[idx], owner, base)
+		}
+	case "literal":
+		if typ.Literal == nil {
+			return
+		}
+
+		key := literalKey(typ.Literal)
+		if g.literalOwners[key] == nil {
+			g.literalOwners[key] = make(map[string]bool)
+			g.literalOrder = append(g.literalOrder, key)
+			g.literalBases[key] = base
+		}
+
+		g.literalOwners[key][owner] = true
+
+		// Nested literals are owned by the enclosing literal.
+		for _, prop := range typ.Literal.Properties {
+			if !prop.Proposed {
+				g.indexLiteralUses(&prop.Type, key, g.literalBases[key]+GoFieldName(prop.Name))
+			}
+		}
+	}
+}
+
+// nameLiterals assigns the Go names of the literals indexed by
+// indexLiterals, in the order they were met: the name derived from the first
+// use, unexported if the literal has a single owner, and numbered from 2 if
+// another declaration or literal already has it. A name therefore depends
+// only on the model, not on the order types are resolved in.
+func (g *Generator) nameLiterals() {
+	for _, key := range g.literalOrder {
+		base := g.literalBases[key]
+		if len(g.literalOwners[key]) <= 1 {
+			runes := []rune(base)
+			runes[0] = unicode.ToLower(runes[0])
+			base = string(runes)
+		}
+
+		g.literalNames[key] = g.freeName(base)
+	}
+}
+
+// freeName returns base, or base followed by the first number from 2 that
+// makes it a name no declaration of the model or promoted literal has.
+func (g *Generator) freeName(base string) string {
+	taken := func(name string) bool {
+		_, isStruct := g.structs[name]
+		_, isEnum := g.enums[name]
+		_, isAlias := g.aliases[name]
+
+		return isStruct || isEnum || isAlias ||
+			slices.Contains(slices.Collect(maps.Values(g.literalNames)), name)
+	}
+
+	name := base
+	for suffix := 2; taken(name); suffix++ {
+		name = base + strconv.Itoa(suffix)
+	}
+
+	return name
+}
+
 // resolveGoType converts an LSP Type into its Go type string representation.
 // Anonymous literal types are promoted to named structs and tracked in
 // namedLiterals for later emission.
@@ -107,6 +300,10 @@ func (g *Generator) resolveGoType(typ *Type) string { //nolint:cyclop
 
 	switch typ.Kind {
 	case "base":
+		if g.Options.JSONNumber && numericAccessor(typ.Name) != "" {
+			return "json.Number"
+		}
+
 		return resolveBaseType(typ.Name)
 	case "reference":
 		return typ.Name
@@ -136,7 +333,13 @@ func (g *Generator) resolveGoType(typ *Type) string { //nolint:cyclop
 // resolveUnion converts an "or" (union) type into a Go type. The logic handles
 // common LSP patterns:
 //   - T | null → *T (nullable; pointer for structs/primitives, bare for slices/maps/any)
-//   - Two non-null types or more → any
+//   - A closed struct union listed in sealedUnions → its sealed interface
+//     (only with Options.SealedUnions)
+//   - boolean | XOptions → BoolOrOptions[XOptions], hand-written in the
+//     protocol package; see boolOrOptions for the forms matched with
+//     Options.UnionTypes
+//   - Two non-null types or more → a wrapper struct registered by
+//     promoteUnion, or any without Options.UnionTypes
 func (g *Generator) resolveUnion(items []Type) string {
 	nonNull := make([]Type, 0, len(items))
 
@@ -159,23 +362,196 @@ func (g *Generator) resolveUnion(items []Type) string {
 		return resolved
 	}
 
+	if union, ok := g.sealedUnionFor(nonNull); ok {
+		return union.name
+	}
+
+	if options, ok := g.boolOrOptions(nonNull); ok {
+		return "BoolOrOptions[" + options + "]"
+	}
+
+	if g.Options.UnionTypes {
+		resolved := g.promoteUnion(nonNull)
+		if hasNull && needsPointerForNull(resolved) {
+			return "*" + resolved
+		}
+
+		return resolved
+	}
+
 	return "any"
 }
 
-// promoteLiteral assigns a name to an anonymous literal type and registers it
-// for later emission as a named Go struct.
+// boolOrOptions reports whether items is the `boolean | XOptions` pattern
+// used by the provider fields of ServerCapabilities, returning the name of
+// the options structure.
+//
+// With Options.UnionTypes, every union of a boolean and an object is a
+// BoolOrOptions rather than a wrapper struct: the options may be any
+// structure or literal, and `boolean | XOptions | XRegistrationOptions`
+// holds the registration options, which have every property of the options.
+func (g *Generator) boolOrOptions(items []Type) (string, bool) {
+	var (
+		hasBool bool
+		objects []Type
+	)
+
+	for _, item := range items {
+		switch {
+		case item.Kind == "base" && item.Name == "boolean" && !hasBool:
+			hasBool = true
+		case g.optionsObject(&item):
+			objects = append(objects, item)
+		default:
+			return "", false
+		}
+	}
+
+	switch {
+	case !hasBool:
+		return "", false
+	case len(objects) == 1:
+		return g.resolveGoType(&objects[0]), true
+	case len(objects) == 2 && g.Options.UnionTypes: //nolint:mnd
+		for idx, reg := range objects {
+			if g.hasProperties(&reg, &objects[1-idx]) {
+				return reg.Name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// optionsObject reports whether typ can be the options of a BoolOrOptions:
+// an XOptions structure or, with Options.UnionTypes, any structure or
+// literal.
+func (g *Generator) optionsObject(typ *Type) bool {
+	switch typ.Kind {
+	case "reference":
+		if _, ok := g.structs[typ.Name]; !ok {
+			return false
+		}
+
+		return g.Options.UnionTypes || strings.HasSuffix(typ.Name, "Options")
+	case "literal":
+		return g.Options.UnionTypes && typ.Literal != nil
+	default:
+		return false
+	}
+}
+
+// hasProperties reports whether the structure sup has every property of the
+// structure sub.
+func (g *Generator) hasProperties(sup, sub *Type) bool {
+	supStruct, supOK := g.structs[sup.Name]
+	subStruct, subOK := g.structs[sub.Name]
+
+	if sup.Kind != "reference" || sub.Kind != "reference" || !supOK || !subOK {
+		return false
+	}
+
+	names := make([]string, 0)
+	for _, prop := range g.collectProperties(supStruct) {
+		names = append(names, prop.Name)
+	}
+
+	for _, prop := range g.collectProperties(subStruct) {
+		if !slices.Contains(names, prop.Name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// promoteLiteral registers an anonymous literal type for later emission as a
+// named Go struct, under the name nameLiterals gave it. Structurally identical
+// literals share one name; literals referenced by a single declaration are
+// unexported.
 func (g *Generator) promoteLiteral(lit *LiteralType) string {
 	if lit == nil {
 		return "any"
 	}
 
-	g.literalCounter++
-	name := fmt.Sprintf("Literal%d", g.literalCounter)
+	key := literalKey(lit)
+
+	name, ok := g.literalNames[key]
+	if !ok {
+		// indexLiterals skips proposed declarations.
+		name = g.freeName("literal")
+		g.literalNames[key] = name
+	}
+
 	g.namedLiterals[name] = lit
 
 	return name
 }
 
+// literalKey returns a canonical string describing the shape of a literal
+// type, used to detect structurally identical literals.
+func literalKey(lit *LiteralType) string {
+	var builder strings.Builder
+
+	builder.WriteString("{")
+
+	for idx, prop := range lit.Properties {
+		if idx > 0 {
+			builder.WriteString(";")
+		}
+
+		builder.WriteString(prop.Name)
+
+		if prop.Optional {
+			builder.WriteString("?")
+		}
+
+		builder.WriteString(":")
+		builder.WriteString(typeKey(&prop.Type))
+	}
+
+	builder.WriteString("}")
+
+	return builder.String()
+}
+
+// typeKey returns a canonical string describing an LSP type.
+func typeKey(typ *Type) string {
+	if typ == nil {
+		return "_"
+	}
+
+	switch typ.Kind {
+	case "base", "reference":
+		return typ.Name
+	case "array":
+		return typeKey(typ.Element) + "[]"
+	case "map":
+		return "map<" + typeKey(typ.Key) + "," + typeKey(typ.MapValue) + ">"
+	case "or", "and", "tuple":
+		parts := make([]string, 0, len(typ.Items))
+		for idx := range typ.Items {
+			parts = append(parts, typeKey(&typ.Items[idx]))
+		}
+
+		return typ.Kind + "(" + strings.Join(parts, ",") + ")"
+	case "literal":
+		if typ.Literal == nil {
+			return "{}"
+		}
+
+		return literalKey(typ.Literal)
+	case "stringLiteral":
+		return strconv.Quote(typ.StringValue)
+	case "integerLiteral":
+		return strconv.FormatInt(typ.IntValue, 10)
+	case "booleanLiteral":
+		return strconv.FormatBool(typ.BoolValue)
+	default:
+		return typ.Kind
+	}
+}
+
 // GoFieldName converts an LSP property name (camelCase) to a Go exported field
 // name (PascalCase). It handles well-known abbreviation prefixes like "uri",
 // "id", "json", etc.
@@ -317,6 +693,21 @@ func resolveBaseType(name string) string { //nolint:cyclop
 	}
 }
 
+// numericAccessor returns the Go type a json.Number accessor converts the
+// given LSP base type to, or "" for non-numeric base types.
+func numericAccessor(name string) string {
+	switch name {
+	case "integer":
+		return "int32"
+	case "uinteger":
+		return "uint32"
+	case "decimal":
+		return "float64"
+	default:
+		return ""
+	}
+}
+
 // needsPointerForNull reports whether the Go type needs a pointer wrapper to
 // represent a nullable value. Slices, maps, and any already have nil as their
 // zero value and don't need wrapping.
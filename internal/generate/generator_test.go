@@ -0,0 +1,259 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// literalOf builds a "literal" kind Type with the given properties.
+func literalOf(props ...Property) Type {
+	return Type{Kind: "literal", Literal: &LiteralType{Properties: props}}
+}
+
+// baseProp builds a required property of the given base type.
+func baseProp(name, base string) Property {
+	return Property{Name: name, Type: Type{Kind: "base", Name: base}}
+}
+
+// generateTypes runs the types emitter over model and returns the output.
+func generateTypes(t *testing.T, model *Model) string {
+	t.Helper()
+
+	out, err := NewGenerator(model).generateTypes()
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+func TestPromoteLiteral_Visibility(t *testing.T) {
+	model := &Model{
+		Structures: []Structure{
+			{
+				Name: "A",
+				Properties: []Property{
+					{Name: "single", Type: literalOf(baseProp("x", "string"))},
+					{Name: "shared", Type: literalOf(baseProp("y", "integer"))},
+				},
+			},
+			{
+				Name: "B",
+				Properties: []Property{
+					{Name: "shared", Type: literalOf(baseProp("y", "integer"))},
+				},
+			},
+		},
+	}
+
+	out := generateTypes(t, model)
+
+	assert.Contains(t, out, "\tSingle aSingle `json:\"single\"`")
+	assert.Contains(t, out, "type aSingle struct {\n\tX string `json:\"x\"`\n}")
+
+	assert.Contains(t, out, "\tShared AShared `json:\"shared\"`")
+	assert.Contains(t, out, "type AShared struct {\n\tY int32 `json:\"y\"`\n}")
+	assert.NotContains(t, out, "BShared", "identical literals should share one declaration")
+}
+
+func TestPromoteLiteral_Names(t *testing.T) {
+	flag := literalOf(Property{
+		Name: "delta", Optional: true, Type: Type{Kind: "base", Name: "boolean"},
+	})
+	model := &Model{
+		Structures: []Structure{
+			{
+				Name: "Requests",
+				Properties: []Property{
+					{Name: "full", Type: Type{Kind: "or", Items: []Type{
+						{Kind: "base", Name: "boolean"}, flag,
+					}}},
+					{Name: "info", Type: literalOf(
+						baseProp("name", "string"),
+						Property{Name: "extra", Type: literalOf(baseProp("x", "string"))},
+					)},
+				},
+			},
+			{Name: "RequestsFull2"},
+		},
+		TypeAliases: []TypeAlias{{
+			Name: "Change",
+			Type: Type{Kind: "or", Items: []Type{
+				literalOf(baseProp("text", "string")),
+				literalOf(baseProp("range", "string"), baseProp("text", "string")),
+			}},
+		}},
+		Requests: []Request{{
+			Method: "workspace/probe",
+			Result: new(literalOf(baseProp("found", "boolean"))),
+		}},
+	}
+
+	src := generateTypes(t, model)
+	assert.Contains(t, src, "\tFull BoolOrOptions[requestsFull] `json:\"full\"`",
+		"literals are named after the property holding them")
+	assert.Contains(t, src, "\tInfo requestsInfo `json:\"info\"`")
+	assert.Contains(t, src, "\tExtra requestsInfoExtra `json:\"extra\"`",
+		"nested literals are named after the enclosing literal")
+	assert.Contains(t, src, "type OrChangeLiteralChangeLiteral2 struct {",
+		"literals in one union are numbered")
+	assert.Contains(t, src, "type workspaceProbeResult struct {")
+	assert.NotContains(t, src, "iteral1")
+	assert.NotContains(t, src, "OrBool")
+}
+
+func TestFieldTypeOverrides(t *testing.T) {
+	contents := Type{
+		Kind: "or",
+		Items: []Type{
+			refType("MarkupContent"),
+			refType("MarkedString"),
+			{Kind: "array", Element: new(refType("MarkedString"))},
+		},
+	}
+	model := &Model{
+		Structures: []Structure{
+			{Name: "Hover", Properties: []Property{{Name: "contents", Type: contents}}},
+			{Name: "Tooltip", Properties: []Property{{Name: "contents", Type: contents}}},
+			{Name: "MarkupContent", Properties: []Property{baseProp("value", "string")}},
+		},
+	}
+
+	out := generateTypes(t, model)
+
+	assert.Contains(t, out, "type Hover struct {\n\tContents HoverContents `json:\"contents\"`\n}")
+	assert.Contains(t, out, "type Tooltip struct {\n"+
+		"\tContents OrMarkupContentMarkedStringMarkedStringArray `json:\"contents\"`\n}",
+		"overrides apply to their structure only")
+}
+
+func TestBoolOrOptions(t *testing.T) {
+	boolOr := func(item Type) Type {
+		return Type{Kind: "or", Items: []Type{{Kind: "base", Name: "boolean"}, item}}
+	}
+	model := &Model{
+		Structures: []Structure{
+			{
+				Name: "ServerCapabilities",
+				Properties: []Property{
+					{Name: "hoverProvider", Optional: true, Type: boolOr(refType("HoverOptions"))},
+					{Name: "colorProvider", Optional: true, Type: boolOr(refType("ColorInfo"))},
+					{Name: "save", Type: boolOr(refType("MissingOptions"))},
+					{Name: "renameProvider", Optional: true, Type: Type{Kind: "or", Items: []Type{
+						{Kind: "base", Name: "boolean"},
+						refType("RenameOptions"),
+						refType("RenameRegistrationOptions"),
+					}}},
+				},
+			},
+			{Name: "HoverOptions", Properties: []Property{baseProp("workDoneProgress", "boolean")}},
+			{Name: "ColorInfo"},
+			{Name: "RenameOptions", Properties: []Property{baseProp("prepareProvider", "boolean")}},
+			{
+				Name:       "RenameRegistrationOptions",
+				Extends:    []Type{refType("RenameOptions")},
+				Properties: []Property{baseProp("id", "string")},
+			},
+		},
+	}
+
+	out := generateTypes(t, model)
+
+	assert.Contains(t, out,
+		"\tHoverProvider *BoolOrOptions[HoverOptions] `json:\"hoverProvider,omitempty\"`")
+	assert.Contains(t, out,
+		"\tColorProvider *BoolOrOptions[ColorInfo] `json:\"colorProvider,omitempty\"`",
+		"union types use BoolOrOptions for every object")
+	assert.Contains(t, out, "\tRenameProvider *BoolOrOptions[RenameRegistrationOptions] "+
+		"`json:\"renameProvider,omitempty\"`", "registration options have every option")
+	assert.Contains(t, out, "\tSave OrBoolMissingOptions `json:\"save\"`",
+		"the options must be a structure")
+	assert.NotContains(t, out, "OrBoolRenameOptions")
+
+	gen := NewGenerator(model)
+	gen.Options.UnionTypes = false
+
+	anyTypes, err := gen.generateTypes()
+	require.NoError(t, err)
+
+	src := string(anyTypes)
+	assert.Contains(t, src,
+		"\tHoverProvider *BoolOrOptions[HoverOptions] `json:\"hoverProvider,omitempty\"`")
+	assert.Contains(t, src, "\tColorProvider any `json:\"colorProvider,omitempty\"`",
+		"only XOptions structures match")
+	assert.Contains(t, src, "\tSave any `json:\"save\"`", "the options must be a structure")
+	assert.Contains(t, src, "\tRenameProvider any `json:\"renameProvider,omitempty\"`")
+}
+
+func TestOptionsJSONNumber(t *testing.T) {
+	model := &Model{
+		Structures: []Structure{
+			{
+				Name: "Color",
+				Properties: []Property{
+					baseProp("red", "decimal"),
+					baseProp("line", "uinteger"),
+					{Name: "delta", Optional: true, Type: Type{Kind: "base", Name: "integer"}},
+				},
+			},
+		},
+	}
+
+	assert.Contains(t, generateTypes(t, model), "\tRed float64 `json:\"red\"`")
+
+	gen := NewGenerator(model)
+	gen.Options.JSONNumber = true
+
+	out, err := gen.generateTypes()
+	require.NoError(t, err)
+
+	src := string(out)
+	assert.Contains(t, src, "\tRed json.Number `json:\"red\"`")
+	assert.Contains(t, src, "\tDelta *json.Number `json:\"delta,omitempty\"`")
+	assert.Contains(t, src, "func (s Color) RedFloat64() (float64, error) {\n\treturn s.Red.Float64()\n}")
+	assert.Contains(t, src, "func (s Color) LineUint32() (uint32, error) {")
+	assert.Contains(t, src, "func (s Color) DeltaInt32() (int32, error) {")
+
+	_, err = parser.ParseFile(token.NewFileSet(), "types_gen.go", out, 0)
+	require.NoError(t, err)
+}
+
+func TestCollectProperties_WorkDoneProgressMixin(t *testing.T) {
+	wdpo := Structure{
+		Name: "WorkDoneProgressOptions",
+		Properties: []Property{
+			{Name: "workDoneProgress", Optional: true, Type: Type{Kind: "base", Name: "boolean"}},
+		},
+	}
+	model := &Model{
+		Structures: []Structure{
+			wdpo,
+			{Name: "HoverOptions", Mixins: []Type{refType("WorkDoneProgressOptions")}},
+			{
+				Name: "TextDocumentRegistrationOptions",
+				Properties: []Property{
+					{Name: "documentSelector", Type: Type{Kind: "base", Name: "string"}},
+				},
+			},
+			{
+				// Reaches WorkDoneProgressOptions twice: directly and via HoverOptions.
+				Name:    "HoverRegistrationOptions",
+				Extends: []Type{refType("TextDocumentRegistrationOptions"), refType("HoverOptions")},
+				Mixins:  []Type{refType("WorkDoneProgressOptions")},
+			},
+		},
+	}
+
+	out := generateTypes(t, model)
+	field := "\tWorkDoneProgress *bool `json:\"workDoneProgress,omitempty\"`\n"
+
+	assert.Contains(t, out, "type HoverOptions struct {\n"+field+"}")
+	assert.Contains(t, out, "type HoverRegistrationOptions struct {\n"+
+		"\tDocumentSelector string `json:\"documentSelector\"`\n"+
+		field+"}")
+}
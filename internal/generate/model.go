@@ -41,12 +41,13 @@ type (
 
 	// Notification describes an LSP notification (no response expected).
 	Notification struct {
-		Documentation    string `json:"documentation"`
-		MessageDirection string `json:"messageDirection"`
-		Method           string `json:"method"`
-		Params           *Type  `json:"params"`
-		Proposed         bool   `json:"proposed"`
-		Since            string `json:"since"`
+		Documentation      string `json:"documentation"`
+		MessageDirection   string `json:"messageDirection"`
+		Method             string `json:"method"`
+		Params             *Type  `json:"params"`
+		Proposed           bool   `json:"proposed"`
+		RegistrationMethod string `json:"registrationMethod"`
+		Since              string `json:"since"`
 	}
 
 	// Structure describes a named LSP type (struct).
@@ -177,7 +178,8 @@ func (t *Type) UnmarshalJSON(data []byte) error { //nolint:cyclop
 	t.Key = pln.Key
 	t.Items = pln.Items
 
-	if len(pln.Value) == 0 {
+	// A missing or null value carries no information for any kind.
+	if len(pln.Value) == 0 || string(pln.Value) == "null" {
 		return nil
 	}
 
@@ -225,3 +227,43 @@ func (t *Type) UnmarshalJSON(data []byte) error { //nolint:cyclop
 
 	return nil
 }
+
+// MarshalJSON is the inverse of UnmarshalJSON: it folds MapValue, Literal and
+// the literal values back into the polymorphic "value" field based on Kind.
+func (t Type) MarshalJSON() ([]byte, error) {
+	type plain struct {
+		Kind    string `json:"kind"`
+		Name    string `json:"name,omitempty"`
+		Element *Type  `json:"element,omitempty"`
+		Key     *Type  `json:"key,omitempty"`
+		Items   []Type `json:"items,omitempty"`
+		Value   any    `json:"value,omitempty"`
+	}
+
+	pln := plain{ //nolint:exhaustruct
+		Kind:    t.Kind,
+		Name:    t.Name,
+		Element: t.Element,
+		Key:     t.Key,
+		Items:   t.Items,
+	}
+
+	switch t.Kind {
+	case "map":
+		if t.MapValue != nil {
+			pln.Value = t.MapValue
+		}
+	case "literal":
+		if t.Literal != nil {
+			pln.Value = t.Literal
+		}
+	case "stringLiteral":
+		pln.Value = t.StringValue
+	case "integerLiteral":
+		pln.Value = t.IntValue
+	case "booleanLiteral":
+		pln.Value = t.BoolValue
+	}
+
+	return json.Marshal(pln) //nolint:wrapcheck
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package generate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzTypeUnmarshal(f *testing.F) {
+	seeds := []string{
+		`{"kind":"base","name":"string"}`,
+		`{"kind":"reference","name":"Position"}`,
+		`{"kind":"array","element":{"kind":"base","name":"integer"}}`,
+		`{"kind":"map","key":{"kind":"base","name":"URI"},"value":{"kind":"base","name":"string"}}`,
+		`{"kind":"or","items":[{"kind":"base","name":"string"},{"kind":"base","name":"null"}]}`,
+		`{"kind":"literal","value":{"properties":[{"name":"x","type":{"kind":"base","name":"string"}}]}}`,
+		`{"kind":"stringLiteral","value":"create"}`,
+		`{"kind":"integerLiteral","value":42}`,
+		`{"kind":"booleanLiteral","value":true}`,
+		`{"kind":"map","value":"not a type"}`,
+		`{"kind":"map","value":null}`,
+		`{"kind":"literal","value":[1,2,3]}`,
+		`{"kind":"integerLiteral","value":1.5}`,
+		`null`,
+		`[]`,
+	}
+
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var first Type
+		if err := json.Unmarshal(data, &first); err != nil {
+			return
+		}
+
+		encoded, err := json.Marshal(first)
+		require.NoError(t, err)
+
+		var second Type
+		require.NoError(t, json.Unmarshal(encoded, &second), "re-decoding %s", encoded)
+
+		assert.Equal(t, first.Kind, second.Kind)
+		assert.Equal(t, first.Name, second.Name)
+		assert.Equal(t, first.StringValue, second.StringValue)
+		assert.Equal(t, first.IntValue, second.IntValue)
+		assert.Equal(t, first.BoolValue, second.BoolValue)
+		assert.Equal(t, first.MapValue == nil, second.MapValue == nil)
+		assert.Equal(t, first.Literal == nil, second.Literal == nil)
+
+		reencoded, err := json.Marshal(second)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(encoded), string(reencoded))
+	})
+}
+
+func TestTypeUnmarshal_NullValue(t *testing.T) {
+	var typ Type
+	require.NoError(t, json.Unmarshal([]byte(`{"kind":"map","value":null}`), &typ))
+	assert.Nil(t, typ.MapValue, "a null value must not produce an empty map value type")
+}
+
+func TestTypeUnmarshal_InvalidMapValue(t *testing.T) {
+	var typ Type
+	assert.Error(t, json.Unmarshal([]byte(`{"kind":"map","value":"not a type"}`), &typ))
+}
@@ -7,18 +7,22 @@ import (
 	"bytes"
 	"cmp"
 	"fmt"
+	"go/token"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 type (
 	// GeneratedOutput holds the generated Go source files.
 	GeneratedOutput struct {
-		Types  []byte // types_gen.go
-		Server []byte // server_gen.go
-		Client []byte // client_gen.go
+		Types     []byte // types_gen.go
+		Server    []byte // server_gen.go
+		Client    []byte // client_gen.go
+		RoundTrip []byte // types_roundtrip_gen_test.go
 	}
 
 	// methodInfo describes a single method on the Server or Client interface.
@@ -29,6 +33,10 @@ type (
 		doc       string
 		isRequest bool
 
+		// concreteErr is set when the method returns *ResponseError rather
+		// than error (see Options.ConcreteErrors).
+		concreteErr bool
+
 		paramsType string // Go type for params, empty if none
 		resultType string // Go type for result, empty if notification
 	}
@@ -55,6 +63,11 @@ func (g *Generator) Generate() (*GeneratedOutput, error) {
 		return nil, fmt.Errorf("generate client: %w", err)
 	}
 
+	out.RoundTrip, err = g.generateRoundTripTests()
+	if err != nil {
+		return nil, fmt.Errorf("generate round-trip tests: %w", err)
+	}
+
 	return out, nil
 }
 
@@ -64,7 +77,34 @@ func (g *Generator) generateTypes() ([]byte, error) { //nolint:gocognit,cyclop,f
 	var buf bytes.Buffer
 
 	buf.Grow(256 * 1024) //nolint:mnd
-	g.writeHeader(&buf, "protocol", "encoding/json")
+
+	sealed := make(map[string][]sealedVariant)
+
+	if g.Options.SealedUnions {
+		for idx := range g.sealed {
+			variants, err := g.sealedVariants(&g.sealed[idx])
+			if err != nil {
+				return nil, err
+			}
+
+			sealed[g.sealed[idx].name] = variants
+		}
+	}
+
+	imports := []string{"encoding/json"}
+	if g.Options.UnionTypes {
+		imports = append([]string{"bytes"}, imports...)
+	}
+
+	if len(sealed) > 0 || g.Options.UnionTypes {
+		imports = append(imports, "fmt")
+	}
+
+	if g.Options.JSONNumber {
+		imports = append(imports, "strconv")
+	}
+
+	g.writeHeader(&buf, "protocol", imports...)
 
 	for _, strc := range g.Model.Structures {
 		if strc.Proposed {
@@ -83,7 +123,7 @@ func (g *Generator) generateTypes() ([]byte, error) { //nolint:gocognit,cyclop,f
 
 			writeFieldDoc(&buf, prop.Documentation)
 
-			goType := optionalType(g.resolveGoType(&prop.Type), prop.Optional)
+			goType := g.fieldType(strc.Name, &prop)
 			_, _ = fmt.Fprintf(
 				&buf,
 				"\t%s %s %s\n",
@@ -94,6 +134,20 @@ func (g *Generator) generateTypes() ([]byte, error) { //nolint:gocognit,cyclop,f
 		}
 
 		_, _ = fmt.Fprintf(&buf, "}\n\n")
+
+		if g.Options.JSONNumber {
+			writeNumberAccessors(&buf, strc.Name, props)
+		}
+
+		if len(sealed) > 0 {
+			g.writeSealedUnmarshal(&buf, strc.Name, props)
+		}
+	}
+
+	for idx := range g.sealed {
+		if variants, ok := sealed[g.sealed[idx].name]; ok {
+			writeSealedUnion(&buf, &g.sealed[idx], variants)
+		}
 	}
 
 	for _, enum := range g.Model.Enumerations {
@@ -139,10 +193,22 @@ func (g *Generator) generateTypes() ([]byte, error) { //nolint:gocognit,cyclop,f
 		}
 
 		writeDoc(&buf, alias.Documentation, alias.Name)
-		goType := g.resolveGoType(&alias.Type)
+
+		// LSPAny is the union of every JSON value; any holds it as well as a
+		// wrapper struct would.
+		goType := "any"
+		if alias.Name != "LSPAny" {
+			goType = g.resolveGoType(&alias.Type)
+		}
 		_, _ = fmt.Fprintf(&buf, "type %s = %s\n\n", alias.Name, goType)
 	}
 
+	if g.Options.UnionTypes {
+		// Unions used only by methods must be declared here too, and
+		// resolving them may promote further literals.
+		g.resolveUnionMethodTypes()
+	}
+
 	if len(g.namedLiterals) > 0 {
 		names := make([]string, 0, len(g.namedLiterals))
 		for name := range g.namedLiterals {
@@ -161,7 +227,7 @@ func (g *Generator) generateTypes() ([]byte, error) { //nolint:gocognit,cyclop,f
 				}
 
 				writeFieldDoc(&buf, prop.Documentation)
-				goType := optionalType(g.resolveGoType(&prop.Type), prop.Optional)
+				goType := g.fieldType(name, &prop)
 				_, _ = fmt.Fprintf(
 					&buf,
 					"\t%s %s %s\n",
@@ -175,14 +241,74 @@ func (g *Generator) generateTypes() ([]byte, error) { //nolint:gocognit,cyclop,f
 		}
 	}
 
+	if g.Options.UnionTypes {
+		if g.unionErr != nil {
+			return nil, g.unionErr
+		}
+
+		writeUnionHelpers(&buf)
+
+		for _, name := range slices.Sorted(maps.Keys(g.unions)) {
+			writeUnionType(&buf, g.unions[name])
+		}
+	}
+
+	g.writeNotificationConstructors(&buf)
+
 	buf.WriteString("// Ensure json import is used.\nvar _ = json.RawMessage{}\n")
 
+	if g.Options.JSONNumber {
+		buf.WriteString("\n// Ensure strconv import is used.\nvar _ = strconv.ParseInt\n")
+	}
+
 	return buf.Bytes(), nil
 }
 
+// writeNumberAccessors emits typed accessor methods for the json.Number
+// fields of a structure generated with Options.JSONNumber. For a decimal field
+// Red this is RedFloat64; integer and uinteger fields get Int32/Uint32
+// accessors that fail when the value does not fit.
+func writeNumberAccessors(buf *bytes.Buffer, structName string, props []Property) {
+	for _, prop := range props {
+		if prop.Proposed || prop.Type.Kind != "base" {
+			continue
+		}
+
+		goType := numericAccessor(prop.Type.Name)
+		if goType == "" {
+			continue
+		}
+
+		field := GoFieldName(prop.Name)
+		method := field + strings.ToUpper(goType[:1]) + goType[1:]
+
+		_, _ = fmt.Fprintf(buf, "// %s returns %s as a %s.\n", method, field, goType)
+		_, _ = fmt.Fprintf(buf, "func (s %s) %s() (%s, error) {\n", structName, method, goType)
+
+		value := "s." + field
+		if prop.Optional {
+			_, _ = fmt.Fprintf(buf, "\tif s.%s == nil {\n\t\treturn 0, nil\n\t}\n", field)
+			value = "(*s." + field + ")"
+		}
+
+		switch goType {
+		case "float64":
+			_, _ = fmt.Fprintf(buf, "\treturn %s.Float64()\n", value)
+		case "int32":
+			_, _ = fmt.Fprintf(buf, "\tn, err := strconv.ParseInt(string(%s), 10, 32)\n", value)
+			buf.WriteString("\treturn int32(n), err\n")
+		case "uint32":
+			_, _ = fmt.Fprintf(buf, "\tn, err := strconv.ParseUint(string(%s), 10, 32)\n", value)
+			buf.WriteString("\treturn uint32(n), err\n")
+		}
+
+		buf.WriteString("}\n\n")
+	}
+}
+
 // generateServer emits server_gen.go containing the Server interface and the
 // dispatch function (serverDispatch).
-func (g *Generator) generateServer() ([]byte, error) { //nolint:funlen,unparam
+func (g *Generator) generateServer() ([]byte, error) { //nolint:funlen
 	var buf bytes.Buffer
 
 	buf.Grow(40 * 1024) //nolint:mnd
@@ -200,26 +326,39 @@ func (g *Generator) generateServer() ([]byte, error) { //nolint:funlen,unparam
 	buf.WriteString("// LSP method name constants.\n")
 	buf.WriteString("const (\n")
 
-	emitted := make(map[string]bool)
+	// emitted maps each constant name to its method. Methods sent in both
+	// directions appear in both lists and share a constant; two distinct
+	// methods normalizing to the same name would hide one of them.
+	emitted := make(map[string]string)
 
-	for _, m := range serverMethods {
+	for _, m := range slices.Concat(serverMethods, clientMethods) {
 		constName := methodConstName(m.method)
-		if constName != "" && !emitted[constName] {
-			emitted[constName] = true
-			_, _ = fmt.Fprintf(&buf, "\t%s = %q\n", constName, m.method)
+		if constName == "" {
+			continue
 		}
-	}
 
-	for _, m := range clientMethods {
-		constName := methodConstName(m.method)
-		if constName != "" && !emitted[constName] {
-			emitted[constName] = true
-			_, _ = fmt.Fprintf(&buf, "\t%s = %q\n", constName, m.method)
+		if prev, ok := emitted[constName]; ok {
+			if prev != m.method {
+				return nil, fmt.Errorf( //nolint:err113
+					"methods %q and %q both map to constant %s",
+					prev,
+					m.method,
+					constName,
+				)
+			}
+
+			continue
 		}
+
+		emitted[constName] = m.method
+		_, _ = fmt.Fprintf(&buf, "\t%s = %q\n", constName, m.method)
 	}
 
 	buf.WriteString(")\n\n")
 
+	g.writeMethodSets(&buf)
+	g.writeDynamicRegistration(&buf, emitted)
+
 	buf.WriteString("// Server defines the interface for an LSP server.\n")
 	buf.WriteString("// All methods correspond to LSP requests and notifications\n")
 	buf.WriteString("// directed from client to server.\n")
@@ -233,7 +372,8 @@ func (g *Generator) generateServer() ([]byte, error) { //nolint:funlen,unparam
 	buf.WriteString("\n")
 	buf.WriteString("\t// Request is a catch-all handler for any LSP method not covered by the\n")
 	buf.WriteString("\t// interface above.  The method string is the raw LSP method name and\n")
-	buf.WriteString("\t// params is the already-decoded parameter value.\n")
+	buf.WriteString("\t// params is the already-decoded parameter value: a pointer to the type\n")
+	buf.WriteString("\t// registered via RegisterCustomMethod, or a plain decoded any otherwise.\n")
 	buf.WriteString("\tRequest(ctx context.Context, method string, params any) (any, error)\n")
 	buf.WriteString("}\n\n")
 
@@ -256,11 +396,9 @@ func (g *Generator) generateServer() ([]byte, error) { //nolint:funlen,unparam
 	}
 
 	buf.WriteString("\tdefault:\n")
-	buf.WriteString("\t\tvar params any\n")
-	buf.WriteString("\t\tif req.Params() != nil {\n")
-	buf.WriteString("\t\t\tif err := json.Unmarshal(req.Params(), &params); err != nil {\n")
-	buf.WriteString("\t\t\t\treturn replyParseError(ctx, reply, err)\n")
-	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\tparams, err := decodeCustomParams(req.Method(), req.Params())\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\treturn replyParseError(ctx, reply, err)\n")
 	buf.WriteString("\t\t}\n")
 	buf.WriteString("\t\tresp, err := server.Request(ctx, req.Method(), params)\n")
 	buf.WriteString("\t\treturn reply(ctx, resp, err)\n")
@@ -270,6 +408,108 @@ func (g *Generator) generateServer() ([]byte, error) { //nolint:funlen,unparam
 	return buf.Bytes(), nil
 }
 
+// writeMethodSets emits lookup tables of the requests, in either direction,
+// that support partial results or work done progress.
+func (g *Generator) writeMethodSets(buf *bytes.Buffer) {
+	var partial, workDone []string
+
+	for _, r := range g.Model.Requests {
+		if r.Proposed {
+			continue
+		}
+
+		if r.PartialResult != nil {
+			partial = append(partial, r.Method)
+		}
+
+		if g.paramsHaveProperty(r.Params, "workDoneToken") {
+			workDone = append(workDone, r.Method)
+		}
+	}
+
+	buf.WriteString("// MethodsWithPartialResult holds the requests whose results may be\n")
+	buf.WriteString("// streamed in chunks via $/progress when the client sends a\n")
+	buf.WriteString("// partialResultToken.\n")
+	writeMethodSet(buf, "MethodsWithPartialResult", partial)
+
+	buf.WriteString("// MethodsWithWorkDoneProgress holds the requests whose params accept a\n")
+	buf.WriteString("// workDoneToken for reporting work done progress.\n")
+	writeMethodSet(buf, "MethodsWithWorkDoneProgress", workDone)
+}
+
+func writeMethodSet(buf *bytes.Buffer, name string, methods []string) {
+	slices.Sort(methods)
+
+	_, _ = fmt.Fprintf(buf, "var %s = map[string]bool{\n", name)
+
+	for _, method := range methods {
+		_, _ = fmt.Fprintf(buf, "\t%s: true,\n", methodConstName(method))
+	}
+
+	buf.WriteString("}\n\n")
+}
+
+// writeDynamicRegistration emits the table of the methods a server may
+// register dynamically (see dynamicRegistrationPaths). A method is keyed by
+// its constant in consts, which maps constant names to methods, or else by
+// its literal name, as for registration methods such as
+// textDocument/semanticTokens that are not sent themselves.
+func (g *Generator) writeDynamicRegistration(buf *bytes.Buffer, consts map[string]string) {
+	present := make(map[string]bool)
+
+	for _, r := range g.Model.Requests {
+		if !r.Proposed && r.MessageDirection != "serverToClient" {
+			present[r.Method] = true
+			present[r.RegistrationMethod] = true
+		}
+	}
+
+	for _, n := range g.Model.Notifications {
+		if !n.Proposed && n.MessageDirection != "serverToClient" {
+			present[n.Method] = true
+			present[n.RegistrationMethod] = true
+		}
+	}
+
+	buf.WriteString("// methodDynamicRegistration maps the methods a server may register via\n")
+	buf.WriteString("// client/registerCapability to the dotted path of the client capability\n")
+	buf.WriteString("// whose dynamicRegistration property allows it.\n")
+	buf.WriteString("var methodDynamicRegistration = map[string]string{\n")
+
+	for _, method := range slices.Sorted(maps.Keys(dynamicRegistrationPaths)) {
+		if !present[method] {
+			continue
+		}
+
+		key := strconv.Quote(method)
+		if constName := methodConstName(method); consts[constName] == method {
+			key = constName
+		}
+
+		_, _ = fmt.Fprintf(buf, "\t%s: %q,\n", key, dynamicRegistrationPaths[method])
+	}
+
+	buf.WriteString("}\n\n")
+}
+
+// paramsHaveProperty reports whether params references a structure that has
+// a property called name, including properties inherited via extends and
+// mixins.
+func (g *Generator) paramsHaveProperty(params *Type, name string) bool {
+	if params == nil || params.Kind != "reference" {
+		return false
+	}
+
+	strc, ok := g.structs[params.Name]
+	if !ok {
+		return false
+	}
+
+	return slices.ContainsFunc(g.collectProperties(strc), func(p Property) bool {
+		return p.Name == name
+	})
+}
+
 // generateClient emits client_gen.go containing the Client interface and the
 // clientDispatcher implementation.
 func (g *Generator) generateClient() ([]byte, error) { //nolint:unparam
@@ -295,6 +535,8 @@ func (g *Generator) generateClient() ([]byte, error) { //nolint:unparam
 
 	buf.WriteString("}\n\n")
 
+	writeClientCapabilities(&buf, clientMethods)
+
 	buf.WriteString("type clientDispatcher struct {\n")
 	buf.WriteString("\tconn jsonrpc2.Conn\n")
 	buf.WriteString("\tlogger Logger\n")
@@ -309,11 +551,20 @@ func (g *Generator) generateClient() ([]byte, error) { //nolint:unparam
 		"// The logger parameter is used for protocol-level logging. Pass NopLogger()\n",
 	)
 	buf.WriteString("// (or nil) to disable logging.\n")
+	buf.WriteString("//\n")
+	buf.WriteString(
+		"// Error responses to requests are returned as *ResponseError, so they can be\n",
+	)
+	buf.WriteString(
+		"// tested with errors.Is against ErrMethodNotFound and the like.\n",
+	)
 	buf.WriteString("func ClientDispatcher(conn jsonrpc2.Conn, logger Logger) Client {\n")
 	buf.WriteString("\tif logger == nil {\n")
 	buf.WriteString("\t\tlogger = NopLogger()\n")
 	buf.WriteString("\t}\n")
-	buf.WriteString("\treturn &clientDispatcher{conn: conn, logger: logger}\n")
+	buf.WriteString(
+		"\treturn &clientDispatcher{conn: responseErrorConn{Conn: conn}, logger: logger}\n",
+	)
 	buf.WriteString("}\n\n")
 
 	for _, m := range clientMethods {
@@ -323,6 +574,350 @@ func (g *Generator) generateClient() ([]byte, error) { //nolint:unparam
 	return buf.Bytes(), nil
 }
 
+// writeClientCapabilities emits the table of the server→client methods in
+// methods that are gated by a client capability (see clientCapabilityPaths).
+func writeClientCapabilities(buf *bytes.Buffer, methods []methodInfo) {
+	buf.WriteString("// methodClientCapability maps server→client methods to the dotted path of\n")
+	buf.WriteString("// the client capability announcing that the client accepts them.\n")
+	buf.WriteString("var methodClientCapability = map[string]string{\n")
+
+	for _, m := range methods {
+		if path, ok := clientCapabilityPaths[m.method]; ok {
+			_, _ = fmt.Fprintf(buf, "\t%s: %q,\n", methodConstName(m.method), path)
+		}
+	}
+
+	buf.WriteString("}\n\n")
+}
+
+// ctorParam is a single parameter of a generated params constructor.
+type ctorParam struct {
+	name   string // Go parameter name
+	goType string // Go parameter type
+}
+
+// writeNotificationConstructors emits a NewXxxParams constructor for every
+// client→server notification whose params consist only of straightforward
+// required fields: base types and enums, optionally nested one struct deep
+// (e.g. DidOpenTextDocumentParams.TextDocument). Optional fields are left
+// unset; notifications with unions, arrays or maps in their required fields
+// are skipped.
+func (g *Generator) writeNotificationConstructors(buf *bytes.Buffer) {
+	for _, meth := range g.collectServerMethods() {
+		if meth.isRequest {
+			continue
+		}
+
+		notif, ok := g.notifs[meth.method]
+		if !ok || notif.Params == nil || notif.Params.Kind != "reference" {
+			continue
+		}
+
+		strc, ok := g.structs[notif.Params.Name]
+		if !ok {
+			continue
+		}
+
+		var params []ctorParam
+
+		body, ok := g.ctorLiteral(strc, 0, &params)
+		if !ok || len(params) == 0 || hasDuplicateParams(params) {
+			continue
+		}
+
+		args := make([]string, 0, len(params))
+		for _, param := range params {
+			args = append(args, param.name+" "+param.goType)
+		}
+
+		_, _ = fmt.Fprintf(buf, "// New%s returns a %s for the %q notification.\n",
+			strc.Name, strc.Name, meth.method)
+		_, _ = fmt.Fprintf(buf, "func New%s(%s) %s {\n", strc.Name, strings.Join(args, ", "), strc.Name)
+		_, _ = fmt.Fprintf(buf, "\treturn %s\n", body)
+		buf.WriteString("}\n\n")
+	}
+}
+
+// ctorLiteral builds the composite literal for strc, appending a constructor
+// parameter for every required simple field. Nested structures are expanded
+// up to one level deep. It reports false when a required field cannot be
+// expressed as a straightforward parameter.
+func (g *Generator) ctorLiteral(strc *Structure, depth int, params *[]ctorParam) (string, bool) {
+	fields := make([]string, 0, len(strc.Properties))
+
+	for _, prop := range g.collectProperties(strc) {
+		if prop.Proposed || prop.Optional {
+			continue
+		}
+
+		field := GoFieldName(prop.Name)
+
+		if goType, ok := g.simpleCtorType(&prop.Type); ok {
+			name := goParamName(prop.Name)
+			*params = append(*params, ctorParam{name: name, goType: goType})
+			fields = append(fields, field+": "+name)
+
+			continue
+		}
+
+		nested, ok := g.structs[prop.Type.Name]
+		if prop.Type.Kind != "reference" || !ok || depth > 0 {
+			return "", false
+		}
+
+		before := len(*params)
+
+		lit, ok := g.ctorLiteral(nested, depth+1, params)
+		if !ok || len(*params) == before {
+			return "", false
+		}
+
+		fields = append(fields, field+": "+lit)
+	}
+
+	return strc.Name + "{" + strings.Join(fields, ", ") + "}", true
+}
+
+// simpleCtorType reports the Go type of typ if it is a base type or an
+// enumeration that can be passed directly as a constructor parameter.
+func (g *Generator) simpleCtorType(typ *Type) (string, bool) {
+	switch typ.Kind {
+	case "base":
+		switch typ.Name {
+		case "null", "LSPAny", "LSPObject", "LSPArray":
+			return "", false
+		default:
+			return g.resolveGoType(typ), true
+		}
+	case "reference":
+		if enum, ok := g.enums[typ.Name]; ok && !enum.Proposed {
+			return enum.Name, true
+		}
+
+		return "", false
+	default:
+		return "", false
+	}
+}
+
+// goParamName converts an LSP property name into a Go parameter name that is
+// not a Go keyword. Initialisms are upper-cased as in Go field names, also
+// within the name: languageId gives languageID.
+func goParamName(lspName string) string {
+	name := upperInitialisms(GoFieldName(lspName))
+
+	runes := []rune(name)
+	for idx := range runes {
+		if idx > 0 && idx+1 < len(runes) && unicode.IsLower(runes[idx+1]) {
+			break
+		}
+
+		if !unicode.IsUpper(runes[idx]) {
+			break
+		}
+
+		runes[idx] = unicode.ToLower(runes[idx])
+	}
+
+	name = string(runes)
+	if token.IsKeyword(name) {
+		name += "Value"
+	}
+
+	return name
+}
+
+// upperInitialisms upper-cases the words of the camel-case name that are one
+// of abbreviationPrefixes, optionally followed by digits: LanguageId gives
+// LanguageID and PositionUtf8 gives PositionUTF8.
+func upperInitialisms(name string) string {
+	var buf strings.Builder
+
+	start := 0
+
+	for idx := 1; idx <= len(name); idx++ {
+		if idx < len(name) && !unicode.IsUpper(rune(name[idx])) {
+			continue
+		}
+
+		word := name[start:idx]
+		letters := strings.TrimRightFunc(word, unicode.IsDigit)
+
+		for _, abbr := range abbreviationPrefixes {
+			if letters == abbr.mixed {
+				word = abbr.upper + word[len(letters):]
+				break
+			}
+		}
+
+		buf.WriteString(word)
+
+		start = idx
+	}
+
+	return buf.String()
+}
+
+// hasDuplicateParams reports whether two constructor parameters share a name.
+func hasDuplicateParams(params []ctorParam) bool {
+	seen := make(map[string]bool, len(params))
+	for _, param := range params {
+		if seen[param.name] {
+			return true
+		}
+
+		seen[param.name] = true
+	}
+
+	return false
+}
+
+// roundTripStructures lists the structures covered by the generated JSON
+// round-trip tests. They are representative of the shapes the generator emits:
+// nested structs, enums, URIs, optional pointers and slices.
+var roundTripStructures = []string{ //nolint:gochecknoglobals
+	"Position",
+	"Range",
+	"Location",
+	"TextEdit",
+	"TextDocumentItem",
+	"MarkupContent",
+	"Diagnostic",
+	"ServerInfo",
+}
+
+// maxSampleDepth bounds the recursion of sampleValue for self-referential
+// structures.
+const maxSampleDepth = 4
+
+// generateRoundTripTests emits types_roundtrip_gen_test.go. For each curated
+// structure it fills the required fields with sample values, marshals,
+// unmarshals, and asserts that the result is structurally equal.
+func (g *Generator) generateRoundTripTests() ([]byte, error) { //nolint:unparam
+	var buf bytes.Buffer
+
+	g.writeHeader(&buf, "protocol",
+		"encoding/json",
+		"reflect",
+		"testing",
+	)
+
+	for _, name := range roundTripStructures {
+		strc, ok := g.structs[name]
+		if !ok || strc.Proposed {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&buf, "func TestGeneratedRoundTrip_%s(t *testing.T) {\n", name)
+		_, _ = fmt.Fprintf(&buf, "\torig := %s\n", g.sampleStruct(strc, 0))
+		buf.WriteString("\tdata, err := json.Marshal(orig)\n")
+		buf.WriteString("\tif err != nil {\n")
+		buf.WriteString("\t\tt.Fatalf(\"marshal: %v\", err)\n")
+		buf.WriteString("\t}\n")
+		_, _ = fmt.Fprintf(&buf, "\tvar got %s\n", name)
+		buf.WriteString("\tif err := json.Unmarshal(data, &got); err != nil {\n")
+		buf.WriteString("\t\tt.Fatalf(\"unmarshal: %v\", err)\n")
+		buf.WriteString("\t}\n")
+		buf.WriteString("\tif !reflect.DeepEqual(orig, got) {\n")
+		buf.WriteString("\t\tt.Fatalf(\"round trip mismatch:\\n got  %#v\\n want %#v\", got, orig)\n")
+		buf.WriteString("\t}\n")
+		buf.WriteString("}\n\n")
+	}
+
+	buf.WriteString("// Ensure imports are used.\n")
+	buf.WriteString("var (\n\t_ = json.Marshal\n\t_ = reflect.DeepEqual\n\t_ testing.TB\n)\n")
+
+	return buf.Bytes(), nil
+}
+
+// sampleStruct returns a Go composite literal for strc with every required
+// field set to a sample value. Optional fields are left at their zero value.
+func (g *Generator) sampleStruct(strc *Structure, depth int) string {
+	fields := make([]string, 0, len(strc.Properties))
+
+	for _, prop := range g.collectProperties(strc) {
+		if prop.Proposed || prop.Optional {
+			continue
+		}
+
+		if val := g.sampleValue(&prop.Type, depth+1); val != "" {
+			fields = append(fields, GoFieldName(prop.Name)+": "+val)
+		}
+	}
+
+	return strc.Name + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// sampleValue returns a Go expression holding a sample value of the given
+// type, or "" when the type has no meaningful sample (unions, maps, any).
+func (g *Generator) sampleValue(typ *Type, depth int) string { //nolint:cyclop
+	if typ == nil || depth > maxSampleDepth {
+		return ""
+	}
+
+	switch typ.Kind {
+	case "base":
+		if g.Options.JSONNumber && numericAccessor(typ.Name) != "" {
+			return strconv.Quote(sampleBaseValue(typ.Name))
+		}
+
+		return sampleBaseValue(typ.Name)
+	case "reference":
+		if strc, ok := g.structs[typ.Name]; ok && !strc.Proposed {
+			return g.sampleStruct(strc, depth)
+		}
+
+		if enum, ok := g.enums[typ.Name]; ok && !enum.Proposed {
+			for _, val := range enum.Values {
+				if !val.Proposed {
+					return GoEnumValueName(enum.Name, val.Name)
+				}
+			}
+		}
+
+		if alias, ok := g.aliases[typ.Name]; ok && !alias.Proposed {
+			return g.sampleValue(&alias.Type, depth)
+		}
+
+		return ""
+	case "array":
+		elem := g.sampleValue(typ.Element, depth+1)
+		if elem == "" {
+			return ""
+		}
+
+		return g.resolveGoType(typ) + "{" + elem + "}"
+	case "stringLiteral":
+		return strconv.Quote(typ.StringValue)
+	case "integerLiteral":
+		return strconv.FormatInt(typ.IntValue, 10)
+	case "booleanLiteral":
+		return strconv.FormatBool(typ.BoolValue)
+	default:
+		return ""
+	}
+}
+
+// sampleBaseValue returns a sample Go expression for an LSP base type.
+func sampleBaseValue(name string) string {
+	switch name {
+	case "string", "RegExp":
+		return `"sample"`
+	case "DocumentUri":
+		return `"file:///sample.go"`
+	case "URI":
+		return `"https://example.com"`
+	case "integer", "uinteger":
+		return "7"
+	case "decimal":
+		return "0.5"
+	case "boolean":
+		return "true"
+	default:
+		return ""
+	}
+}
+
 // collectServerMethods returns all methods that belong on the Server interface
 // (clientToServer and both directions), sorted by method name.
 func (g *Generator) collectServerMethods() []methodInfo {
@@ -333,7 +928,7 @@ func (g *Generator) collectServerMethods() []methodInfo {
 			continue
 		}
 
-		methods = append(methods, g.buildRequestMethod(&r))
+		methods = append(methods, g.buildRequestMethod(&r, g.Options.ConcreteErrors))
 	}
 
 	for _, n := range g.Model.Notifications {
@@ -344,7 +939,7 @@ func (g *Generator) collectServerMethods() []methodInfo {
 		methods = append(methods, g.buildNotificationMethod(&n))
 	}
 
-	disambiguateMethods(methods)
+	disambiguateMethods(methods, g.methodOverrides())
 
 	slices.SortFunc(methods, func(a, b methodInfo) int {
 		return cmp.Compare(a.method, b.method)
@@ -363,7 +958,7 @@ func (g *Generator) collectClientMethods() []methodInfo {
 			continue
 		}
 
-		methods = append(methods, g.buildRequestMethod(&r))
+		methods = append(methods, g.buildRequestMethod(&r, false))
 	}
 
 	for _, n := range g.Model.Notifications {
@@ -374,7 +969,7 @@ func (g *Generator) collectClientMethods() []methodInfo {
 		methods = append(methods, g.buildNotificationMethod(&n))
 	}
 
-	disambiguateMethods(methods)
+	disambiguateMethods(methods, g.methodOverrides())
 
 	slices.SortFunc(methods, func(a, b methodInfo) int {
 		return cmp.Compare(a.method, b.method)
@@ -383,17 +978,31 @@ func (g *Generator) collectClientMethods() []methodInfo {
 	return methods
 }
 
+// methodOverrides returns the method name overrides to apply: the built-in
+// methodNameOverrides (unless Options.SpecNames is set) merged with
+// Options.MethodNameOverrides, the latter taking precedence.
+func (g *Generator) methodOverrides() map[string]string {
+	overrides := make(map[string]string, len(methodNameOverrides)+len(g.Options.MethodNameOverrides))
+	if !g.Options.SpecNames {
+		maps.Copy(overrides, methodNameOverrides)
+	}
+
+	maps.Copy(overrides, g.Options.MethodNameOverrides)
+
+	return overrides
+}
+
 // disambiguateMethods detects Go name collisions and switches colliding entries
 // to their fully-qualified names, unless a preferred name is specified in
-// methodNameOverrides. Overridden methods are pinned to their override name
-// and never renamed by the collision resolver.
-func disambiguateMethods(methods []methodInfo) {
+// overrides. Overridden methods are pinned to their override name and never
+// renamed by the collision resolver.
+func disambiguateMethods(methods []methodInfo, overrides map[string]string) {
 	pinned := make(map[int]bool, len(methods))
 
 	// Apply overrides first: some methods keep legacy short names for
 	// backward compatibility with go.lsp.dev/protocol v0.12.0.
 	for idx := range methods {
-		if override, ok := methodNameOverrides[methods[idx].method]; ok {
+		if override, ok := overrides[methods[idx].method]; ok {
 			methods[idx].signature = strings.Replace(
 				methods[idx].signature,
 				methods[idx].goName+"(",
@@ -468,37 +1077,117 @@ var methodNameOverrides = map[string]string{ //nolint:gosec,gochecknoglobals
 	"window/workDoneProgress/cancel": "WorkDoneProgressCancel",
 }
 
-func (g *Generator) buildRequestMethod(req *Request) methodInfo {
+// clientCapabilityPaths maps server→client methods to the client capability
+// that gates them, curated from the specification since metaModel.json does
+// not record it. Methods absent from the model are skipped; methods gated
+// per feature (client/registerCapability) or always allowed (window/logMessage)
+// have no entry.
+var clientCapabilityPaths = map[string]string{ //nolint:gochecknoglobals
+	"window/showDocument":              "window.showDocument.support",
+	"window/workDoneProgress/create":   "window.workDoneProgress",
+	"workspace/applyEdit":              "workspace.applyEdit",
+	"workspace/codeLens/refresh":       "workspace.codeLens.refreshSupport",
+	"workspace/configuration":          "workspace.configuration",
+	"workspace/diagnostic/refresh":     "workspace.diagnostics.refreshSupport",
+	"workspace/foldingRange/refresh":   "workspace.foldingRange.refreshSupport",
+	"workspace/inlayHint/refresh":      "workspace.inlayHint.refreshSupport",
+	"workspace/inlineValue/refresh":    "workspace.inlineValue.refreshSupport",
+	"workspace/semanticTokens/refresh": "workspace.semanticTokens.refreshSupport",
+	"workspace/workspaceFolders":       "workspace.workspaceFolders",
+}
+
+// dynamicRegistrationPaths maps the methods a server registers via
+// client/registerCapability to the client capability holding the matching
+// dynamicRegistration property, curated from the specification since
+// metaModel.json does not record it. Most paths follow the method name; the
+// synchronization, file operation and hierarchy methods share or rename
+// theirs. Methods absent from the model are skipped.
+var dynamicRegistrationPaths = map[string]string{ //nolint:gochecknoglobals
+	"notebookDocument/sync":             "notebookDocument.synchronization",
+	"textDocument/codeAction":           "textDocument.codeAction",
+	"textDocument/codeLens":             "textDocument.codeLens",
+	"textDocument/completion":           "textDocument.completion",
+	"textDocument/declaration":          "textDocument.declaration",
+	"textDocument/definition":           "textDocument.definition",
+	"textDocument/diagnostic":           "textDocument.diagnostic",
+	"textDocument/didChange":            "textDocument.synchronization",
+	"textDocument/didClose":             "textDocument.synchronization",
+	"textDocument/didOpen":              "textDocument.synchronization",
+	"textDocument/didSave":              "textDocument.synchronization",
+	"textDocument/documentColor":        "textDocument.colorProvider",
+	"textDocument/documentHighlight":    "textDocument.documentHighlight",
+	"textDocument/documentLink":         "textDocument.documentLink",
+	"textDocument/documentSymbol":       "textDocument.documentSymbol",
+	"textDocument/foldingRange":         "textDocument.foldingRange",
+	"textDocument/formatting":           "textDocument.formatting",
+	"textDocument/hover":                "textDocument.hover",
+	"textDocument/implementation":       "textDocument.implementation",
+	"textDocument/inlayHint":            "textDocument.inlayHint",
+	"textDocument/inlineValue":          "textDocument.inlineValue",
+	"textDocument/linkedEditingRange":   "textDocument.linkedEditingRange",
+	"textDocument/moniker":              "textDocument.moniker",
+	"textDocument/onTypeFormatting":     "textDocument.onTypeFormatting",
+	"textDocument/prepareCallHierarchy": "textDocument.callHierarchy",
+	"textDocument/prepareTypeHierarchy": "textDocument.typeHierarchy",
+	"textDocument/rangeFormatting":      "textDocument.rangeFormatting",
+	"textDocument/references":           "textDocument.references",
+	"textDocument/rename":               "textDocument.rename",
+	"textDocument/selectionRange":       "textDocument.selectionRange",
+	"textDocument/semanticTokens":       "textDocument.semanticTokens",
+	"textDocument/signatureHelp":        "textDocument.signatureHelp",
+	"textDocument/typeDefinition":       "textDocument.typeDefinition",
+	"textDocument/willSave":             "textDocument.synchronization",
+	"textDocument/willSaveWaitUntil":    "textDocument.synchronization",
+	"workspace/didChangeConfiguration":  "workspace.didChangeConfiguration",
+	"workspace/didChangeWatchedFiles":   "workspace.didChangeWatchedFiles",
+	"workspace/didCreateFiles":          "workspace.fileOperations",
+	"workspace/didDeleteFiles":          "workspace.fileOperations",
+	"workspace/didRenameFiles":          "workspace.fileOperations",
+	"workspace/executeCommand":          "workspace.executeCommand",
+	"workspace/symbol":                  "workspace.symbol",
+	"workspace/willCreateFiles":         "workspace.fileOperations",
+	"workspace/willDeleteFiles":         "workspace.fileOperations",
+	"workspace/willRenameFiles":         "workspace.fileOperations",
+}
+
+func (g *Generator) buildRequestMethod(req *Request, concreteErr bool) methodInfo {
 	goName := GoMethodName(req.Method)
 	paramsType := g.resolveMethodType(req.Params)
 	resultType := g.resolveMethodType(req.Result)
 
+	errType := "error"
+	if concreteErr {
+		errType = "*ResponseError"
+	}
+
 	var sig string
 
 	switch {
 	case paramsType != "" && resultType != "":
 		sig = fmt.Sprintf(
-			"%s(ctx context.Context, params %s) (%s, error)",
+			"%s(ctx context.Context, params %s) (%s, %s)",
 			goName,
 			paramsType,
 			resultType,
+			errType,
 		)
 	case paramsType != "":
-		sig = fmt.Sprintf("%s(ctx context.Context, params %s) error", goName, paramsType)
+		sig = fmt.Sprintf("%s(ctx context.Context, params %s) %s", goName, paramsType, errType)
 	case resultType != "":
-		sig = fmt.Sprintf("%s(ctx context.Context) (%s, error)", goName, resultType)
+		sig = fmt.Sprintf("%s(ctx context.Context) (%s, %s)", goName, resultType, errType)
 	default:
-		sig = goName + "(ctx context.Context) error"
+		sig = goName + "(ctx context.Context) " + errType
 	}
 
 	return methodInfo{
-		method:     req.Method,
-		goName:     goName,
-		signature:  sig,
-		doc:        req.Documentation,
-		isRequest:  true,
-		paramsType: paramsType,
-		resultType: resultType,
+		method:      req.Method,
+		goName:      goName,
+		signature:   sig,
+		doc:         req.Documentation,
+		isRequest:   true,
+		concreteErr: concreteErr,
+		paramsType:  paramsType,
+		resultType:  resultType,
 	}
 }
 
@@ -603,6 +1292,29 @@ func (g *Generator) collectPropertiesImpl( //nolint:gocognit,cyclop
 	return result
 }
 
+// fieldTypeOverrides maps "Structure.property" to a hand-written Go type
+// used for the field instead of the type resolved from the model. It covers
+// unions too awkward to use as `any`; the types live in the protocol package.
+var fieldTypeOverrides = map[string]string{ //nolint:gochecknoglobals
+	"Hover.contents": "HoverContents",
+}
+
+// fieldType returns the Go type of the field generated for prop in the
+// structure named owner. Sealed union interfaces represent absence with nil
+// and are never wrapped in a pointer.
+func (g *Generator) fieldType(owner string, prop *Property) string {
+	goType, ok := fieldTypeOverrides[owner+"."+prop.Name]
+	if !ok {
+		goType = g.resolveGoType(&prop.Type)
+	}
+
+	if g.isSealedUnion(goType) {
+		return goType
+	}
+
+	return optionalType(goType, prop.Optional)
+}
+
 // optionalType wraps goType in a pointer if the field is optional and the
 // type doesn't already represent nil natively.
 func optionalType(goType string, optional bool) string {
@@ -667,34 +1379,51 @@ func (g *Generator) writeHeader(buf *bytes.Buffer, pkg string, imports ...string
 }
 
 func writeDoc(buf *bytes.Buffer, doc, name string) {
-	if doc != "" {
-		for line := range strings.SplitSeq(strings.TrimSpace(doc), "\n") {
-			_, _ = fmt.Fprintf(buf, "// %s\n", strings.TrimSpace(line))
-		}
-	} else {
+	lines := docLines(doc)
+	if len(lines) == 0 {
 		_, _ = fmt.Fprintf(buf, "// %s is an LSP type.\n", name)
+		return
+	}
+
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(buf, "// %s\n", line)
 	}
 }
 
 func writeFieldDoc(buf *bytes.Buffer, doc string) {
-	if doc == "" {
+	for _, line := range docLines(doc) {
+		_, _ = fmt.Fprintf(buf, "\t// %s\n", line)
+	}
+}
+
+func writeMethodDoc(buf *bytes.Buffer, doc, goName, method string) {
+	lines := docLines(doc)
+	if len(lines) == 0 {
+		_, _ = fmt.Fprintf(buf, "\t// %s handles the %q method.\n", goName, method)
 		return
 	}
 
-	lines := strings.SplitSeq(strings.TrimSpace(doc), "\n")
-	for line := range lines {
-		_, _ = fmt.Fprintf(buf, "\t// %s\n", strings.TrimSpace(line))
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(buf, "\t// %s\n", line)
 	}
 }
 
-func writeMethodDoc(buf *bytes.Buffer, doc, goName, method string) {
-	if doc != "" {
-		for line := range strings.SplitSeq(strings.TrimSpace(doc), "\n") {
-			_, _ = fmt.Fprintf(buf, "\t// %s\n", strings.TrimSpace(line))
+// docLines splits a documentation string into trimmed comment lines. Runs of
+// blank lines collapse into one, and whitespace-only documentation yields no
+// lines at all.
+func docLines(doc string) []string {
+	var lines []string
+
+	for line := range strings.SplitSeq(strings.TrimSpace(doc), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
 		}
-	} else {
-		_, _ = fmt.Fprintf(buf, "\t// %s handles the %q method.\n", goName, method)
+
+		lines = append(lines, line)
 	}
+
+	return lines
 }
 
 // writeRequestDispatch writes the dispatch case for a request (expects a response).
@@ -707,20 +1436,40 @@ func writeRequestDispatch(buf *bytes.Buffer, info *methodInfo) {
 		buf.WriteString("\t\t}\n")
 	}
 
-	switch {
-	case info.paramsType != "" && info.resultType != "":
-		_, _ = fmt.Fprintf(buf, "\t\tresult, err := server.%s(ctx, &params)\n", info.goName)
-		buf.WriteString("\t\treturn reply(ctx, result, err)\n")
-	case info.paramsType != "":
-		_, _ = fmt.Fprintf(buf, "\t\terr := server.%s(ctx, &params)\n", info.goName)
-		buf.WriteString("\t\treturn reply(ctx, nil, err)\n")
-	case info.resultType != "":
-		_, _ = fmt.Fprintf(buf, "\t\tresult, err := server.%s(ctx)\n", info.goName)
-		buf.WriteString("\t\treturn reply(ctx, result, err)\n")
-	default:
-		_, _ = fmt.Fprintf(buf, "\t\terr := server.%s(ctx)\n", info.goName)
-		buf.WriteString("\t\treturn reply(ctx, nil, err)\n")
+	args := "ctx"
+	if info.paramsType != "" {
+		args = "ctx, &params"
+	}
+
+	result := "nil"
+	if info.resultType != "" {
+		result = "result"
 	}
+
+	if info.concreteErr {
+		// A nil *ResponseError is a non-nil error interface, so reply must
+		// only receive err when the handler actually returned one.
+		if info.resultType != "" {
+			_, _ = fmt.Fprintf(buf, "\t\tresult, err := server.%s(%s)\n", info.goName, args)
+		} else {
+			_, _ = fmt.Fprintf(buf, "\t\terr := server.%s(%s)\n", info.goName, args)
+		}
+
+		buf.WriteString("\t\tif err != nil {\n")
+		buf.WriteString("\t\t\treturn reply(ctx, nil, err)\n")
+		buf.WriteString("\t\t}\n")
+		_, _ = fmt.Fprintf(buf, "\t\treturn reply(ctx, %s, nil)\n", result)
+
+		return
+	}
+
+	if info.resultType != "" {
+		_, _ = fmt.Fprintf(buf, "\t\tresult, err := server.%s(%s)\n", info.goName, args)
+	} else {
+		_, _ = fmt.Fprintf(buf, "\t\terr := server.%s(%s)\n", info.goName, args)
+	}
+
+	_, _ = fmt.Fprintf(buf, "\t\treturn reply(ctx, %s, err)\n", result)
 }
 
 // writeNotificationDispatch writes the dispatch case for a notification (no response).
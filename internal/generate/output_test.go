@@ -0,0 +1,412 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package generate
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refType builds a "reference" kind Type.
+func refType(name string) Type {
+	return Type{Kind: "reference", Name: name}
+}
+
+// positionRangeModel returns a small model with the Position and Range structures.
+func positionRangeModel() *Model {
+	return &Model{
+		Structures: []Structure{
+			{
+				Name: "Position",
+				Properties: []Property{
+					baseProp("line", "uinteger"),
+					baseProp("character", "uinteger"),
+				},
+			},
+			{
+				Name: "Range",
+				Properties: []Property{
+					{Name: "start", Type: refType("Position")},
+					{Name: "end", Type: refType("Position")},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateRoundTripTests(t *testing.T) {
+	out, err := NewGenerator(positionRangeModel()).Generate()
+	require.NoError(t, err)
+	require.NotEmpty(t, out.RoundTrip)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "types_roundtrip_gen_test.go", out.RoundTrip, 0)
+	require.NoError(t, err, "generated round-trip tests must parse:\n%s", out.RoundTrip)
+	assert.Equal(t, "protocol", file.Name.Name)
+
+	src := string(out.RoundTrip)
+	assert.Contains(t, src, "func TestGeneratedRoundTrip_Position(t *testing.T) {")
+	assert.Contains(t, src, "func TestGeneratedRoundTrip_Range(t *testing.T) {")
+	assert.Contains(
+		t,
+		src,
+		"orig := Range{Start: Position{Line: 7, Character: 7}, End: Position{Line: 7, Character: 7}}",
+	)
+	assert.NotContains(t, src, "TestGeneratedRoundTrip_Location", "absent structures are skipped")
+}
+
+func TestNotificationConstructors(t *testing.T) {
+	model := &Model{
+		Notifications: []Notification{
+			{
+				Method:           "textDocument/didOpen",
+				MessageDirection: "clientToServer",
+				Params:           &Type{Kind: "reference", Name: "DidOpenTextDocumentParams"},
+			},
+			{
+				Method:           "textDocument/didChange",
+				MessageDirection: "clientToServer",
+				Params:           &Type{Kind: "reference", Name: "DidChangeTextDocumentParams"},
+			},
+		},
+		Structures: []Structure{
+			{
+				Name:       "DidOpenTextDocumentParams",
+				Properties: []Property{{Name: "textDocument", Type: refType("TextDocumentItem")}},
+			},
+			{
+				Name: "TextDocumentItem",
+				Properties: []Property{
+					baseProp("uri", "DocumentUri"),
+					{Name: "languageId", Type: refType("LanguageKind")},
+					baseProp("version", "integer"),
+					baseProp("text", "string"),
+				},
+			},
+			{
+				Name: "DidChangeTextDocumentParams",
+				Properties: []Property{{
+					Name: "contentChanges",
+					Type: Type{Kind: "array", Element: &Type{Kind: "base", Name: "LSPAny"}},
+				}},
+			},
+		},
+		Enumerations: []Enumeration{{
+			Name:   "LanguageKind",
+			Type:   EnumBaseType{Kind: "base", Name: "string"},
+			Values: []EnumerationValue{{Name: "Go", Value: "go"}},
+		}},
+	}
+
+	src := generateTypes(t, model)
+
+	assert.Contains(t, src, "func NewDidOpenTextDocumentParams("+
+		"uri DocumentURI, languageID LanguageKind, version int32, text string) DidOpenTextDocumentParams {\n"+
+		"\treturn DidOpenTextDocumentParams{TextDocument: TextDocumentItem{"+
+		"URI: uri, LanguageId: languageID, Version: version, Text: text}}\n}")
+	assert.NotContains(t, src, "func NewDidChangeTextDocumentParams(", "array params are skipped")
+}
+
+func TestGoParamName(t *testing.T) {
+	for lspName, want := range map[string]string{
+		"uri":           "uri",
+		"documentUri":   "documentURI",
+		"languageId":    "languageID",
+		"resultId":      "resultID",
+		"identifier":    "identifier",
+		"version":       "version",
+		"type":          "typeValue",
+		"positionUtf16": "positionUTF16",
+	} {
+		assert.Equal(t, want, goParamName(lspName), lspName)
+	}
+}
+
+func TestOptionsSpecNames(t *testing.T) {
+	model := &Model{
+		Requests: []Request{{
+			Method:           "textDocument/foldingRange",
+			MessageDirection: "clientToServer",
+			Params:           &Type{Kind: "reference", Name: "FoldingRangeParams"},
+			Result:           &Type{Kind: "array", Element: &Type{Kind: "reference", Name: "FoldingRange"}},
+		}},
+		Structures: []Structure{
+			{Name: "FoldingRangeParams"},
+			{Name: "FoldingRange", Properties: []Property{baseProp("startLine", "uinteger")}},
+		},
+	}
+
+	gen := NewGenerator(model)
+	out, err := gen.generateServer()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "\tFoldingRanges(ctx context.Context, params *FoldingRangeParams)")
+
+	gen = NewGenerator(model)
+	gen.Options.SpecNames = true
+	out, err = gen.generateServer()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "\tFoldingRange(ctx context.Context, params *FoldingRangeParams)")
+	assert.NotContains(t, string(out), "FoldingRanges(")
+}
+
+func TestGenerateServer_DuplicateMethodConstant(t *testing.T) {
+	notification := func(method, direction string) Notification {
+		return Notification{Method: method, MessageDirection: direction}
+	}
+
+	t.Run("shared by both directions", func(t *testing.T) {
+		model := &Model{Notifications: []Notification{notification("$/progress", "both")}}
+
+		out, err := NewGenerator(model).generateServer()
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(out), "\tMethodProgress = \"$/progress\""))
+	})
+
+	t.Run("distinct methods collide", func(t *testing.T) {
+		model := &Model{Notifications: []Notification{
+			notification("$/logTrace", "serverToClient"),
+			notification("logTrace", "clientToServer"),
+		}}
+
+		_, err := NewGenerator(model).generateServer()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"$/logTrace"`)
+		assert.Contains(t, err.Error(), `"logTrace"`)
+		assert.Contains(t, err.Error(), "MethodLogTrace")
+	})
+}
+
+func TestGenerateServer_MethodSets(t *testing.T) {
+	token := Property{Name: "workDoneToken", Optional: true, Type: Type{Kind: "base", Name: "string"}}
+	model := &Model{
+		Requests: []Request{
+			{
+				Method:           "textDocument/references",
+				MessageDirection: "clientToServer",
+				Params:           new(refType("ReferenceParams")),
+				Result:           &Type{Kind: "base", Name: "string"},
+				PartialResult:    &Type{Kind: "base", Name: "string"},
+			},
+			{
+				Method:           "textDocument/hover",
+				MessageDirection: "clientToServer",
+				Params:           new(refType("HoverParams")),
+				Result:           &Type{Kind: "base", Name: "string"},
+			},
+			{
+				Method:           "workspace/configuration",
+				MessageDirection: "serverToClient",
+				Params:           new(refType("ConfigurationParams")),
+				Result:           &Type{Kind: "base", Name: "string"},
+			},
+		},
+		Structures: []Structure{
+			{Name: "WorkDoneProgressParams", Properties: []Property{token}},
+			{Name: "ReferenceParams", Mixins: []Type{refType("WorkDoneProgressParams")}},
+			{Name: "HoverParams", Mixins: []Type{refType("WorkDoneProgressParams")}},
+			{Name: "ConfigurationParams"},
+		},
+	}
+
+	out, err := NewGenerator(model).generateServer()
+	require.NoError(t, err)
+
+	src := string(out)
+	assert.Contains(t, src, "var MethodsWithPartialResult = map[string]bool{\n"+
+		"\tMethodTextDocumentReferences: true,\n"+
+		"}\n")
+	assert.Contains(t, src, "var MethodsWithWorkDoneProgress = map[string]bool{\n"+
+		"\tMethodTextDocumentHover: true,\n"+
+		"\tMethodTextDocumentReferences: true,\n"+
+		"}\n")
+}
+
+func TestGenerateServer_DynamicRegistration(t *testing.T) {
+	model := &Model{
+		Requests: []Request{
+			{
+				Method:           "textDocument/hover",
+				MessageDirection: "clientToServer",
+				Params:           new(refType("HoverParams")),
+				Result:           &Type{Kind: "base", Name: "string"},
+			},
+			{
+				Method:             "textDocument/semanticTokens/full",
+				MessageDirection:   "clientToServer",
+				Params:             new(refType("SemanticTokensParams")),
+				Result:             &Type{Kind: "base", Name: "string"},
+				RegistrationMethod: "textDocument/semanticTokens",
+			},
+			{
+				Method:           "shutdown",
+				MessageDirection: "clientToServer",
+			},
+		},
+		Notifications: []Notification{{
+			Method:           "textDocument/didOpen",
+			MessageDirection: "clientToServer",
+			Params:           new(refType("DidOpenTextDocumentParams")),
+		}},
+		Structures: []Structure{
+			{Name: "HoverParams"},
+			{Name: "SemanticTokensParams"},
+			{Name: "DidOpenTextDocumentParams"},
+		},
+	}
+
+	out, err := NewGenerator(model).generateServer()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "var methodDynamicRegistration = map[string]string{\n"+
+		"\tMethodTextDocumentDidOpen: \"textDocument.synchronization\",\n"+
+		"\tMethodTextDocumentHover: \"textDocument.hover\",\n"+
+		"\t\"textDocument/semanticTokens\": \"textDocument.semanticTokens\",\n"+
+		"}\n")
+}
+
+func TestOptionsMethodNameOverrides(t *testing.T) {
+	model := &Model{
+		Requests: []Request{{
+			Method:           "myServer/reindexWorkspace",
+			MessageDirection: "clientToServer",
+			Params:           &Type{Kind: "reference", Name: "ReindexParams"},
+			Result:           &Type{Kind: "base", Name: "boolean"},
+		}},
+		Notifications: []Notification{{
+			Method:           "textDocument/didOpen",
+			MessageDirection: "clientToServer",
+			Params:           &Type{Kind: "reference", Name: "DidOpenTextDocumentParams"},
+		}},
+		Structures: []Structure{{Name: "ReindexParams"}, {Name: "DidOpenTextDocumentParams"}},
+	}
+
+	gen := NewGenerator(model)
+	gen.Options.MethodNameOverrides = map[string]string{"myServer/reindexWorkspace": "Reindex"}
+
+	out, err := gen.generateServer()
+	require.NoError(t, err)
+
+	src := string(out)
+	assert.Contains(t, src, "\tReindex(ctx context.Context, params *ReindexParams) (bool, error)")
+	assert.Contains(t, src, "result, err := server.Reindex(ctx, &params)")
+	assert.NotContains(t, src, "ReindexWorkspace(")
+	assert.Contains(t, src, "\tDidOpen(ctx context.Context", "built-in overrides still apply")
+}
+
+func TestWriteDoc(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"empty", "", "// Foo is an LSP type.\n"},
+		{"whitespace only", " \n\t\n  ", "// Foo is an LSP type.\n"},
+		{
+			"internal blank lines",
+			"First paragraph.\n\n\n  \nSecond paragraph.\n\n@since 3.17.0\n",
+			"// First paragraph.\n// \n// Second paragraph.\n// \n// @since 3.17.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeDoc(&buf, tt.doc, "Foo")
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	var buf bytes.Buffer
+	writeFieldDoc(&buf, "\n \n")
+	assert.Empty(t, buf.String())
+
+	writeMethodDoc(&buf, "  ", "Hover", "textDocument/hover")
+	assert.Equal(t, "\t// Hover handles the \"textDocument/hover\" method.\n", buf.String())
+}
+
+func TestOptionsConcreteErrors(t *testing.T) {
+	model := &Model{
+		Requests: []Request{
+			{
+				Method:           "textDocument/hover",
+				MessageDirection: "clientToServer",
+				Params:           new(refType("HoverParams")),
+				Result:           new(refType("Hover")),
+			},
+			{Method: "shutdown", MessageDirection: "clientToServer"},
+			{
+				Method:           "workspace/configuration",
+				MessageDirection: "serverToClient",
+				Params:           new(refType("ConfigurationParams")),
+				Result:           &Type{Kind: "base", Name: "string"},
+			},
+		},
+		Notifications: []Notification{{Method: "exit", MessageDirection: "clientToServer"}},
+		Structures:    []Structure{{Name: "HoverParams"}, {Name: "Hover"}, {Name: "ConfigurationParams"}},
+	}
+
+	out, err := NewGenerator(model).generateServer()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "\tHover(ctx context.Context, params *HoverParams) (*Hover, error)\n")
+	assert.Contains(t, string(out), "\t\treturn reply(ctx, result, err)\n")
+
+	gen := NewGenerator(model)
+	gen.Options.ConcreteErrors = true
+
+	out, err = gen.generateServer()
+	require.NoError(t, err)
+
+	src := string(out)
+	assert.Contains(t, src, "\tHover(ctx context.Context, params *HoverParams) (*Hover, *ResponseError)\n")
+	assert.Contains(t, src, "\tShutdown(ctx context.Context) *ResponseError\n")
+	assert.Contains(t, src, "\tExit(ctx context.Context) error\n", "notifications keep error")
+
+	// The dispatcher must not pass a typed nil *ResponseError to reply.
+	assert.Contains(t, src, "\t\tresult, err := server.Hover(ctx, &params)\n"+
+		"\t\tif err != nil {\n"+
+		"\t\t\treturn reply(ctx, nil, err)\n"+
+		"\t\t}\n"+
+		"\t\treturn reply(ctx, result, nil)\n")
+	assert.Contains(t, src, "\t\terr := server.Shutdown(ctx)\n"+
+		"\t\tif err != nil {\n"+
+		"\t\t\treturn reply(ctx, nil, err)\n"+
+		"\t\t}\n"+
+		"\t\treturn reply(ctx, nil, nil)\n")
+	assert.NotContains(t, src, "return reply(ctx, result, err)")
+
+	_, err = parser.ParseFile(token.NewFileSet(), "server_gen.go", out, 0)
+	require.NoError(t, err)
+
+	client, err := gen.generateClient()
+	require.NoError(t, err)
+	assert.Contains(t, string(client), "Configuration(ctx context.Context, params *ConfigurationParams) (string, error)",
+		"client methods keep error")
+}
+
+func TestGenerateClient_MethodClientCapability(t *testing.T) {
+	model := &Model{
+		Requests: []Request{{
+			Method:           "workspace/configuration",
+			MessageDirection: "serverToClient",
+			Params:           new(refType("ConfigurationParams")),
+			Result:           &Type{Kind: "base", Name: "string"},
+		}},
+		Notifications: []Notification{{
+			Method:           "window/logMessage",
+			MessageDirection: "serverToClient",
+			Params:           new(refType("LogMessageParams")),
+		}},
+		Structures: []Structure{{Name: "ConfigurationParams"}, {Name: "LogMessageParams"}},
+	}
+
+	out, err := NewGenerator(model).generateClient()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "var methodClientCapability = map[string]string{\n"+
+		"\tMethodWorkspaceConfiguration: \"workspace.configuration\",\n"+
+		"}\n")
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// sealedUnion describes a closed union of structures emitted as a sealed
+	// interface when Options.SealedUnions is set.
+	sealedUnion struct {
+		// name is the Go name of the interface.
+		name string
+		// discriminator is the JSON property whose string literal value tells
+		// the variants apart. At most one variant may lack it; that variant
+		// is decoded when the property is absent.
+		discriminator string
+		// variants are the structures making up the union.
+		variants []string
+	}
+
+	// sealedVariant is a variant of a sealedUnion together with its
+	// discriminator value ("" for the default variant).
+	sealedVariant struct {
+		name  string
+		value string
+	}
+)
+
+// sealedUnions lists the closed struct unions emitted as sealed interfaces
+// under Options.SealedUnions. A union type matches an entry when its non-null
+// items are references to exactly the entry's variants, in any order.
+var sealedUnions = []sealedUnion{ //nolint:gochecknoglobals
+	{
+		name:          "DocumentChange",
+		discriminator: "kind",
+		variants:      []string{"TextDocumentEdit", "CreateFile", "RenameFile", "DeleteFile"},
+	},
+}
+
+// sealedUnionFor returns the sealed union whose variants are exactly the
+// referenced items, if Options.SealedUnions is set and there is one.
+func (g *Generator) sealedUnionFor(items []Type) (*sealedUnion, bool) {
+	if !g.Options.SealedUnions {
+		return nil, false
+	}
+
+	names := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if item.Kind != "reference" {
+			return nil, false
+		}
+
+		names = append(names, item.Name)
+	}
+
+	slices.Sort(names)
+
+	for idx := range g.sealed {
+		variants := slices.Sorted(slices.Values(g.sealed[idx].variants))
+		if slices.Equal(names, variants) {
+			return &g.sealed[idx], true
+		}
+	}
+
+	return nil, false
+}
+
+// isSealedUnion reports whether goType names a sealed union interface.
+func (g *Generator) isSealedUnion(goType string) bool {
+	if !g.Options.SealedUnions {
+		return false
+	}
+
+	return slices.ContainsFunc(g.sealed, func(u sealedUnion) bool { return u.name == goType })
+}
+
+// sealedVariants resolves the discriminator value of every variant of u from
+// the model. It fails if a variant is missing, or if more than one variant
+// lacks a string literal discriminator.
+func (g *Generator) sealedVariants(u *sealedUnion) ([]sealedVariant, error) {
+	variants := make([]sealedVariant, 0, len(u.variants))
+	defaultVariant := ""
+
+	for _, name := range u.variants {
+		strc, ok := g.structs[name]
+		if !ok || strc.Proposed {
+			return nil, fmt.Errorf("sealed union %s: unknown variant %s", u.name, name)
+		}
+
+		value := ""
+
+		for _, prop := range g.collectProperties(strc) {
+			if prop.Name == u.discriminator && prop.Type.Kind == "stringLiteral" {
+				value = prop.Type.StringValue
+			}
+		}
+
+		if value == "" {
+			if defaultVariant != "" {
+				return nil, fmt.Errorf(
+					"sealed union %s: neither %s nor %s has a %q discriminator",
+					u.name, defaultVariant, name, u.discriminator,
+				)
+			}
+
+			defaultVariant = name
+		}
+
+		variants = append(variants, sealedVariant{name: name, value: value})
+	}
+
+	return variants, nil
+}
+
+// writeSealedUnion emits the interface for u, the marker method and a
+// discriminator-setting MarshalJSON for each variant, and the
+// Unmarshal<Name> function decoding a variant by its discriminator.
+func writeSealedUnion(buf *bytes.Buffer, u *sealedUnion, variants []sealedVariant) {
+	names := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		names = append(names, variant.name)
+	}
+
+	marker := "is" + u.name
+
+	_, _ = fmt.Fprintf(buf, "// %s is one of %s, told apart by their %q property.\n",
+		u.name, strings.Join(names, ", "), u.discriminator)
+	_, _ = fmt.Fprintf(buf, "// Decode one with Unmarshal%s.\n", u.name)
+	_, _ = fmt.Fprintf(buf, "type %s interface {\n\t%s()\n}\n\n", u.name, marker)
+
+	for _, variant := range variants {
+		_, _ = fmt.Fprintf(buf, "func (%s) %s() {}\n\n", variant.name, marker)
+
+		if variant.value == "" {
+			continue
+		}
+
+		field := GoFieldName(u.discriminator)
+
+		_, _ = fmt.Fprintf(buf,
+			"// MarshalJSON encodes v with its %s set to %q.\n", field, variant.value)
+		_, _ = fmt.Fprintf(buf, "func (v %s) MarshalJSON() ([]byte, error) {\n", variant.name)
+		_, _ = fmt.Fprintf(buf, "\ttype plain %s\n", variant.name)
+		_, _ = fmt.Fprintf(buf, "\tv.%s = %q\n", field, variant.value)
+		buf.WriteString("\treturn json.Marshal(plain(v))\n}\n\n")
+	}
+
+	_, _ = fmt.Fprintf(buf,
+		"// Unmarshal%s decodes the %s variant selected by the %q property of data.\n",
+		u.name, u.name, u.discriminator)
+	_, _ = fmt.Fprintf(buf, "func Unmarshal%s(data []byte) (%s, error) {\n", u.name, u.name)
+	_, _ = fmt.Fprintf(buf,
+		"\tvar probe struct {\n\t\tValue *string `json:%q`\n\t}\n", u.discriminator)
+	buf.WriteString("\tif err := json.Unmarshal(data, &probe); err != nil {\n")
+	buf.WriteString("\t\treturn nil, err\n\t}\n")
+	buf.WriteString("\tvalue := \"\"\n\tif probe.Value != nil {\n\t\tvalue = *probe.Value\n\t}\n")
+	buf.WriteString("\tswitch value {\n")
+
+	for _, variant := range variants {
+		_, _ = fmt.Fprintf(buf, "\tcase %q:\n", variant.value)
+		_, _ = fmt.Fprintf(buf, "\t\tvar v %s\n", variant.name)
+		buf.WriteString("\t\tif err := json.Unmarshal(data, &v); err != nil {\n")
+		buf.WriteString("\t\t\treturn nil, err\n\t\t}\n")
+		buf.WriteString("\t\treturn v, nil\n")
+	}
+
+	buf.WriteString("\t}\n")
+	_, _ = fmt.Fprintf(buf, "\treturn nil, fmt.Errorf(\"unknown %s %s %%q\", value)\n}\n\n",
+		u.name, u.discriminator)
+}
+
+// writeSealedUnmarshal emits an UnmarshalJSON method for structure name if
+// any of its properties holds a sealed union, either directly or as an
+// array, decoding those properties with the union's Unmarshal function.
+func (g *Generator) writeSealedUnmarshal(buf *bytes.Buffer, name string, props []Property) {
+	type sealedField struct {
+		field, tag, union string
+		array             bool
+	}
+
+	var fields []sealedField
+
+	for _, prop := range props {
+		if prop.Proposed {
+			continue
+		}
+
+		goType := g.resolveGoType(&prop.Type)
+		union, array := strings.CutPrefix(goType, "[]")
+
+		if g.isSealedUnion(union) {
+			fields = append(fields, sealedField{
+				field: GoFieldName(prop.Name),
+				tag:   JSONTag(prop.Name, prop.Optional),
+				union: union,
+				array: array,
+			})
+		}
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	buf.WriteString("// UnmarshalJSON decodes s and the variants of its sealed union fields.\n")
+	_, _ = fmt.Fprintf(buf, "func (s *%s) UnmarshalJSON(data []byte) error {\n", name)
+	_, _ = fmt.Fprintf(buf, "\ttype plain %s\n", name)
+	buf.WriteString("\tvar raw struct {\n\t\t*plain\n")
+
+	for _, f := range fields {
+		rawType := "json.RawMessage"
+		if f.array {
+			rawType = "[]json.RawMessage"
+		}
+
+		_, _ = fmt.Fprintf(buf, "\t\t%s %s %s\n", f.field, rawType, f.tag)
+	}
+
+	buf.WriteString("\t}\n\traw.plain = (*plain)(s)\n")
+	buf.WriteString("\tif err := json.Unmarshal(data, &raw); err != nil {\n\t\treturn err\n\t}\n")
+
+	for _, f := range fields {
+		if f.array {
+			_, _ = fmt.Fprintf(buf, "\ts.%s = nil\n", f.field)
+			_, _ = fmt.Fprintf(buf, "\tif raw.%s != nil {\n", f.field)
+			_, _ = fmt.Fprintf(buf,
+				"\t\ts.%s = make([]%s, 0, len(raw.%s))\n\t}\n", f.field, f.union, f.field)
+			_, _ = fmt.Fprintf(buf, "\tfor _, item := range raw.%s {\n", f.field)
+			_, _ = fmt.Fprintf(buf, "\t\tv, err := Unmarshal%s(item)\n", f.union)
+			buf.WriteString("\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n")
+			_, _ = fmt.Fprintf(buf, "\t\ts.%s = append(s.%s, v)\n\t}\n", f.field, f.field)
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(buf, "\ts.%s = nil\n", f.field)
+		_, _ = fmt.Fprintf(buf,
+			"\tif len(raw.%s) > 0 && string(raw.%s) != \"null\" {\n", f.field, f.field)
+		_, _ = fmt.Fprintf(buf, "\t\tv, err := Unmarshal%s(raw.%s)\n", f.union, f.field)
+		buf.WriteString("\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n")
+		_, _ = fmt.Fprintf(buf, "\t\ts.%s = v\n\t}\n", f.field)
+	}
+
+	buf.WriteString("\treturn nil\n}\n\n")
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shapeModel returns a model with a closed union of Circle, Square and the
+// kind-less default Point, held by Canvas both directly and in an array.
+func shapeModel() *Model {
+	kind := func(value string) Property {
+		return Property{Name: "kind", Type: Type{Kind: "stringLiteral", StringValue: value}}
+	}
+	uinteger := func(name string) Property { return baseProp(name, "uinteger") }
+	integer := func(name string) Property { return baseProp(name, "integer") }
+	shape := Type{
+		Kind:  "or",
+		Items: []Type{refType("Circle"), refType("Square"), refType("Point")},
+	}
+	null := Type{Kind: "base", Name: "null"}
+	nullableShape := Type{Kind: "or", Items: append(slices.Clone(shape.Items), null)}
+
+	return &Model{
+		Structures: []Structure{
+			{Name: "Circle", Properties: []Property{kind("circle"), uinteger("radius")}},
+			{Name: "Square", Properties: []Property{kind("square"), uinteger("side")}},
+			{Name: "Point", Properties: []Property{integer("x"), integer("y")}},
+			{
+				Name: "Canvas",
+				Properties: []Property{
+					{Name: "shapes", Type: Type{Kind: "array", Element: &shape}},
+					{Name: "focus", Optional: true, Type: nullableShape},
+				},
+			},
+		},
+	}
+}
+
+// shapeUnion is the sealed union entry for shapeModel.
+var shapeUnion = sealedUnion{
+	name:          "Shape",
+	discriminator: "kind",
+	variants:      []string{"Circle", "Square", "Point"},
+}
+
+func TestOptionsSealedUnions(t *testing.T) {
+	gen := NewGenerator(shapeModel())
+	gen.sealed = []sealedUnion{shapeUnion}
+
+	out, err := gen.generateTypes()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "\tShapes []OrCircleSquarePoint `json:\"shapes\"`",
+		"off by default")
+	assert.NotContains(t, string(out), "type Shape interface")
+
+	gen = NewGenerator(shapeModel())
+	gen.sealed = []sealedUnion{shapeUnion}
+	gen.Options.SealedUnions = true
+
+	out, err = gen.generateTypes()
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "types_gen.go", out, 0)
+	require.NoError(t, err, "generated types must parse:\n%s", out)
+
+	src := string(out)
+	assert.Contains(t, src, "\"fmt\"")
+	assert.Contains(t, src, "\tShapes []Shape `json:\"shapes\"`")
+	assert.Contains(t, src, "\tFocus Shape `json:\"focus,omitempty\"`")
+	assert.Contains(t, src, "type Shape interface {\n\tisShape()\n}")
+	assert.Contains(t, src, "func (Circle) isShape() {}")
+	assert.Contains(t, src, "func (Point) isShape() {}")
+	assert.Contains(t, src, "func (v Square) MarshalJSON() ([]byte, error) {")
+	assert.NotContains(t, src, "func (v Point) MarshalJSON()",
+		"the default variant has no discriminator")
+	assert.Contains(t, src, "func UnmarshalShape(data []byte) (Shape, error) {")
+	assert.Contains(t, src, "func (s *Canvas) UnmarshalJSON(data []byte) error {")
+}
+
+func TestOptionsSealedUnions_AmbiguousDefault(t *testing.T) {
+	gen := NewGenerator(shapeModel())
+	gen.sealed = []sealedUnion{
+		{name: "Shape", discriminator: "kind", variants: []string{"Point", "Canvas"}},
+	}
+	gen.Options.SealedUnions = true
+
+	_, err := gen.generateTypes()
+	require.ErrorContains(t, err, `neither Point nor Canvas has a "kind" discriminator`)
+}
+
+// sealedRoundTrip is compiled together with the generated types for
+// shapeModel and prints the re-encoded Canvas decoded from its input.
+const sealedRoundTrip = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var canvas Canvas
+	if err := json.Unmarshal([]byte(os.Args[1]), &canvas); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	for _, shape := range canvas.Shapes {
+		fmt.Printf("%T ", shape)
+	}
+
+	data, _ := json.Marshal(canvas)
+	fmt.Println(string(data))
+
+	data, _ = json.Marshal(Canvas{Shapes: []Shape{Square{Side: 2}}})
+	fmt.Println(string(data))
+}
+`
+
+func TestOptionsSealedUnions_RoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds generated code")
+	}
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	gen := NewGenerator(shapeModel())
+	gen.sealed = []sealedUnion{shapeUnion}
+	gen.Options.SealedUnions = true
+
+	out, err := gen.generateTypes()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	types := strings.Replace(string(out), "package protocol", "package main", 1)
+	for name, src := range map[string]string{
+		"go.mod":       "module sealed\n\ngo 1.26\n",
+		"types_gen.go": types,
+		"main.go":      sealedRoundTrip,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600))
+	}
+
+	run := func(input string) string {
+		cmd := exec.Command(goTool, "run", ".", input)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%s", output)
+
+		return string(output)
+	}
+
+	input := `{
+		"shapes": [
+			{"kind": "circle", "radius": 3},
+			{"x": 1, "y": 2},
+			{"kind": "square", "side": 4}
+		],
+		"focus": {"kind": "square", "side": 4}
+	}`
+	lines := strings.Split(strings.TrimSpace(run(input)), "\n")
+	require.Len(t, lines, 2)
+
+	parts := strings.SplitN(lines[0], "{", 2)
+	assert.Equal(t, "main.Circle main.Point main.Square ", parts[0])
+	assert.JSONEq(t, input, "{"+parts[1])
+	assert.JSONEq(t, `{"shapes": [{"kind": "square", "side": 2}]}`, lines[1],
+		"MarshalJSON fills in the discriminator")
+
+	assert.Contains(t, run(`{"shapes": [{"kind": "hexagon"}]}`),
+		`error: unknown Shape kind "hexagon"`)
+}
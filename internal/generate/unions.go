@@ -0,0 +1,456 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// unionType is a union with several members promoted to a named wrapper
+	// struct under Options.UnionTypes.
+	unionType struct {
+		name     string
+		variants []unionVariant
+	}
+
+	// unionVariant is a member of a unionType.
+	unionVariant struct {
+		// goType is the Go type of the member.
+		goType string
+		// part names the member in the union's name and in its As and Set
+		// methods, e.g. "LocationArray" for []Location.
+		part string
+		// field is the unexported field holding the member.
+		field string
+		// required lists the properties an object, or each element of an
+		// array, must have to decode as the member; excluded lists those it
+		// must not have, which only other members have. Both are empty for
+		// members that are not objects.
+		required []unionKey
+		excluded []string
+	}
+
+	// unionKey is a required property of a union member. value is the JSON
+	// encoding of the string literal the property must hold, if it is one.
+	unionKey struct {
+		name  string
+		value string
+	}
+)
+
+// promoteUnion returns the name of the wrapper struct for the union of items,
+// which must not include null, registering it for emission. The name joins
+// the members' names in specification order, so it is stable across runs
+// and identical unions share one wrapper. Members resolving to the same Go
+// type are merged; if only one remains, its type is returned instead.
+func (g *Generator) promoteUnion(items []Type) string {
+	variants := make([]unionVariant, 0, len(items))
+	props := make([][]string, 0, len(items))
+	objects := make([]bool, 0, len(items))
+
+	for _, item := range items {
+		if g.isProposedType(&item) {
+			continue
+		}
+
+		goType := g.resolveGoType(&item)
+		if slices.ContainsFunc(variants, func(v unionVariant) bool { return v.goType == goType }) {
+			continue
+		}
+
+		part := unionPartName(goType)
+		variants = append(variants, unionVariant{
+			goType:   goType,
+			part:     part,
+			field:    unionFieldName(part),
+			required: g.unionRequired(&item),
+			excluded: nil,
+		})
+		props = append(props, g.unionPropertyNames(&item))
+		objects = append(objects, g.unionObject(&item))
+	}
+
+	for idx := range variants {
+		if !objects[idx] {
+			continue
+		}
+
+		for other, names := range props {
+			for _, name := range names {
+				if other != idx && !slices.Contains(props[idx], name) &&
+					!slices.Contains(variants[idx].excluded, name) {
+					variants[idx].excluded = append(variants[idx].excluded, name)
+				}
+			}
+		}
+	}
+
+	if len(variants) == 1 {
+		return variants[0].goType
+	}
+
+	var name strings.Builder
+
+	name.WriteString("Or")
+
+	for _, variant := range variants {
+		name.WriteString(variant.part)
+	}
+
+	if existing, ok := g.unions[name.String()]; ok {
+		sameType := func(a, b unionVariant) bool { return a.goType == b.goType }
+		if !slices.EqualFunc(existing.variants, variants, sameType) && g.unionErr == nil {
+			g.unionErr = fmt.Errorf("unions %s and %s are both named %s",
+				unionGoTypes(existing.variants), unionGoTypes(variants), name.String())
+		}
+
+		return name.String()
+	}
+
+	g.unions[name.String()] = &unionType{name: name.String(), variants: variants}
+
+	return name.String()
+}
+
+// isProposedType reports whether typ refers to a proposed declaration, which
+// is not emitted.
+func (g *Generator) isProposedType(typ *Type) bool {
+	if typ.Kind != "reference" {
+		return false
+	}
+
+	if strc, ok := g.structs[typ.Name]; ok {
+		return strc.Proposed
+	}
+
+	if alias, ok := g.aliases[typ.Name]; ok {
+		return alias.Proposed
+	}
+
+	enum, ok := g.enums[typ.Name]
+
+	return ok && enum.Proposed
+}
+
+// unionProperties returns the properties of the object typ describes, looking
+// through arrays and aliases. For a union of objects, it returns the
+// properties of every member.
+func (g *Generator) unionProperties(typ *Type) []Property {
+	switch typ.Kind {
+	case "array":
+		return g.unionProperties(typ.Element)
+	case "literal":
+		return typ.Literal.Properties
+	case "or":
+		var props []Property
+		for idx := range typ.Items {
+			props = append(props, g.unionProperties(&typ.Items[idx])...)
+		}
+
+		return props
+	case "reference":
+		if alias, ok := g.aliases[typ.Name]; ok {
+			return g.unionProperties(&alias.Type)
+		}
+
+		if strc, ok := g.structs[typ.Name]; ok {
+			return g.collectProperties(strc)
+		}
+	}
+
+	return nil
+}
+
+// unionPropertyNames returns the names of the non-proposed properties of
+// unionProperties(typ).
+func (g *Generator) unionPropertyNames(typ *Type) []string {
+	var names []string
+
+	for _, prop := range g.unionProperties(typ) {
+		if !prop.Proposed && !slices.Contains(names, prop.Name) {
+			names = append(names, prop.Name)
+		}
+	}
+
+	return names
+}
+
+// unionObject strips arrays and aliases from typ, and reports whether the
+// result describes an object: a structure, a literal or a union of those.
+// Only objects, or arrays of them, have their properties checked.
+func (g *Generator) unionObject(typ *Type) bool {
+	typ = g.unionElement(typ)
+
+	switch typ.Kind {
+	case "literal":
+		return true
+	case "or":
+		return slices.ContainsFunc(typ.Items, func(item Type) bool { return g.unionObject(&item) })
+	case "reference":
+		_, ok := g.structs[typ.Name]
+		return ok
+	default:
+		return false
+	}
+}
+
+// unionElement returns typ with arrays and aliases stripped.
+func (g *Generator) unionElement(typ *Type) *Type {
+	for {
+		switch typ.Kind {
+		case "array":
+			typ = typ.Element
+		case "reference":
+			alias, ok := g.aliases[typ.Name]
+			if !ok {
+				return typ
+			}
+
+			typ = &alias.Type
+		default:
+			return typ
+		}
+	}
+}
+
+// unionRequired returns the required properties of the object typ describes,
+// in declaration order. It is empty if typ is itself a union, whose wrapper
+// checks its own members.
+func (g *Generator) unionRequired(typ *Type) []unionKey {
+	typ = g.unionElement(typ)
+	if typ.Kind == "or" {
+		return nil
+	}
+
+	var keys []unionKey
+
+	for _, prop := range g.unionProperties(typ) {
+		if prop.Optional || prop.Proposed {
+			continue
+		}
+
+		key := unionKey{name: prop.Name, value: ""}
+		if prop.Type.Kind == "stringLiteral" {
+			key.value = strconv.Quote(prop.Type.StringValue)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// unionGoTypes describes the members of a union for error messages.
+func unionGoTypes(variants []unionVariant) string {
+	types := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		types = append(types, variant.goType)
+	}
+
+	return strings.Join(types, " | ")
+}
+
+// unionPartName returns the exported name of a union member of Go type
+// goType: Location for Location, LocationArray for []Location, and
+// MapStringString for map[string]string.
+func unionPartName(goType string) string {
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		return unionPartName(goType[1:])
+	case strings.HasPrefix(goType, "[]"):
+		return unionPartName(goType[2:]) + "Array"
+	case strings.HasPrefix(goType, "map["):
+		key, value, _ := strings.Cut(goType[len("map["):], "]")
+		return "Map" + unionPartName(key) + unionPartName(value)
+	}
+
+	if _, name, ok := strings.Cut(goType, "."); ok {
+		goType = name // e.g. json.Number
+	}
+
+	return strings.ToUpper(goType[:1]) + goType[1:]
+}
+
+// unionFieldName returns the unexported field name for a member named part,
+// lowering its leading word or initialism: location for Location, lspAny for
+// LSPAny, uri for URI.
+func unionFieldName(part string) string {
+	runes := []rune(part)
+
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+
+	// In LSPAny the last capital of the initialism starts the next word.
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		upper--
+	}
+
+	for idx := range upper {
+		runes[idx] = unicode.ToLower(runes[idx])
+	}
+
+	field := string(runes)
+	if token.IsKeyword(field) {
+		field += "Value"
+	}
+
+	return field
+}
+
+// resolveUnionMethodTypes resolves the params and result of every method, so
+// that the unions they use are registered before the types are emitted.
+func (g *Generator) resolveUnionMethodTypes() {
+	for idx := range g.Model.Requests {
+		if req := &g.Model.Requests[idx]; !req.Proposed {
+			g.resolveMethodType(req.Params)
+			g.resolveMethodType(req.Result)
+		}
+	}
+
+	for idx := range g.Model.Notifications {
+		if notif := &g.Model.Notifications[idx]; !notif.Proposed {
+			g.resolveMethodType(notif.Params)
+		}
+	}
+}
+
+// writeUnionHelpers emits the functions shared by the UnmarshalJSON methods
+// of the union wrappers.
+func writeUnionHelpers(buf *bytes.Buffer) {
+	buf.WriteString("// unionKey is a property an object must have to decode as a union\n")
+	buf.WriteString("// variant; value, unless empty, is the JSON value it must hold.\n")
+	buf.WriteString("type unionKey struct {\n\tname  string\n\tvalue string\n}\n\n")
+	buf.WriteString("// decodeUnionVariant decodes data into v. If data is an object, or an\n")
+	buf.WriteString("// array of objects, each object must have the properties in required and\n")
+	buf.WriteString("// none of those in excluded, which only other variants have. Other\n")
+	buf.WriteString("// properties are ignored, as is everything below the top level, so that\n")
+	buf.WriteString("// objects sent by a newer version of the protocol still decode.\n")
+	buf.WriteString("func decodeUnionVariant(data []byte, v any, required []unionKey, " +
+		"excluded ...string) error {\n")
+	buf.WriteString("\tif err := json.Unmarshal(data, v); err != nil {\n\t\treturn err\n\t}\n")
+	buf.WriteString("\tif len(required) == 0 && len(excluded) == 0 {\n\t\treturn nil\n\t}\n")
+	buf.WriteString("\tvar objects []map[string]json.RawMessage\n")
+	buf.WriteString("\tif data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {\n")
+	buf.WriteString("\t\tif err := json.Unmarshal(data, &objects); err != nil {\n")
+	buf.WriteString("\t\t\treturn err\n\t\t}\n")
+	buf.WriteString("\t} else {\n\t\tvar object map[string]json.RawMessage\n")
+	buf.WriteString("\t\tif err := json.Unmarshal(data, &object); err != nil {\n")
+	buf.WriteString("\t\t\treturn err\n\t\t}\n")
+	buf.WriteString("\t\tobjects = append(objects, object)\n\t}\n")
+	buf.WriteString("\tfor _, object := range objects {\n")
+	buf.WriteString("\t\tfor _, key := range required {\n")
+	buf.WriteString("\t\t\tvalue, ok := object[key.name]\n")
+	buf.WriteString("\t\t\tif !ok {\n")
+	buf.WriteString("\t\t\t\treturn fmt.Errorf(\"missing property %q\", key.name)\n\t\t\t}\n")
+	buf.WriteString("\t\t\tif key.value != \"\" && string(bytes.TrimSpace(value)) != key.value {\n")
+	buf.WriteString("\t\t\t\treturn fmt.Errorf(\"property %q is %s, not %s\", " +
+		"key.name, value, key.value)\n\t\t\t}\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tfor _, name := range excluded {\n")
+	buf.WriteString("\t\t\tif _, ok := object[name]; ok {\n")
+	buf.WriteString("\t\t\t\treturn fmt.Errorf(\"unexpected property %q\", name)\n\t\t\t}\n")
+	buf.WriteString("\t\t}\n\t}\n\treturn nil\n}\n\n")
+	buf.WriteString("// unionMismatch reports that no variant of the union name accepts data.\n")
+	buf.WriteString("func unionMismatch(name string, data []byte) error {\n")
+	buf.WriteString("\treturn fmt.Errorf(\"%s: no variant accepts %s\", name, data)\n}\n\n")
+}
+
+// writeUnionType emits the wrapper struct for u with its As and Set methods,
+// and MarshalJSON and UnmarshalJSON methods. UnmarshalJSON decodes the first
+// variant, in specification order, that accepts the data.
+func writeUnionType(buf *bytes.Buffer, u *unionType) {
+	types := make([]string, 0, len(u.variants))
+	for _, variant := range u.variants {
+		types = append(types, variant.goType)
+	}
+
+	_, _ = fmt.Fprintf(buf, "// %s holds one of %s. The zero value holds none and\n",
+		u.name, strings.Join(types, ", "))
+	buf.WriteString("// is encoded as null.\n")
+	_, _ = fmt.Fprintf(buf, "type %s struct {\n", u.name)
+
+	for _, variant := range u.variants {
+		_, _ = fmt.Fprintf(buf, "\t%s *%s\n", variant.field, variant.goType)
+	}
+
+	buf.WriteString("}\n\n")
+
+	for _, variant := range u.variants {
+		_, _ = fmt.Fprintf(buf, "// As%s returns the %s held by u, if any.\n",
+			variant.part, variant.goType)
+		_, _ = fmt.Fprintf(buf, "func (u %s) As%s() (%s, bool) {\n",
+			u.name, variant.part, variant.goType)
+		_, _ = fmt.Fprintf(buf, "\tif u.%s == nil {\n", variant.field)
+		_, _ = fmt.Fprintf(buf, "\t\tvar zero %s\n\t\treturn zero, false\n\t}\n", variant.goType)
+		_, _ = fmt.Fprintf(buf, "\treturn *u.%s, true\n}\n\n", variant.field)
+
+		_, _ = fmt.Fprintf(buf, "// Set%s makes u hold v.\n", variant.part)
+		_, _ = fmt.Fprintf(buf, "func (u *%s) Set%s(v %s) {\n",
+			u.name, variant.part, variant.goType)
+		_, _ = fmt.Fprintf(buf, "\t*u = %s{%s: &v}\n}\n\n", u.name, variant.field)
+	}
+
+	buf.WriteString("// MarshalJSON encodes the variant held by u, or null if it holds none.\n")
+	_, _ = fmt.Fprintf(buf, "func (u %s) MarshalJSON() ([]byte, error) {\n", u.name)
+	buf.WriteString("\tswitch {\n")
+
+	for _, variant := range u.variants {
+		_, _ = fmt.Fprintf(buf, "\tcase u.%s != nil:\n", variant.field)
+		_, _ = fmt.Fprintf(buf, "\t\treturn json.Marshal(*u.%s)\n", variant.field)
+	}
+
+	buf.WriteString("\t}\n\treturn []byte(\"null\"), nil\n}\n\n")
+
+	buf.WriteString("// UnmarshalJSON decodes data as the first variant, in specification\n")
+	buf.WriteString("// order, that accepts it.\n")
+	_, _ = fmt.Fprintf(buf, "func (u *%s) UnmarshalJSON(data []byte) error {\n", u.name)
+	buf.WriteString("\tif string(bytes.TrimSpace(data)) == \"null\" {\n")
+	_, _ = fmt.Fprintf(buf, "\t\t*u = %s{}\n\t\treturn nil\n\t}\n", u.name)
+
+	for idx, variant := range u.variants {
+		_, _ = fmt.Fprintf(buf, "\tvar v%d %s\n", idx, variant.goType)
+		_, _ = fmt.Fprintf(buf, "\tif decodeUnionVariant(data, &v%d, %s) == nil {\n",
+			idx, unionKeyArgs(&variant))
+		_, _ = fmt.Fprintf(buf, "\t\tu.Set%s(v%d)\n\t\treturn nil\n\t}\n", variant.part, idx)
+	}
+
+	_, _ = fmt.Fprintf(buf, "\treturn unionMismatch(%q, data)\n}\n\n", u.name)
+}
+
+// unionKeyArgs returns the arguments of the decodeUnionVariant call checking
+// the properties of variant, after data and v.
+func unionKeyArgs(variant *unionVariant) string {
+	var args strings.Builder
+
+	if len(variant.required) == 0 {
+		args.WriteString("nil")
+	} else {
+		args.WriteString("[]unionKey{")
+
+		for idx, key := range variant.required {
+			if idx > 0 {
+				args.WriteString(", ")
+			}
+
+			_, _ = fmt.Fprintf(&args, "{%q, %q}", key.name, key.value)
+		}
+
+		args.WriteString("}")
+	}
+
+	for _, name := range variant.excluded {
+		_, _ = fmt.Fprintf(&args, ", %q", name)
+	}
+
+	return args.String()
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// locationModel returns a model using the union `Location | Location[]` in
+// two structures and a method result, and `string | integer | null` once.
+func locationModel() *Model {
+	locations := Type{
+		Kind: "or",
+		Items: []Type{
+			refType("Location"),
+			{Kind: "array", Element: new(refType("Location"))},
+		},
+	}
+	nullableID := Type{
+		Kind: "or",
+		Items: []Type{
+			{Kind: "base", Name: "string"},
+			{Kind: "base", Name: "integer"},
+			{Kind: "base", Name: "null"},
+		},
+	}
+	definition := Type{
+		Kind: "or",
+		Items: []Type{
+			refType("Location"),
+			{Kind: "array", Element: new(refType("Location"))},
+			{Kind: "base", Name: "null"},
+		},
+	}
+
+	return &Model{
+		Requests: []Request{{
+			Method:           "textDocument/definition",
+			MessageDirection: "clientToServer",
+			Params:           new(refType("Location")),
+			Result:           &definition,
+		}},
+		Structures: []Structure{
+			{Name: "Location", Properties: []Property{baseProp("uri", "string")}},
+			{
+				Name: "Reference",
+				Properties: []Property{
+					{Name: "target", Type: locations},
+					{Name: "id", Type: nullableID},
+				},
+			},
+			{
+				Name:       "Link",
+				Properties: []Property{{Name: "target", Optional: true, Type: locations}},
+			},
+		},
+	}
+}
+
+func TestOptionsUnionTypes(t *testing.T) {
+	gen := NewGenerator(locationModel())
+	gen.Options.UnionTypes = false
+
+	out, err := gen.generateTypes()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "\tTarget any `json:\"target\"`")
+
+	gen = NewGenerator(locationModel())
+	require.True(t, gen.Options.UnionTypes, "on by default")
+
+	out, err = gen.generateTypes()
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "types_gen.go", out, 0)
+	require.NoError(t, err, "generated types must parse:\n%s", out)
+
+	src := string(out)
+	assert.Contains(t, src, "\t\"bytes\"\n\t\"encoding/json\"\n\t\"fmt\"\n")
+	assert.Contains(t, src, "\tTarget OrLocationLocationArray `json:\"target\"`")
+	assert.Contains(t, src, "\tTarget *OrLocationLocationArray `json:\"target,omitempty\"`")
+	assert.Contains(t, src, "\tID *OrStringInt32 `json:\"id\"`")
+	assert.Equal(t, 1, strings.Count(src, "type OrLocationLocationArray struct {"),
+		"identical unions share one wrapper")
+	assert.Contains(t, src, "type OrLocationLocationArray struct {\n"+
+		"\tlocation *Location\n"+
+		"\tlocationArray *[]Location\n"+
+		"}")
+	assert.Contains(t, src,
+		"func (u OrLocationLocationArray) AsLocationArray() ([]Location, bool) {")
+	assert.Contains(t, src, "func (u *OrLocationLocationArray) SetLocation(v Location) {")
+	assert.Contains(t, src, "func (u OrStringInt32) AsInt32() (int32, bool) {")
+
+	server, err := gen.generateServer()
+	require.NoError(t, err)
+	assert.Contains(t, string(server),
+		"Definition(ctx context.Context, params *Location) (*OrLocationLocationArray, error)")
+}
+
+func TestUnionNames(t *testing.T) {
+	tests := []struct {
+		goType, part, field string
+	}{
+		{"Location", "Location", "location"},
+		{"[]Location", "LocationArray", "locationArray"},
+		{"map[string][]TextEdit", "MapStringTextEditArray", "mapStringTextEditArray"},
+		{"LSPAny", "LSPAny", "lspAny"},
+		{"URI", "URI", "uri"},
+		{"int32", "Int32", "int32"},
+		{"json.Number", "Number", "number"},
+		{"*Range", "Range", "rangeValue"},
+	}
+
+	for _, tt := range tests {
+		part := unionPartName(tt.goType)
+		assert.Equal(t, tt.part, part, tt.goType)
+		assert.Equal(t, tt.field, unionFieldName(part), tt.goType)
+	}
+}
+
+func TestOptionsUnionTypes_NameClash(t *testing.T) {
+	union := func(items ...Type) []Property {
+		return []Property{{Name: "x", Type: Type{Kind: "or", Items: items}}}
+	}
+	model := &Model{
+		Structures: []Structure{
+			{Name: "A", Properties: union(
+				Type{Kind: "array", Element: new(refType("Range"))}, refType("A"))},
+			{Name: "B", Properties: union(refType("RangeArray"), refType("A"))},
+			{Name: "Range"},
+			{Name: "RangeArray"},
+		},
+	}
+
+	_, err := NewGenerator(model).generateTypes()
+	require.ErrorContains(t, err, "[]Range | A and RangeArray | A are both named OrRangeArrayA")
+}
+
+func TestOptionsUnionTypes_VariantProperties(t *testing.T) {
+	kind := func(value string) Property {
+		return Property{Name: "kind", Type: Type{Kind: "stringLiteral", StringValue: value}}
+	}
+	model := &Model{
+		Structures: []Structure{
+			{
+				Name: "Edit",
+				Properties: []Property{
+					{Name: "edits", Type: Type{Kind: "array", Element: &Type{
+						Kind: "or",
+						Items: []Type{
+							refType("TextEdit"), refType("AnnotatedTextEdit"),
+							refType("SnippetTextEdit"),
+						},
+					}}},
+					{Name: "change", Type: Type{
+						Kind:  "or",
+						Items: []Type{refType("CreateFile"), refType("DeleteFile")},
+					}},
+					{Name: "data", Type: refType("LSPAny")},
+				},
+			},
+			{Name: "TextEdit", Properties: []Property{baseProp("newText", "string")}},
+			{
+				Name:       "AnnotatedTextEdit",
+				Extends:    []Type{refType("TextEdit")},
+				Properties: []Property{baseProp("annotationId", "string")},
+			},
+			{Name: "SnippetTextEdit", Proposed: true},
+			{Name: "CreateFile", Properties: []Property{kind("create"), baseProp("uri", "string")}},
+			{Name: "DeleteFile", Properties: []Property{kind("delete"), baseProp("uri", "string")}},
+		},
+		TypeAliases: []TypeAlias{{
+			Name: "LSPAny",
+			Type: Type{Kind: "or", Items: []Type{
+				{Kind: "base", Name: "string"},
+				{Kind: "base", Name: "integer"},
+				{Kind: "base", Name: "null"},
+			}},
+		}},
+	}
+
+	src := generateTypes(t, model)
+
+	assert.Contains(t, src, "\tEdits []OrTextEditAnnotatedTextEdit `json:\"edits\"`",
+		"proposed members are dropped")
+	assert.Contains(t, src,
+		`decodeUnionVariant(data, &v0, []unionKey{{"newText", ""}}, "annotationId")`,
+		"a member must not have the properties only other members have")
+	assert.Contains(t, src,
+		`decodeUnionVariant(data, &v1, []unionKey{{"annotationId", ""}, {"newText", ""}})`)
+	assert.Contains(t, src,
+		`decodeUnionVariant(data, &v0, []unionKey{{"kind", "\"create\""}, {"uri", ""}})`,
+		"string literal properties must match")
+	assert.Contains(t, src, "type LSPAny = any")
+	assert.NotContains(t, src, "OrStringInt32")
+}
+
+// unionRoundTrip is compiled together with the generated types for
+// locationModel and prints how each input decodes and re-encodes.
+const unionRoundTrip = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+func main() {
+	for _, input := range os.Args[1:] {
+		var ref Reference
+		if err := json.Unmarshal([]byte(input), &ref); err != nil {
+			fmt.Println("error:", err)
+			continue
+		}
+
+		_, isLocation := ref.Target.AsLocation()
+		locations, isArray := ref.Target.AsLocationArray()
+		data, _ := json.Marshal(ref)
+		fmt.Println(isLocation, isArray, len(locations), string(data))
+	}
+
+	var ref Reference
+	ref.Target.SetLocationArray([]Location{{URI: "file:///a"}})
+	data, _ := json.Marshal(ref)
+	fmt.Println(string(data))
+}
+`
+
+func TestOptionsUnionTypes_RoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds generated code")
+	}
+
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	out, err := NewGenerator(locationModel()).generateTypes()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	types := strings.Replace(string(out), "package protocol", "package main", 1)
+
+	for name, src := range map[string]string{
+		"go.mod":       "module unions\n\ngo 1.26\n",
+		"types_gen.go": types,
+		"main.go":      unionRoundTrip,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600))
+	}
+
+	cmd := exec.Command(goTool, "run", ".",
+		`{"target": {"uri": "file:///a"}, "id": 7}`,
+		`{"target": [{"uri": "file:///a"}, {"uri": "file:///b"}], "id": "x"}`,
+		`{"target": null, "id": null}`,
+		`{"target": {"uri": "file:///a", "extra": {"nested": 1}}, "id": 7}`,
+		`{"target": [{"uri": "file:///a"}, {"range": {}}], "id": 7}`,
+	)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s", output)
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, `true false 0 {"target":{"uri":"file:///a"},"id":7}`, lines[0])
+	assert.Equal(t,
+		`false true 2 {"target":[{"uri":"file:///a"},{"uri":"file:///b"}],"id":"x"}`, lines[1])
+	assert.Equal(t, `false false 0 {"target":null,"id":null}`, lines[2])
+	assert.Equal(t, `true false 0 {"target":{"uri":"file:///a"},"id":7}`, lines[3],
+		"properties the variant lacks are ignored")
+	assert.Contains(t, lines[4], "error: OrLocationLocationArray: no variant accepts",
+		"every element needs the required properties")
+	assert.Equal(t, `{"target":[{"uri":"file:///a"}],"id":null}`, lines[5])
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides ResultCache, which lets a server answer expensive read
+// requests such as textDocument/documentSymbol or textDocument/semanticTokens
+// from the result computed for the same document version.
+
+import "sync"
+
+type (
+	// ResultCache holds one result per document, tagged with the document
+	// version it was computed for. Storing a result for a newer version
+	// replaces the older one, and looking up a newer version drops it, so a
+	// document never holds more than its latest result. Documents are keyed
+	// by DocumentURI.CanonicalKey, as in DocumentStore. It is safe for
+	// concurrent use.
+	ResultCache[T any] struct {
+		mu      sync.Mutex
+		entries map[DocumentURI]resultEntry[T]
+	}
+
+	resultEntry[T any] struct {
+		version int32
+		value   T
+	}
+)
+
+// NewResultCache returns an empty ResultCache.
+func NewResultCache[T any]() *ResultCache[T] {
+	return &ResultCache[T]{ //nolint:exhaustruct
+		entries: make(map[DocumentURI]resultEntry[T]),
+	}
+}
+
+// Get returns the result stored for version of uri. It misses if no result
+// is stored or the stored one is for another version; a stored result for
+// an older version is dropped.
+func (c *ResultCache[T]) Get(uri DocumentURI, version int32) (T, bool) {
+	key := uri.CanonicalKey()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && entry.version < version {
+		delete(c.entries, key)
+	}
+
+	if !ok || entry.version != version {
+		var zero T
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+// Put stores value as the result for version of uri, replacing the result of
+// an older version. A result for a version older than the stored one is
+// ignored, so a slow computation cannot overwrite a newer result.
+func (c *ResultCache[T]) Put(uri DocumentURI, version int32, value T) {
+	key := uri.CanonicalKey()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok && entry.version > version {
+		return
+	}
+
+	c.entries[key] = resultEntry[T]{version: version, value: value}
+}
+
+// Invalidate forgets the result of uri, typically after it was closed.
+func (c *ResultCache[T]) Invalidate(uri DocumentURI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, uri.CanonicalKey())
+}
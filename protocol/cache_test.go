@@ -0,0 +1,145 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache(t *testing.T) {
+	t.Run("hit", func(t *testing.T) {
+		cache := NewResultCache[string]()
+		cache.Put(storeURI, 1, "tokens")
+
+		got, ok := cache.Get(storeURI, 1)
+		require.True(t, ok)
+		assert.Equal(t, "tokens", got)
+
+		got, ok = cache.Get(storeURI+"?version=1", 1)
+		require.True(t, ok, "URIs are compared by their canonical key")
+		assert.Equal(t, "tokens", got)
+	})
+
+	t.Run("miss on version bump", func(t *testing.T) {
+		cache := NewResultCache[string]()
+		cache.Put(storeURI, 1, "old")
+
+		_, ok := cache.Get(storeURI, 2)
+		assert.False(t, ok)
+
+		_, ok = cache.Get(storeURI, 1)
+		assert.False(t, ok, "looking up a newer version drops the old result")
+	})
+
+	t.Run("newer result replaces older", func(t *testing.T) {
+		cache := NewResultCache[string]()
+		cache.Put(storeURI, 1, "old")
+		cache.Put(storeURI, 2, "new")
+
+		_, ok := cache.Get(storeURI, 1)
+		assert.False(t, ok)
+
+		got, ok := cache.Get(storeURI, 2)
+		require.True(t, ok)
+		assert.Equal(t, "new", got)
+	})
+
+	t.Run("stale put ignored", func(t *testing.T) {
+		cache := NewResultCache[string]()
+		cache.Put(storeURI, 3, "new")
+		cache.Put(storeURI, 2, "stale")
+
+		got, ok := cache.Get(storeURI, 3)
+		require.True(t, ok)
+		assert.Equal(t, "new", got)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		cache := NewResultCache[string]()
+		cache.Put(storeURI, 1, "tokens")
+		cache.Invalidate(storeURI)
+
+		_, ok := cache.Get(storeURI, 1)
+		assert.False(t, ok)
+	})
+
+	t.Run("concurrent access", func(t *testing.T) {
+		cache := NewResultCache[int32]()
+
+		var wg sync.WaitGroup
+
+		for worker := range 8 {
+			wg.Go(func() {
+				for version := range int32(100) {
+					cache.Put(storeURI, version, version)
+
+					if got, ok := cache.Get(storeURI, version); ok {
+						assert.Equal(t, version, got)
+					}
+
+					if worker%2 == 0 {
+						cache.Invalidate(storeURI)
+					}
+				}
+			})
+		}
+
+		wg.Wait()
+	})
+}
+
+func TestResultCache_DocumentSymbols(t *testing.T) {
+	store := openStore(t, "package main\n\nfunc main() {}\n")
+	cache := NewResultCache[[]DocumentSymbol]()
+	computed := 0
+
+	// documentSymbols serves textDocument/documentSymbol from the cache when
+	// the document has not changed since the symbols were computed.
+	documentSymbols := func(uri DocumentURI) []DocumentSymbol {
+		doc, ok := store.Get(uri)
+		require.True(t, ok)
+
+		if symbols, ok := cache.Get(uri, doc.Version); ok {
+			return symbols
+		}
+
+		computed++
+
+		var symbols []DocumentSymbol
+
+		for line, text := range strings.Split(doc.Text, "\n") {
+			if name, ok := strings.CutPrefix(text, "func "); ok {
+				name, _, _ = strings.Cut(name, "(")
+				pos := Position{Line: uint32(line), Character: 0}
+				symbols = append(symbols, DocumentSymbol{
+					Name:           name,
+					Kind:           SymbolKindFunction,
+					Range:          pos.Range(uint32(len(text))),
+					SelectionRange: pos.Range(uint32(len(text))),
+				})
+			}
+		}
+
+		cache.Put(uri, doc.Version, symbols)
+
+		return symbols
+	}
+
+	assert.Len(t, documentSymbols(storeURI), 1)
+	assert.Len(t, documentSymbols(storeURI), 1)
+	assert.Equal(t, 1, computed, "an unchanged document is served from the cache")
+
+	change := NewFullChange(storeURI, 2, "package main\n\nfunc main() {}\n\nfunc run() {}\n")
+	require.NoError(t, store.DidChange(&change))
+
+	symbols := documentSymbols(storeURI)
+	require.Len(t, symbols, 2)
+	assert.Equal(t, "run", symbols[1].Name)
+	assert.Equal(t, 2, computed, "a new version is recomputed")
+}
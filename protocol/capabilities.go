@@ -0,0 +1,476 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides Capabilities, a read-only view over the
+// ClientCapabilities a client announced in its "initialize" request. The
+// generated capability structs are deeply nested and almost entirely
+// optional; Capabilities answers the common feature questions without the
+// pointer dances.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Capabilities wraps the ClientCapabilities received during initialization.
+// A nil *Capabilities behaves like a client that declared no capabilities.
+type Capabilities struct {
+	client ClientCapabilities
+}
+
+// NewCapabilities returns a Capabilities view over caps. A nil caps is
+// treated as an empty set of capabilities.
+func NewCapabilities(caps *ClientCapabilities) *Capabilities {
+	c := &Capabilities{} //nolint:exhaustruct
+	if caps != nil {
+		c.client = *caps
+	}
+
+	return c
+}
+
+// Client returns the underlying ClientCapabilities.
+func (c *Capabilities) Client() ClientCapabilities {
+	if c == nil {
+		return ClientCapabilities{} //nolint:exhaustruct
+	}
+
+	return c.client
+}
+
+// SupportsApplyEdit reports whether the client accepts workspace/applyEdit
+// requests.
+func (c *Capabilities) SupportsApplyEdit() bool {
+	ws := c.Client().Workspace
+	return ws != nil && ws.ApplyEdit != nil && *ws.ApplyEdit
+}
+
+// CompletionResolvableFields returns the completion item properties the
+// client can resolve lazily through completionItem/resolve, e.g.
+// ["documentation", "detail"]. A server may leave these out of the items of
+// textDocument/completion and fill them in on resolve. It is empty if the
+// client did not announce resolveSupport.
+func (c *Capabilities) CompletionResolvableFields() []string {
+	td := c.Client().TextDocument
+	if td == nil || td.Completion == nil || td.Completion.CompletionItem == nil {
+		return nil
+	}
+
+	resolve := td.Completion.CompletionItem.ResolveSupport
+	if resolve == nil {
+		return nil
+	}
+
+	return slices.Clone(resolve.Properties)
+}
+
+// Filter returns a copy of sc with every provider cleared for which the
+// client did not declare the corresponding textDocument (or workspace)
+// capability, so the server does not advertise features the client cannot
+// use. For example, SemanticTokensProvider is cleared when the client
+// capabilities lack textDocument.semanticTokens.
+//
+// Filtering is deliberately strict: a client that supports a feature but
+// omits its capability block will lose it. Use Filter only for clients known
+// to announce their capabilities faithfully.
+func (c *Capabilities) Filter(sc ServerCapabilities) ServerCapabilities { //nolint:cyclop,gocyclo,funlen
+	var td TextDocumentClientCapabilities
+	if client := c.Client(); client.TextDocument != nil {
+		td = *client.TextDocument
+	}
+
+	var ws WorkspaceClientCapabilities
+	if client := c.Client(); client.Workspace != nil {
+		ws = *client.Workspace
+	}
+
+	if td.Completion == nil {
+		sc.CompletionProvider = nil
+	}
+
+	if td.Hover == nil {
+		sc.HoverProvider = nil
+	}
+
+	if td.SignatureHelp == nil {
+		sc.SignatureHelpProvider = nil
+	}
+
+	if td.Declaration == nil {
+		sc.DeclarationProvider = nil
+	}
+
+	if td.Definition == nil {
+		sc.DefinitionProvider = nil
+	}
+
+	if td.TypeDefinition == nil {
+		sc.TypeDefinitionProvider = nil
+	}
+
+	if td.Implementation == nil {
+		sc.ImplementationProvider = nil
+	}
+
+	if td.References == nil {
+		sc.ReferencesProvider = nil
+	}
+
+	if td.DocumentHighlight == nil {
+		sc.DocumentHighlightProvider = nil
+	}
+
+	if td.DocumentSymbol == nil {
+		sc.DocumentSymbolProvider = nil
+	}
+
+	if td.CodeAction == nil {
+		sc.CodeActionProvider = nil
+	}
+
+	if td.CodeLens == nil {
+		sc.CodeLensProvider = nil
+	}
+
+	if td.DocumentLink == nil {
+		sc.DocumentLinkProvider = nil
+	}
+
+	if td.ColorProvider == nil {
+		sc.ColorProvider = nil
+	}
+
+	if td.Formatting == nil {
+		sc.DocumentFormattingProvider = nil
+	}
+
+	if td.RangeFormatting == nil {
+		sc.DocumentRangeFormattingProvider = nil
+	}
+
+	if td.OnTypeFormatting == nil {
+		sc.DocumentOnTypeFormattingProvider = nil
+	}
+
+	if td.Rename == nil {
+		sc.RenameProvider = nil
+	}
+
+	if td.FoldingRange == nil {
+		sc.FoldingRangeProvider = nil
+	}
+
+	if td.SelectionRange == nil {
+		sc.SelectionRangeProvider = nil
+	}
+
+	if td.CallHierarchy == nil {
+		sc.CallHierarchyProvider = nil
+	}
+
+	if td.SemanticTokens == nil {
+		sc.SemanticTokensProvider = nil
+	}
+
+	if td.LinkedEditingRange == nil {
+		sc.LinkedEditingRangeProvider = nil
+	}
+
+	if td.Moniker == nil {
+		sc.MonikerProvider = nil
+	}
+
+	if td.TypeHierarchy == nil {
+		sc.TypeHierarchyProvider = nil
+	}
+
+	if td.InlineValue == nil {
+		sc.InlineValueProvider = nil
+	}
+
+	if td.InlayHint == nil {
+		sc.InlayHintProvider = nil
+	}
+
+	if td.Diagnostic == nil {
+		sc.DiagnosticProvider = nil
+	}
+
+	if ws.Symbol == nil {
+		sc.WorkspaceSymbolProvider = nil
+	}
+
+	if ws.ExecuteCommand == nil {
+		sc.ExecuteCommandProvider = nil
+	}
+
+	return sc
+}
+
+// NegotiatePositionEncoding returns the first encoding in prefer that the
+// client announced in general.positionEncodings. UTF-16 is mandatory for
+// clients, so it is returned when there is no overlap or the client announced
+// nothing. The result must be echoed in ServerCapabilities.PositionEncoding.
+func NegotiatePositionEncoding(caps *ClientCapabilities, prefer []PositionEncodingKind) PositionEncodingKind {
+	var supported []PositionEncodingKind
+	if caps != nil && caps.General != nil {
+		supported = caps.General.PositionEncodings
+	}
+
+	for _, enc := range prefer {
+		if enc == PositionEncodingKindUTF16 || slices.Contains(supported, enc) {
+			return enc
+		}
+	}
+
+	return PositionEncodingKindUTF16
+}
+
+// DefaultServerCapabilities returns a baseline ServerCapabilities for a
+// server answering caps: full document sync with open/close notifications,
+// and a position encoding negotiated with the client, preferring UTF-8 (a
+// Mapper can serve any encoding, and UTF-8 matches Go strings). Enable
+// feature providers on the result as the server implements them.
+func DefaultServerCapabilities(caps *ClientCapabilities) ServerCapabilities {
+	encoding := NegotiatePositionEncoding(caps, []PositionEncodingKind{
+		PositionEncodingKindUTF8,
+		PositionEncodingKindUTF16,
+	})
+
+	var sync OrTextDocumentSyncOptionsTextDocumentSyncKind
+	sync.SetTextDocumentSyncOptions(TextDocumentSyncOptions{ //nolint:exhaustruct
+		OpenClose: new(true),
+		Change:    new(TextDocumentSyncKindFull),
+	})
+
+	return ServerCapabilities{ //nolint:exhaustruct
+		PositionEncoding: &encoding,
+		TextDocumentSync: &sync,
+	}
+}
+
+// NewOnTypeFormattingOptions returns the options advertising
+// textDocument/onTypeFormatting, triggered by typing first or any of more.
+// Each trigger must be a single character; Validate and
+// EnableOnTypeFormatting check this.
+func NewOnTypeFormattingOptions(first string, more ...string) DocumentOnTypeFormattingOptions {
+	return DocumentOnTypeFormattingOptions{
+		FirstTriggerCharacter: first,
+		MoreTriggerCharacter:  more,
+	}
+}
+
+// Validate returns an error if the first trigger or one of the additional
+// triggers of o is not exactly one character, as the specification
+// requires; clients may otherwise never send textDocument/onTypeFormatting.
+func (o DocumentOnTypeFormattingOptions) Validate() error {
+	for _, ch := range append([]string{o.FirstTriggerCharacter}, o.MoreTriggerCharacter...) {
+		if utf8.RuneCountInString(ch) != 1 {
+			return fmt.Errorf("on-type formatting trigger %q is not a single character", ch) //nolint:err113
+		}
+	}
+
+	return nil
+}
+
+// EnableOnTypeFormatting advertises textDocument/onTypeFormatting with opts.
+// It returns the error of opts.Validate, leaving sc unchanged, if a trigger
+// is not a single character.
+func (sc *ServerCapabilities) EnableOnTypeFormatting(opts DocumentOnTypeFormattingOptions) error {
+	if err := opts.Validate(); err != nil { //nolint:noinlineerr
+		return err
+	}
+
+	sc.DocumentOnTypeFormattingProvider = &opts
+
+	return nil
+}
+
+// NewSignatureHelpOptions returns the options advertising
+// textDocument/signatureHelp. Typing one of triggers opens signature help;
+// typing one of retriggers updates it while it is already showing. Trigger
+// characters always retrigger too, so retriggers may be nil.
+func NewSignatureHelpOptions(triggers, retriggers []string) SignatureHelpOptions {
+	return SignatureHelpOptions{ //nolint:exhaustruct
+		TriggerCharacters:   triggers,
+		RetriggerCharacters: retriggers,
+	}
+}
+
+// Validate returns an error if o has no trigger characters, in which case
+// the client never opens signature help on its own, or if a trigger or
+// retrigger is not exactly one character.
+func (o SignatureHelpOptions) Validate() error {
+	if len(o.TriggerCharacters) == 0 {
+		return errors.New("signature help has no trigger characters") //nolint:err113
+	}
+
+	for _, chars := range [][]string{o.TriggerCharacters, o.RetriggerCharacters} {
+		for _, ch := range chars {
+			if utf8.RuneCountInString(ch) != 1 {
+				return fmt.Errorf("signature help trigger %q is not a single character", ch) //nolint:err113
+			}
+		}
+	}
+
+	return nil
+}
+
+// EnableSignatureHelp advertises textDocument/signatureHelp with opts.
+func (sc *ServerCapabilities) EnableSignatureHelp(opts SignatureHelpOptions) {
+	sc.SignatureHelpProvider = &opts
+}
+
+// RequiredClientCapability returns the dotted path of the client capability
+// a client must announce before a server sends it method, for example
+// "workspace.configuration" for workspace/configuration. The boolean is false
+// for methods that are not gated by a single capability.
+func RequiredClientCapability(method string) (string, bool) {
+	path, ok := methodClientCapability[method]
+	return path, ok
+}
+
+// SupportsDynamicRegistration reports whether the client announced
+// dynamicRegistration for the capability behind method, so that the server may
+// register it via client/registerCapability. method is the method of the
+// Registration, e.g. "textDocument/hover", or "textDocument/semanticTokens"
+// for the semantic tokens requests. It is false for methods that cannot be
+// registered dynamically.
+func (c *Capabilities) SupportsDynamicRegistration(method string) bool {
+	path, ok := methodDynamicRegistration[method]
+	if !ok {
+		return false
+	}
+
+	v := reflect.ValueOf(c.Client())
+	for name := range strings.SplitSeq(path+".dynamicRegistration", ".") {
+		if v = jsonField(v, name); !v.IsValid() {
+			return false
+		}
+	}
+
+	return v.Kind() == reflect.Bool && v.Bool()
+}
+
+// jsonField returns the field of struct v whose JSON name is name,
+// dereferenced if it is a pointer. It returns the zero Value if v is not a
+// struct, has no such field, or the field is a nil pointer.
+func jsonField(v reflect.Value, name string) reflect.Value {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	for i := range v.NumField() {
+		tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				return reflect.Value{}
+			}
+
+			field = field.Elem()
+		}
+
+		return field
+	}
+
+	return reflect.Value{}
+}
+
+// DiffCapabilities reports which capabilities differ between old and cur,
+// for example the ones announced before and after a client reconnects. Each
+// difference is returned as the dotted JSON path of a leaf field, such as
+// "textDocument.completion.completionItem.snippetSupport", in declaration
+// order. An absent capability block is compared as if it were empty, so a
+// leaf is reported only when its own value changed; a boolean that went from
+// absent to false is still reported.
+func DiffCapabilities(old, cur ClientCapabilities) []string {
+	var paths []string
+
+	diffValues(reflect.ValueOf(old), reflect.ValueOf(cur), "", &paths)
+
+	return paths
+}
+
+// diffValues appends the paths of the leaves that differ between a and b,
+// which have the same type. Types with their own JSON encoding, such as the
+// union types, keep their state in unexported fields; they are leaves
+// compared by their encoding, as are interface values.
+func diffValues(a, b reflect.Value, path string, paths *[]string) {
+	typ := a.Type()
+	if typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Struct &&
+		!typ.Implements(jsonMarshaler) {
+		a, b = derefOrZero(a), derefOrZero(b)
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ.Implements(jsonMarshaler), typ.Kind() == reflect.Interface:
+		if !jsonEqual(a.Interface(), b.Interface()) {
+			*paths = append(*paths, path)
+		}
+
+		return
+	case typ.Kind() != reflect.Struct:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*paths = append(*paths, path)
+		}
+
+		return
+	}
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		if path != "" {
+			name = path + "." + name
+		}
+
+		diffValues(a.Field(i), b.Field(i), name, paths)
+	}
+}
+
+// jsonMarshaler is the type of json.Marshaler, for diffValues.
+var jsonMarshaler = reflect.TypeFor[json.Marshaler]() //nolint:gochecknoglobals
+
+// jsonEqual reports whether a and b have the same JSON encoding. Values that
+// fail to encode are compared with reflect.DeepEqual.
+func jsonEqual(a, b any) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+
+	if aErr != nil || bErr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+
+	return bytes.Equal(aData, bData)
+}
+
+// derefOrZero returns the struct v points to, or a zero struct if v is nil.
+func derefOrZero(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+
+	return v.Elem()
+}
@@ -0,0 +1,355 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilitiesFilter(t *testing.T) {
+	var semanticTokens OrSemanticTokensOptionsSemanticTokensRegistrationOptions
+	semanticTokens.SetSemanticTokensOptions(SemanticTokensOptions{
+		Full: &BoolOrOptions[SemanticTokensFullDelta]{Bool: true},
+	})
+
+	caps := NewCapabilities(&ClientCapabilities{
+		TextDocument: &TextDocumentClientCapabilities{
+			Hover: &HoverClientCapabilities{},
+		},
+	})
+
+	got := caps.Filter(ServerCapabilities{
+		HoverProvider:          &BoolOrOptions[HoverOptions]{Bool: true},
+		SemanticTokensProvider: &semanticTokens,
+		PositionEncoding:       new(PositionEncodingKindUTF16),
+	})
+
+	assert.True(t, got.HoverEnabled())
+	assert.Nil(t, got.SemanticTokensProvider)
+	assert.Equal(t, new(PositionEncodingKindUTF16), got.PositionEncoding, "non-provider fields are kept")
+}
+
+func TestCapabilitiesFilterNil(t *testing.T) {
+	var caps *Capabilities
+
+	got := caps.Filter(ServerCapabilities{
+		HoverProvider:      &BoolOrOptions[HoverOptions]{Bool: true},
+		CompletionProvider: &CompletionOptions{},
+	})
+
+	assert.Nil(t, got.HoverProvider)
+	assert.Nil(t, got.CompletionProvider)
+}
+
+func TestNegotiatePositionEncoding(t *testing.T) {
+	utf8Client := &ClientCapabilities{
+		General: &GeneralClientCapabilities{
+			PositionEncodings: []PositionEncodingKind{PositionEncodingKindUTF8, PositionEncodingKindUTF16},
+		},
+	}
+	utf32Client := &ClientCapabilities{
+		General: &GeneralClientCapabilities{
+			PositionEncodings: []PositionEncodingKind{PositionEncodingKindUTF32},
+		},
+	}
+	preferUTF8 := []PositionEncodingKind{PositionEncodingKindUTF8}
+
+	tests := []struct {
+		name   string
+		caps   *ClientCapabilities
+		prefer []PositionEncodingKind
+		want   PositionEncodingKind
+	}{
+		{"mutual utf-8", utf8Client, preferUTF8, PositionEncodingKindUTF8},
+		{"no overlap", utf32Client, preferUTF8, PositionEncodingKindUTF16},
+		{"client announced nothing", &ClientCapabilities{}, preferUTF8, PositionEncodingKindUTF16},
+		{"nil caps", nil, preferUTF8, PositionEncodingKindUTF16},
+		{"server preference order wins", utf8Client, []PositionEncodingKind{
+			PositionEncodingKindUTF32, PositionEncodingKindUTF16, PositionEncodingKindUTF8,
+		}, PositionEncodingKindUTF16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NegotiatePositionEncoding(tt.caps, tt.prefer))
+		})
+	}
+}
+
+func TestDefaultServerCapabilities(t *testing.T) {
+	sc := DefaultServerCapabilities(&ClientCapabilities{
+		General: &GeneralClientCapabilities{
+			PositionEncodings: []PositionEncodingKind{PositionEncodingKindUTF8},
+		},
+	})
+	assert.Equal(t, new(PositionEncodingKindUTF8), sc.PositionEncoding)
+	require.NotNil(t, sc.TextDocumentSync)
+	sync, ok := sc.TextDocumentSync.AsTextDocumentSyncOptions()
+	require.True(t, ok)
+	assert.Equal(t, TextDocumentSyncOptions{
+		OpenClose: new(true),
+		Change:    new(TextDocumentSyncKindFull),
+	}, sync)
+
+	assert.Equal(t, new(PositionEncodingKindUTF16), DefaultServerCapabilities(nil).PositionEncoding)
+}
+
+func TestOnTypeFormattingOptions(t *testing.T) {
+	sc := DefaultServerCapabilities(nil)
+	require.NoError(t, sc.EnableOnTypeFormatting(NewOnTypeFormattingOptions("}", ";", "\n")))
+
+	data, err := json.Marshal(sc.DocumentOnTypeFormattingProvider)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"firstTriggerCharacter": "}", "moreTriggerCharacter": [";", "\n"]}`, string(data))
+
+	data, err = json.Marshal(NewOnTypeFormattingOptions("}"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"firstTriggerCharacter": "}"}`, string(data))
+
+	t.Run("invalid triggers", func(t *testing.T) {
+		for name, triggers := range map[string][]string{
+			"empty first":           {""},
+			"multi-character first": {"=>"},
+			"empty more":            {"}", ";", ""},
+			"multi-character more":  {"}", "end"},
+		} {
+			opts := NewOnTypeFormattingOptions(triggers[0], triggers[1:]...)
+			require.Error(t, opts.Validate(), name)
+
+			sc := DefaultServerCapabilities(nil)
+			require.Error(t, sc.EnableOnTypeFormatting(opts), name)
+			assert.Nil(t, sc.DocumentOnTypeFormattingProvider, name)
+		}
+
+		require.NoError(t, DocumentOnTypeFormattingOptions{FirstTriggerCharacter: "é"}.Validate(),
+			"a trigger is one character, not one byte")
+	})
+}
+
+func TestSignatureHelpOptions(t *testing.T) {
+	t.Run("both character sets", func(t *testing.T) {
+		sc := DefaultServerCapabilities(nil)
+		sc.EnableSignatureHelp(NewSignatureHelpOptions([]string{"(", ","}, []string{")"}))
+
+		require.NoError(t, sc.SignatureHelpProvider.Validate())
+
+		data, err := json.Marshal(sc.SignatureHelpProvider)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"triggerCharacters": ["(", ","], "retriggerCharacters": [")"]}`, string(data))
+	})
+
+	t.Run("retriggers default to empty", func(t *testing.T) {
+		opts := NewSignatureHelpOptions([]string{"("}, nil)
+
+		assert.Empty(t, opts.RetriggerCharacters)
+		require.NoError(t, opts.Validate())
+
+		data, err := json.Marshal(opts)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"triggerCharacters": ["("]}`, string(data))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Error(t, NewSignatureHelpOptions(nil, []string{")"}).Validate())
+		require.Error(t, NewSignatureHelpOptions([]string{"(", ""}, nil).Validate())
+		require.Error(t, NewSignatureHelpOptions([]string{"("}, []string{"=>"}).Validate())
+	})
+}
+
+func TestCompletionResolvableFields(t *testing.T) {
+	caps := NewCapabilities(&ClientCapabilities{
+		TextDocument: &TextDocumentClientCapabilities{
+			Completion: &CompletionClientCapabilities{
+				CompletionItem: &ClientCompletionItemOptions{
+					ResolveSupport: &ClientCompletionItemResolveOptions{
+						Properties: []string{"documentation", "detail"},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, []string{"documentation", "detail"}, caps.CompletionResolvableFields())
+
+	for name, caps := range map[string]*Capabilities{
+		"nil":           nil,
+		"no completion": NewCapabilities(&ClientCapabilities{}),
+		"no resolveSupport": NewCapabilities(&ClientCapabilities{
+			TextDocument: &TextDocumentClientCapabilities{
+				Completion: &CompletionClientCapabilities{
+					CompletionItem: &ClientCompletionItemOptions{SnippetSupport: new(true)},
+				},
+			},
+		}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Empty(t, caps.CompletionResolvableFields())
+		})
+	}
+}
+
+func TestRequiredClientCapability(t *testing.T) {
+	path, ok := RequiredClientCapability(MethodWorkspaceConfiguration)
+	assert.True(t, ok)
+	assert.Equal(t, "workspace.configuration", path)
+
+	path, ok = RequiredClientCapability(MethodWindowShowDocument)
+	assert.True(t, ok)
+	assert.Equal(t, "window.showDocument.support", path)
+
+	_, ok = RequiredClientCapability(MethodWindowLogMessage)
+	assert.False(t, ok)
+}
+
+func TestSupportsDynamicRegistration(t *testing.T) {
+	caps := NewCapabilities(&ClientCapabilities{
+		TextDocument: &TextDocumentClientCapabilities{
+			Hover:          &HoverClientCapabilities{DynamicRegistration: new(true)},
+			Completion:     &CompletionClientCapabilities{DynamicRegistration: new(false)},
+			SemanticTokens: &SemanticTokensClientCapabilities{DynamicRegistration: new(true)},
+		},
+		Workspace: &WorkspaceClientCapabilities{
+			FileOperations: &FileOperationClientCapabilities{DynamicRegistration: new(true)},
+		},
+	})
+
+	assert.True(t, caps.SupportsDynamicRegistration(MethodTextDocumentHover))
+	assert.True(t, caps.SupportsDynamicRegistration("textDocument/semanticTokens"))
+	assert.True(t, caps.SupportsDynamicRegistration(MethodWorkspaceWillRenameFiles))
+	assert.False(t, caps.SupportsDynamicRegistration(MethodTextDocumentCompletion))
+	assert.False(t, caps.SupportsDynamicRegistration(MethodTextDocumentDefinition))
+	assert.False(t, caps.SupportsDynamicRegistration(MethodShutdown))
+	assert.False(t, (*Capabilities)(nil).SupportsDynamicRegistration(MethodTextDocumentHover))
+}
+
+// TestMethodDynamicRegistrationPaths checks that every capability path in
+// the table leads to a dynamicRegistration property.
+func TestMethodDynamicRegistrationPaths(t *testing.T) {
+	for method, path := range methodDynamicRegistration {
+		typ := reflect.TypeFor[ClientCapabilities]()
+
+		for name := range strings.SplitSeq(path+".dynamicRegistration", ".") {
+			field, ok := jsonFieldType(typ, name)
+			require.True(t, ok, "%s: %s has no %s", method, path, name)
+
+			typ = field
+		}
+
+		assert.Equal(t, reflect.Bool, typ.Kind(), method)
+	}
+}
+
+// jsonFieldType returns the type of the field of struct typ whose JSON name
+// is name, dereferenced if it is a pointer.
+func jsonFieldType(typ reflect.Type, name string) (reflect.Type, bool) {
+	for i := range typ.NumField() {
+		tag, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+
+		field := typ.Field(i).Type
+		if field.Kind() == reflect.Pointer {
+			field = field.Elem()
+		}
+
+		return field, true
+	}
+
+	return nil, false
+}
+
+func TestDiffCapabilities(t *testing.T) {
+	old := ClientCapabilities{
+		TextDocument: &TextDocumentClientCapabilities{
+			Completion: &CompletionClientCapabilities{
+				CompletionItem: &ClientCompletionItemOptions{SnippetSupport: new(false)},
+			},
+			Hover: &HoverClientCapabilities{ContentFormat: []MarkupKind{MarkupKindMarkdown}},
+		},
+		Workspace: &WorkspaceClientCapabilities{ApplyEdit: new(true)},
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		assert.Empty(t, DiffCapabilities(old, old))
+		assert.Empty(t, DiffCapabilities(ClientCapabilities{}, ClientCapabilities{}))
+	})
+
+	t.Run("nested toggle", func(t *testing.T) {
+		cur := old
+		cur.TextDocument = &TextDocumentClientCapabilities{
+			Completion: &CompletionClientCapabilities{
+				CompletionItem: &ClientCompletionItemOptions{SnippetSupport: new(true)},
+			},
+			Hover: &HoverClientCapabilities{ContentFormat: []MarkupKind{MarkupKindMarkdown}},
+		}
+
+		assert.Equal(t, []string{"textDocument.completion.completionItem.snippetSupport"}, DiffCapabilities(old, cur))
+	})
+
+	t.Run("absent blocks", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"workspace.applyEdit",
+			"textDocument.completion.completionItem.snippetSupport",
+			"textDocument.hover.contentFormat",
+		}, DiffCapabilities(old, ClientCapabilities{}))
+	})
+
+	t.Run("union capability", func(t *testing.T) {
+		tokens := func(full *BoolOrOptions[ClientSemanticTokensRequestFullDelta]) ClientCapabilities {
+			return ClientCapabilities{
+				TextDocument: &TextDocumentClientCapabilities{
+					SemanticTokens: &SemanticTokensClientCapabilities{
+						Requests: ClientSemanticTokensRequestOptions{Full: full},
+					},
+				},
+			}
+		}
+
+		var decoded ClientCapabilities
+		require.NoError(t, json.Unmarshal(
+			[]byte(`{"textDocument":{"semanticTokens":{"requests":{"full":{"delta":true}}}}}`), &decoded))
+
+		enabled := &BoolOrOptions[ClientSemanticTokensRequestFullDelta]{Bool: true}
+		assert.Equal(t, []string{"textDocument.semanticTokens.requests.full"},
+			DiffCapabilities(tokens(enabled), decoded))
+		assert.Empty(t, DiffCapabilities(
+			tokens(NewBoolOrOptions(ClientSemanticTokensRequestFullDelta{Delta: new(true)})), decoded),
+			"unions compare by their encoding")
+	})
+
+	t.Run("union wrapper", func(t *testing.T) {
+		type capabilities struct {
+			Provider *BoolOrOptions[HoverOptions]                   `json:"provider,omitempty"`
+			Contents HoverContents                                  `json:"contents"`
+			Sync     *OrTextDocumentSyncOptionsTextDocumentSyncKind `json:"sync,omitempty"`
+		}
+
+		var oldSync, curSync OrTextDocumentSyncOptionsTextDocumentSyncKind
+		oldSync.SetTextDocumentSyncKind(TextDocumentSyncKindFull)
+		curSync.SetTextDocumentSyncKind(TextDocumentSyncKindIncremental)
+
+		old := capabilities{
+			Provider: NewBoolOrOptions(HoverOptions{}),
+			Contents: NewMarkedStringHoverContents(markedText("a")),
+			Sync:     &oldSync,
+		}
+		cur := capabilities{
+			Provider: &BoolOrOptions[HoverOptions]{Bool: true, Options: &HoverOptions{}},
+			Contents: NewMarkedStringHoverContents(markedText("b")),
+			Sync:     &curSync,
+		}
+
+		var paths []string
+
+		diffValues(reflect.ValueOf(old), reflect.ValueOf(cur), "", &paths)
+		assert.Equal(t, []string{"contents", "sync"}, paths,
+			"the options take precedence over the boolean on the wire")
+	})
+}
@@ -80,6 +80,21 @@ type Client interface {
 	WorkspaceFolders(ctx context.Context) ([]WorkspaceFolder, error)
 }
 
+// methodClientCapability maps server→client methods to the dotted path of
+// the client capability announcing that the client accepts them.
+var methodClientCapability = map[string]string{
+	MethodWindowShowDocument: "window.showDocument.support",
+	MethodWindowWorkDoneProgressCreate: "window.workDoneProgress",
+	MethodWorkspaceApplyEdit: "workspace.applyEdit",
+	MethodWorkspaceCodeLensRefresh: "workspace.codeLens.refreshSupport",
+	MethodWorkspaceConfiguration: "workspace.configuration",
+	MethodWorkspaceDiagnosticRefresh: "workspace.diagnostics.refreshSupport",
+	MethodWorkspaceInlayHintRefresh: "workspace.inlayHint.refreshSupport",
+	MethodWorkspaceInlineValueRefresh: "workspace.inlineValue.refreshSupport",
+	MethodWorkspaceSemanticTokensRefresh: "workspace.semanticTokens.refreshSupport",
+	MethodWorkspaceWorkspaceFolders: "workspace.workspaceFolders",
+}
+
 type clientDispatcher struct {
 	conn jsonrpc2.Conn
 	logger Logger
@@ -90,11 +105,14 @@ type clientDispatcher struct {
 //
 // The logger parameter is used for protocol-level logging. Pass NopLogger()
 // (or nil) to disable logging.
+//
+// Error responses to requests are returned as *ResponseError, so they can be
+// tested with errors.Is against ErrMethodNotFound and the like.
 func ClientDispatcher(conn jsonrpc2.Conn, logger Logger) Client {
 	if logger == nil {
 		logger = NopLogger()
 	}
-	return &clientDispatcher{conn: conn, logger: logger}
+	return &clientDispatcher{conn: responseErrorConn{Conn: conn}, logger: logger}
 }
 
 func (c *clientDispatcher) CancelRequest(ctx context.Context, params *CancelParams) error {
@@ -0,0 +1,267 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides convenience wrappers around the generated Client
+// interface for the server→client calls that servers make most often.
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// LogMessagef sends a window/logMessage notification whose message is
+// formatted from format and args as by fmt.Sprintf.
+func LogMessagef(ctx context.Context, c Client, typ MessageType, format string, args ...any) error {
+	return c.LogMessage(ctx, &LogMessageParams{ //nolint:wrapcheck
+		Type:    typ,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// ShowMessagef sends a window/showMessage notification whose message is
+// formatted from format and args as by fmt.Sprintf.
+func ShowMessagef(
+	ctx context.Context,
+	c Client,
+	typ MessageType,
+	format string,
+	args ...any,
+) error {
+	return c.ShowMessage(ctx, &ShowMessageParams{ //nolint:wrapcheck
+		Type:    typ,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// ShowDocumentOptions controls how the client presents a document requested
+// via ShowDocument.
+type ShowDocumentOptions struct {
+	// External asks the client to open the resource in an external program,
+	// e.g. the default web browser.
+	External bool
+	// TakeFocus asks the editor showing the document to take focus.
+	TakeFocus bool
+	// Selection is an optional range to select if the document is a text
+	// document.
+	Selection *Range
+}
+
+// ShowDocument asks the client to show the resource identified by uri
+// (window/showDocument) and reports whether the client succeeded.
+func ShowDocument(ctx context.Context, c Client, uri URI, opts ShowDocumentOptions) (bool, error) {
+	result, err := c.ShowDocument(ctx, &ShowDocumentParams{
+		URI:       uri,
+		External:  new(opts.External),
+		TakeFocus: new(opts.TakeFocus),
+		Selection: opts.Selection,
+	})
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+
+	return result != nil && result.Success, nil
+}
+
+// ApplyEdit asks the client to apply edit (workspace/applyEdit). The label,
+// if not empty, is shown in the client's undo stack.
+//
+// If caps is not nil and the client did not announce workspace.applyEdit,
+// ApplyEdit returns an error wrapping ErrUnsupported without contacting the
+// client; a nil caps skips the check.
+func ApplyEdit(
+	ctx context.Context,
+	c Client,
+	caps *Capabilities,
+	label string,
+	edit WorkspaceEdit,
+) (*ApplyWorkspaceEditResult, error) {
+	if caps != nil && !caps.SupportsApplyEdit() {
+		return nil, fmt.Errorf("workspace/applyEdit: %w", ErrUnsupported)
+	}
+
+	params := &ApplyWorkspaceEditParams{Edit: edit} //nolint:exhaustruct
+	if label != "" {
+		params.Label = &label
+	}
+
+	return c.ApplyEdit(ctx, params) //nolint:wrapcheck
+}
+
+// RegisterCapability asks the client to register regs dynamically
+// (client/registerCapability).
+//
+// If caps is not nil, registrations whose method the client cannot register
+// dynamically (see Capabilities.SupportsDynamicRegistration) are skipped, and
+// the client is only contacted if any remain. The skipped methods are then
+// reported by an error wrapping ErrUnsupported, returned once the others have
+// been registered. A nil caps skips the check.
+func RegisterCapability(
+	ctx context.Context,
+	c Client,
+	caps *Capabilities,
+	regs ...Registration,
+) error {
+	var skipped []string
+
+	if caps != nil {
+		regs = slices.DeleteFunc(slices.Clone(regs), func(reg Registration) bool {
+			if caps.SupportsDynamicRegistration(reg.Method) {
+				return false
+			}
+
+			skipped = append(skipped, reg.Method)
+
+			return true
+		})
+	}
+
+	if len(regs) > 0 {
+		_, err := c.RegisterCapability(ctx, &RegistrationParams{Registrations: regs})
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	if len(skipped) > 0 {
+		return fmt.Errorf("client/registerCapability: %s: %w",
+			strings.Join(skipped, ", "), ErrUnsupported)
+	}
+
+	return nil
+}
+
+// TelemetryErrorEvent is the payload of the telemetry/event notification
+// sent by TelemetryError. It is encoded as
+//
+//	{"kind": "error", "method": "textDocument/hover", "message": "...", "code": -32603}
+//
+// where code is only present for JSON-RPC errors.
+type TelemetryErrorEvent struct {
+	// Kind is always "error", telling these events apart from others the
+	// server sends.
+	Kind string `json:"kind"`
+	// Method is the LSP method whose handling failed.
+	Method string `json:"method"`
+	// Message is the text of the error.
+	Message string `json:"message"`
+	// Code is the code of the error, as returned by CodeOf.
+	Code *int64 `json:"code,omitempty"`
+}
+
+// TelemetryError reports err, raised while handling method, to the client as
+// a telemetry/event notification carrying a TelemetryErrorEvent. It sends
+// nothing and returns nil if err is nil.
+func TelemetryError(ctx context.Context, c Client, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	event := TelemetryErrorEvent{
+		Kind:    "error",
+		Method:  method,
+		Message: err.Error(),
+		Code:    nil,
+	}
+
+	if code, ok := CodeOf(err); ok {
+		event.Code = new(code)
+	}
+
+	return c.Event(ctx, event) //nolint:wrapcheck
+}
+
+// PublishDiagnosticsBatch sends one textDocument/publishDiagnostics
+// notification per entry of byURI, in URI order, all stamped with version. A
+// nil or empty diagnostics slice clears the diagnostics of its URI. It stops
+// at the first error, which it returns.
+func PublishDiagnosticsBatch(
+	ctx context.Context,
+	c Client,
+	byURI map[DocumentURI][]Diagnostic,
+	version int32,
+) error {
+	return PublishDiagnosticsBatchConcurrent(ctx, c, byURI, version, 1)
+}
+
+// PublishDiagnosticsBatchConcurrent is like PublishDiagnosticsBatch, but
+// publishes from up to workers goroutines at once, which speeds things up
+// when the connection to the client is slow. c must be safe for concurrent
+// use, as the Client returned by ClientDispatcher is. After the first error
+// no further notifications are started, and that error is returned once the
+// ones in flight have finished.
+func PublishDiagnosticsBatchConcurrent(
+	ctx context.Context,
+	c Client,
+	byURI map[DocumentURI][]Diagnostic,
+	version int32,
+	workers int,
+) error {
+	uris := slices.Sorted(maps.Keys(byURI))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+
+	jobs := make(chan DocumentURI)
+
+	for range min(max(workers, 1), len(uris)) {
+		wg.Go(func() {
+			for uri := range jobs {
+				if ctx.Err() != nil {
+					continue // drain after the first error
+				}
+
+				diagnostics := byURI[uri]
+				if diagnostics == nil {
+					diagnostics = []Diagnostic{}
+				}
+
+				err := c.PublishDiagnostics(ctx, &PublishDiagnosticsParams{
+					URI:         uri,
+					Version:     &version,
+					Diagnostics: diagnostics,
+				})
+				if err != nil {
+					once.Do(func() {
+						first = fmt.Errorf("publish diagnostics for %s: %w", uri, err)
+						cancel()
+					})
+				}
+			}
+		})
+	}
+
+	var stopped error
+
+	for _, uri := range uris {
+		if stopped = ctx.Err(); stopped != nil {
+			break
+		}
+
+		select {
+		case jobs <- uri:
+		case <-ctx.Done():
+			stopped = ctx.Err()
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if first != nil {
+		return first
+	}
+
+	return stopped
+}
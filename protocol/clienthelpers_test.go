@@ -0,0 +1,332 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/jsonrpc2"
+)
+
+// fakeClient is a Client that records the params of the calls made through
+// it. Methods not overridden here panic via the nil embedded interface.
+type fakeClient struct {
+	Client
+
+	showDocument *ShowDocumentParams
+	applyEdit    *ApplyWorkspaceEditParams
+	logMessage   *LogMessageParams
+	showMessage  *ShowMessageParams
+	registration *RegistrationParams
+	event        LSPAny
+
+	// publishErr, if set, is returned by PublishDiagnostics for publishErrURI.
+	publishErr    error
+	publishErrURI DocumentURI
+	publishHook   func()
+
+	mu        sync.Mutex
+	published []*PublishDiagnosticsParams
+}
+
+func (c *fakeClient) PublishDiagnostics(_ context.Context, params *PublishDiagnosticsParams) error {
+	if c.publishHook != nil {
+		c.publishHook()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.published = append(c.published, params)
+	if c.publishErr != nil && params.URI == c.publishErrURI {
+		return c.publishErr
+	}
+
+	return nil
+}
+
+func (c *fakeClient) LogMessage(_ context.Context, params *LogMessageParams) error {
+	c.logMessage = params
+	return nil
+}
+
+func (c *fakeClient) ShowMessage(_ context.Context, params *ShowMessageParams) error {
+	c.showMessage = params
+	return nil
+}
+
+func (c *fakeClient) ApplyEdit(
+	_ context.Context,
+	params *ApplyWorkspaceEditParams,
+) (*ApplyWorkspaceEditResult, error) {
+	c.applyEdit = params
+	return &ApplyWorkspaceEditResult{Applied: true}, nil
+}
+
+func (c *fakeClient) RegisterCapability(
+	_ context.Context,
+	params *RegistrationParams,
+) (any, error) {
+	c.registration = params
+	return nil, nil
+}
+
+func (c *fakeClient) Event(_ context.Context, params LSPAny) error {
+	c.event = params
+	return nil
+}
+
+func (c *fakeClient) ShowDocument(
+	_ context.Context,
+	params *ShowDocumentParams,
+) (*ShowDocumentResult, error) {
+	c.showDocument = params
+	return &ShowDocumentResult{Success: true}, nil
+}
+
+func TestShowDocument(t *testing.T) {
+	client := &fakeClient{}
+	sel := Range{
+		Start: Position{Line: 3, Character: 0},
+		End:   Position{Line: 3, Character: 8},
+	}
+
+	ok, err := ShowDocument(context.Background(), client, "https://example.com/docs", ShowDocumentOptions{
+		External:  true,
+		TakeFocus: false,
+		Selection: &sel,
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NotNil(t, client.showDocument)
+	assert.Equal(t, URI("https://example.com/docs"), client.showDocument.URI)
+	require.NotNil(t, client.showDocument.External)
+	assert.True(t, *client.showDocument.External)
+	require.NotNil(t, client.showDocument.TakeFocus)
+	assert.False(t, *client.showDocument.TakeFocus)
+	assert.Equal(t, &sel, client.showDocument.Selection)
+}
+
+func TestApplyEdit(t *testing.T) {
+	edit := WorkspaceEdit{Changes: map[DocumentURI][]TextEdit{
+		"file:///a.go": {{NewText: "// header\n"}},
+	}}
+	supporting := NewCapabilities(&ClientCapabilities{
+		Workspace: &WorkspaceClientCapabilities{ApplyEdit: new(true)},
+	})
+
+	t.Run("supported", func(t *testing.T) {
+		client := &fakeClient{}
+
+		res, err := ApplyEdit(context.Background(), client, supporting, "Add header", edit)
+		require.NoError(t, err)
+		assert.True(t, res.Applied)
+		require.NotNil(t, client.applyEdit)
+		assert.Equal(t, "Add header", *client.applyEdit.Label)
+		assert.Equal(t, edit, client.applyEdit.Edit)
+	})
+
+	t.Run("unchecked without capabilities", func(t *testing.T) {
+		client := &fakeClient{}
+
+		_, err := ApplyEdit(context.Background(), client, nil, "", edit)
+		require.NoError(t, err)
+		require.NotNil(t, client.applyEdit)
+		assert.Nil(t, client.applyEdit.Label)
+	})
+
+	for name, caps := range map[string]*ClientCapabilities{
+		"no workspace capabilities": {},
+		"applyEdit false":           {Workspace: &WorkspaceClientCapabilities{ApplyEdit: new(false)}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeClient{}
+
+			assert.False(t, NewCapabilities(caps).SupportsApplyEdit())
+
+			_, err := ApplyEdit(context.Background(), client, NewCapabilities(caps), "", edit)
+			require.ErrorIs(t, err, ErrUnsupported)
+			assert.Nil(t, client.applyEdit, "the client must not be contacted")
+		})
+	}
+
+	assert.True(t, supporting.SupportsApplyEdit())
+}
+
+func TestLogMessagef(t *testing.T) {
+	client := &fakeClient{}
+
+	err := LogMessagef(context.Background(), client, MessageTypeWarning,
+		"indexed %d files in %s", 42, "/src")
+	require.NoError(t, err)
+	require.NotNil(t, client.logMessage)
+	assert.Equal(t, MessageTypeWarning, client.logMessage.Type)
+	assert.Equal(t, "indexed 42 files in /src", client.logMessage.Message)
+}
+
+func TestShowMessagef(t *testing.T) {
+	client := &fakeClient{}
+
+	err := ShowMessagef(context.Background(), client, MessageTypeError, "cannot load %q", "go.mod")
+	require.NoError(t, err)
+	require.NotNil(t, client.showMessage)
+	assert.Equal(t, MessageTypeError, client.showMessage.Type)
+	assert.Equal(t, `cannot load "go.mod"`, client.showMessage.Message)
+}
+
+func TestPublishDiagnosticsBatch(t *testing.T) {
+	byURI := map[DocumentURI][]Diagnostic{
+		"file:///b.go": {{Message: "unused variable"}},
+		"file:///a.go": {{Message: "missing return"}, {Message: "undefined: x"}},
+		"file:///c.go": nil,
+	}
+
+	client := &fakeClient{}
+	require.NoError(t, PublishDiagnosticsBatch(context.Background(), client, byURI, 4))
+
+	require.Len(t, client.published, 3)
+
+	for i, uri := range []DocumentURI{"file:///a.go", "file:///b.go", "file:///c.go"} {
+		params := client.published[i]
+		assert.Equal(t, uri, params.URI)
+		assert.Equal(t, new(int32(4)), params.Version)
+		assert.Len(t, params.Diagnostics, len(byURI[uri]))
+		assert.NotNil(t, params.Diagnostics, "cleared diagnostics must be sent as []")
+	}
+
+	client = &fakeClient{publishErr: errors.New("broken pipe"), publishErrURI: "file:///a.go"}
+	err := PublishDiagnosticsBatch(context.Background(), client, byURI, 4)
+	require.ErrorIs(t, err, client.publishErr)
+	assert.Contains(t, err.Error(), "file:///a.go")
+	assert.Len(t, client.published, 1, "publishing stops at the first error")
+}
+
+func TestPublishDiagnosticsBatchConcurrent(t *testing.T) {
+	byURI := make(map[DocumentURI][]Diagnostic)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		byURI[DocumentURI("file:///"+name+".go")] = []Diagnostic{{Message: name}}
+	}
+
+	var running, peak atomic.Int32
+
+	client := &fakeClient{publishHook: func() {
+		n := running.Add(1)
+		for cur := peak.Load(); n > cur && !peak.CompareAndSwap(cur, n); cur = peak.Load() {
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+	}}
+	require.NoError(t, PublishDiagnosticsBatchConcurrent(context.Background(), client, byURI, 1, 3))
+
+	published := make(map[DocumentURI]bool)
+	for _, params := range client.published {
+		published[params.URI] = true
+	}
+
+	assert.Len(t, published, len(byURI), "every URI is published exactly once")
+	assert.Len(t, client.published, len(byURI))
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := PublishDiagnosticsBatchConcurrent(ctx, &fakeClient{}, byURI, 1, 3)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRegisterCapability(t *testing.T) {
+	caps := NewCapabilities(&ClientCapabilities{
+		TextDocument: &TextDocumentClientCapabilities{
+			Hover: &HoverClientCapabilities{DynamicRegistration: new(true)},
+		},
+	})
+	hover := Registration{ID: "hover", Method: MethodTextDocumentHover}
+	completion := Registration{ID: "completion", Method: MethodTextDocumentCompletion}
+
+	t.Run("supported", func(t *testing.T) {
+		client := &fakeClient{}
+
+		require.NoError(t, RegisterCapability(context.Background(), client, caps, hover))
+		require.NotNil(t, client.registration)
+		assert.Equal(t, []Registration{hover}, client.registration.Registrations)
+	})
+
+	t.Run("unsupported skipped", func(t *testing.T) {
+		client := &fakeClient{}
+		regs := []Registration{completion, hover}
+
+		err := RegisterCapability(context.Background(), client, caps, regs...)
+		require.ErrorIs(t, err, ErrUnsupported)
+		require.ErrorContains(t, err, MethodTextDocumentCompletion)
+		require.NotNil(t, client.registration)
+		assert.Equal(t, []Registration{hover}, client.registration.Registrations)
+		assert.Equal(t, []Registration{completion, hover}, regs, "regs must not be modified")
+	})
+
+	t.Run("only unsupported", func(t *testing.T) {
+		client := &fakeClient{}
+
+		err := RegisterCapability(context.Background(), client, caps, completion)
+		require.ErrorIs(t, err, ErrUnsupported)
+		assert.Nil(t, client.registration, "the client must not be contacted")
+	})
+
+	t.Run("unchecked without capabilities", func(t *testing.T) {
+		client := &fakeClient{}
+
+		require.NoError(t, RegisterCapability(context.Background(), client, nil, completion))
+		require.NotNil(t, client.registration)
+		assert.Equal(t, []Registration{completion}, client.registration.Registrations)
+	})
+}
+
+func TestTelemetryError(t *testing.T) {
+	t.Run("plain error", func(t *testing.T) {
+		client := &fakeClient{}
+
+		err := TelemetryError(context.Background(), client, MethodTextDocumentHover,
+			errors.New("index not ready"))
+		require.NoError(t, err)
+
+		data, err := json.Marshal(client.event)
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"kind": "error", "method": "textDocument/hover", "message": "index not ready"}`,
+			string(data))
+	})
+
+	t.Run("JSON-RPC error", func(t *testing.T) {
+		client := &fakeClient{}
+		rpcErr := jsonrpc2.NewError(jsonrpc2.Code(CodeContentModified), "stale")
+
+		err := TelemetryError(context.Background(), client, MethodTextDocumentCompletion,
+			fmt.Errorf("completion: %w", rpcErr))
+		require.NoError(t, err)
+
+		event, ok := client.event.(TelemetryErrorEvent)
+		require.True(t, ok)
+		assert.Equal(t, MethodTextDocumentCompletion, event.Method)
+		assert.Contains(t, event.Message, "stale")
+		require.NotNil(t, event.Code)
+		assert.Equal(t, CodeContentModified, *event.Code)
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		client := &fakeClient{}
+
+		require.NoError(t, TelemetryError(context.Background(), client, MethodTextDocumentHover, nil))
+		assert.Nil(t, client.event, "nothing is sent")
+	})
+}
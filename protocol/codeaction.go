@@ -0,0 +1,50 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides a fluent builder for CodeAction values.
+//
+// A code action carries an edit, a command, or both. When both are present
+// the client applies the edit first and then executes the command. In the
+// codeAction/resolve flow the edit is typically omitted from the initial
+// response and filled in later with WithEdit.
+
+// NewCodeAction returns a CodeAction with the given title and kind. Use the
+// With* methods to attach an edit, a command, or diagnostics.
+//
+//	action := protocol.NewCodeAction("Remove unused import", protocol.CodeActionKindQuickFix).
+//		WithEdit(edit).
+//		WithDiagnostics(diag).
+//		AsPreferred()
+func NewCodeAction(title string, kind CodeActionKind) CodeAction {
+	return CodeAction{ //nolint:exhaustruct
+		Title: title,
+		Kind:  &kind,
+	}
+}
+
+// WithEdit returns a copy of a with its workspace edit set to edit.
+func (a CodeAction) WithEdit(edit WorkspaceEdit) CodeAction {
+	a.Edit = &edit
+	return a
+}
+
+// WithCommand returns a copy of a with its command set to cmd.
+func (a CodeAction) WithCommand(cmd Command) CodeAction {
+	a.Command = &cmd
+	return a
+}
+
+// WithDiagnostics returns a copy of a that also resolves diags.
+func (a CodeAction) WithDiagnostics(diags ...Diagnostic) CodeAction {
+	a.Diagnostics = append(a.Diagnostics[:len(a.Diagnostics):len(a.Diagnostics)], diags...)
+	return a
+}
+
+// AsPreferred returns a copy of a marked as the preferred action, which
+// clients may apply with the "auto fix" command.
+func (a CodeAction) AsPreferred() CodeAction {
+	a.IsPreferred = new(true)
+	return a
+}
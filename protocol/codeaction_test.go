@@ -0,0 +1,73 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCodeAction(t *testing.T) {
+	diag := Diagnostic{
+		Range: Range{
+			Start: Position{Line: 2, Character: 1},
+			End:   Position{Line: 2, Character: 6},
+		},
+		Message: "unused import",
+	}
+
+	t.Run("edit only", func(t *testing.T) {
+		edit := WorkspaceEdit{Changes: map[DocumentURI][]TextEdit{
+			"file:///a.go": {{Range: diag.Range, NewText: ""}},
+		}}
+
+		action := NewCodeAction("Remove import", CodeActionKindQuickFix).
+			WithEdit(edit).
+			WithDiagnostics(diag).
+			AsPreferred()
+
+		data, err := json.Marshal(action)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"title": "Remove import",
+			"kind": "quickfix",
+			"diagnostics": [{
+				"range": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 6}},
+				"message": "unused import"
+			}],
+			"isPreferred": true,
+			"edit": {"changes": {"file:///a.go": [{
+				"range": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 6}},
+				"newText": ""
+			}]}}
+		}`, string(data))
+	})
+
+	t.Run("command only", func(t *testing.T) {
+		action := NewCodeAction("Organize imports", CodeActionKindSourceOrganizeImports).
+			WithCommand(Command{Title: "Organize imports", Command: "go.organizeImports"})
+
+		data, err := json.Marshal(action)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"title": "Organize imports",
+			"kind": "source.organizeImports",
+			"command": {"title": "Organize imports", "command": "go.organizeImports"}
+		}`, string(data))
+	})
+
+	t.Run("builder does not alias", func(t *testing.T) {
+		base := NewCodeAction("Fix", CodeActionKindQuickFix).WithDiagnostics(diag)
+		a := base.WithDiagnostics(diag)
+		b := base.AsPreferred()
+
+		assert.Len(t, base.Diagnostics, 1)
+		assert.Len(t, a.Diagnostics, 2)
+		assert.Nil(t, base.IsPreferred)
+		assert.True(t, *b.IsPreferred)
+	})
+}
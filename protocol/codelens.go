@@ -0,0 +1,41 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for CodeLens values. A server may answer
+// textDocument/codeLens with lenses carrying only a range and Data, and
+// fill in their commands when the client sends each lens back in
+// codeLens/resolve.
+
+// NewCodeLens returns a CodeLens valid in r without a command, to be
+// resolved later through codeLens/resolve. Attach the context needed to
+// resolve it with WithData.
+//
+//	lens := protocol.NewCodeLens(r).WithData(testRef{Name: "TestFoo"})
+func NewCodeLens(r Range) CodeLens {
+	return CodeLens{ //nolint:exhaustruct
+		Range: r,
+	}
+}
+
+// WithCommand returns a copy of l with its command set to cmd, typically
+// when resolving it.
+func (l CodeLens) WithCommand(cmd Command) CodeLens {
+	l.Command = &cmd
+	return l
+}
+
+// WithData returns a copy of l carrying v in its Data, which the client
+// returns unchanged in codeLens/resolve. v must be JSON-serializable.
+func (l CodeLens) WithData(v any) CodeLens {
+	l.Data = &v
+	return l
+}
+
+// DecodeData decodes the lens's Data into v, which must be a pointer. It
+// works both on lenses built in Go and on lenses decoded from JSON, where
+// Data holds a map[string]any.
+func (l CodeLens) DecodeData(v any) error {
+	return decodeData(l.Data, v)
+}
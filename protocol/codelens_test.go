@@ -0,0 +1,72 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeLens(t *testing.T) {
+	type testRef struct {
+		Name string `json:"name"`
+		Line int    `json:"line"`
+	}
+
+	r := rng(12, 0, 12, 20)
+	ref := testRef{Name: "TestParse", Line: 12}
+
+	t.Run("lazy", func(t *testing.T) {
+		lens := NewCodeLens(r)
+		assert.Equal(t, r, lens.Range)
+		assert.Nil(t, lens.Command)
+		assert.Nil(t, lens.Data)
+
+		data, err := json.Marshal(lens)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"range": {"start": {"line": 12, "character": 0}, "end": {"line": 12, "character": 20}}
+		}`, string(data))
+	})
+
+	t.Run("resolved", func(t *testing.T) {
+		lazy := NewCodeLens(r).WithData(ref)
+		lens := lazy.WithCommand(NewCommand("run test", "go.test.run", ref.Name))
+
+		assert.Nil(t, lazy.Command, "WithCommand must not modify the receiver")
+		require.NotNil(t, lens.Command)
+		assert.Equal(t, "go.test.run", lens.Command.Command)
+
+		data, err := json.Marshal(lens)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"range": {"start": {"line": 12, "character": 0}, "end": {"line": 12, "character": 20}},
+			"command": {"title": "run test", "command": "go.test.run", "arguments": ["TestParse"]},
+			"data": {"name": "TestParse", "line": 12}
+		}`, string(data))
+	})
+
+	t.Run("data round trip", func(t *testing.T) {
+		lens := NewCodeLens(r).WithData(ref)
+
+		var direct testRef
+		require.NoError(t, lens.DecodeData(&direct))
+		assert.Equal(t, ref, direct)
+
+		raw, err := json.Marshal(lens)
+		require.NoError(t, err)
+
+		var echoed CodeLens
+		require.NoError(t, json.Unmarshal(raw, &echoed))
+
+		var got testRef
+		require.NoError(t, echoed.DecodeData(&got))
+		assert.Equal(t, ref, got)
+
+		require.Error(t, NewCodeLens(r).DecodeData(&got))
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides constructors for the document color provider results
+// (textDocument/documentColor and textDocument/colorPresentation).
+
+import "fmt"
+
+// NewColor returns the Color with the given RGBA components. It returns an
+// error if a component is outside the range [0, 1] (see Validate).
+func NewColor(r, g, b, a float64) (Color, error) {
+	c := Color{Red: r, Green: g, Blue: b, Alpha: a}
+
+	return c, c.Validate()
+}
+
+// Validate returns an error if a component of c is outside [0, 1] (or NaN).
+func (c Color) Validate() error {
+	for _, comp := range []struct {
+		name  string
+		value float64
+	}{
+		{"red", c.Red},
+		{"green", c.Green},
+		{"blue", c.Blue},
+		{"alpha", c.Alpha},
+	} {
+		if !(comp.value >= 0 && comp.value <= 1) {
+			return fmt.Errorf("color %s component %v is outside [0, 1]", comp.name, comp.value) //nolint:err113
+		}
+	}
+
+	return nil
+}
+
+// NewColorInformation returns the ColorInformation reporting color c at rng,
+// as returned by textDocument/documentColor.
+func NewColorInformation(rng Range, c Color) ColorInformation {
+	return ColorInformation{Range: rng, Color: c}
+}
+
+// NewColorPresentation returns a ColorPresentation shown as label in the
+// color picker which, when selected, applies edit to the document.
+func NewColorPresentation(label string, edit TextEdit) ColorPresentation {
+	return ColorPresentation{ //nolint:exhaustruct
+		Label:    label,
+		TextEdit: &edit,
+	}
+}
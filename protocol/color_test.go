@@ -0,0 +1,69 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColorResults(t *testing.T) {
+	rng := Range{
+		Start: Position{Line: 4, Character: 10},
+		End:   Position{Line: 4, Character: 17},
+	}
+
+	color, err := NewColor(1, 0.5, 0, 1)
+	require.NoError(t, err)
+
+	info := NewColorInformation(rng, color)
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"range": {"start": {"line": 4, "character": 10}, "end": {"line": 4, "character": 17}},
+		"color": {"red": 1, "green": 0.5, "blue": 0, "alpha": 1}
+	}`, string(data))
+
+	pres := NewColorPresentation("#ff8000", TextEdit{Range: rng, NewText: `"#ff8000"`})
+	data, err = json.Marshal(pres)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"label": "#ff8000",
+		"textEdit": {
+			"range": {"start": {"line": 4, "character": 10}, "end": {"line": 4, "character": 17}},
+			"newText": "\"#ff8000\""
+		}
+	}`, string(data))
+}
+
+func TestColorValidate(t *testing.T) {
+	_, err := NewColor(0, 0.25, 1, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		r, g, b, a float64
+		want       string
+	}{
+		{"red above one", 1.5, 0, 0, 1, "red"},
+		{"green negative", 0, -0.1, 0, 1, "green"},
+		{"blue NaN", 0, 0, math.NaN(), 1, "blue"},
+		{"alpha as byte", 0, 0, 0, 255, "alpha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewColor(tt.r, tt.g, tt.b, tt.a)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+
+			color := Color{Red: tt.r, Green: tt.g, Blue: tt.b, Alpha: tt.a}
+			require.Error(t, color.Validate())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for Command, the reference to a server command
+// attached to code lenses, code actions and other results. The client sends
+// the arguments back unchanged in workspace/executeCommand.
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NewCommand returns the command titled title invoking command with args.
+// Each argument is stored in its JSON encoding, so the command carries the
+// same arguments whether it is sent to the client or used in Go; an argument
+// that cannot be encoded is stored as is, and sending the command fails.
+func NewCommand(title, command string, args ...any) Command {
+	cmd := Command{Title: title, Command: command, Arguments: nil}
+
+	for _, arg := range args {
+		raw, err := json.Marshal(arg)
+		if err != nil {
+			cmd.Arguments = append(cmd.Arguments, arg)
+			continue
+		}
+
+		cmd.Arguments = append(cmd.Arguments, json.RawMessage(raw))
+	}
+
+	return cmd
+}
+
+// DecodeArgs decodes the arguments of c into targets, which must be
+// pointers, in order. It works both on commands built with NewCommand and on
+// commands decoded from JSON, whose arguments hold map[string]any and other
+// generic values. Arguments beyond the targets are ignored; it returns an
+// error if there are fewer arguments than targets.
+func (c Command) DecodeArgs(targets ...any) error {
+	if len(targets) > len(c.Arguments) {
+		return fmt.Errorf( //nolint:err113
+			"command %s: want %d arguments, got %d", c.Command, len(targets), len(c.Arguments))
+	}
+
+	for idx, target := range targets {
+		raw, err := json.Marshal(c.Arguments[idx])
+		if err != nil {
+			return fmt.Errorf("command %s: encode argument %d: %w", c.Command, idx, err)
+		}
+
+		if err := json.Unmarshal(raw, target); err != nil { //nolint:noinlineerr
+			return fmt.Errorf("command %s: decode argument %d: %w", c.Command, idx, err)
+		}
+	}
+
+	return nil
+}
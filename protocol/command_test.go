@@ -0,0 +1,84 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommand(t *testing.T) {
+	type target struct {
+		URI   DocumentURI `json:"uri"`
+		Range Range       `json:"range"`
+	}
+
+	arg := target{URI: "file:///main.go", Range: rng(1, 0, 1, 4)}
+	cmd := NewCommand("Apply fix", "go.applyFix", arg, 3, "quick", true, nil)
+
+	assert.Equal(t, "Apply fix", cmd.Title)
+	assert.Equal(t, "go.applyFix", cmd.Command)
+	require.Len(t, cmd.Arguments, 5)
+
+	data, err := json.Marshal(cmd)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"title": "Apply fix",
+		"command": "go.applyFix",
+		"arguments": [
+			{"uri": "file:///main.go", "range": {
+				"start": {"line": 1, "character": 0}, "end": {"line": 1, "character": 4}
+			}},
+			3, "quick", true, null
+		]
+	}`, string(data))
+
+	var decoded Command
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	for name, c := range map[string]Command{"built": cmd, "decoded": decoded} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				gotTarget target
+				gotCount  int
+				gotMode   string
+				gotForce  bool
+			)
+
+			require.NoError(t, c.DecodeArgs(&gotTarget, &gotCount, &gotMode, &gotForce))
+			assert.Equal(t, arg, gotTarget)
+			assert.Equal(t, 3, gotCount)
+			assert.Equal(t, "quick", gotMode)
+			assert.True(t, gotForce)
+		})
+	}
+}
+
+func TestCommand_DecodeArgs(t *testing.T) {
+	cmd := NewCommand("Run", "run", "first", 2)
+
+	t.Run("fewer targets", func(t *testing.T) {
+		var first string
+		require.NoError(t, cmd.DecodeArgs(&first))
+		assert.Equal(t, "first", first)
+	})
+
+	t.Run("too many targets", func(t *testing.T) {
+		var first, second, third string
+		require.ErrorContains(t, cmd.DecodeArgs(&first, &second, &third),
+			"want 3 arguments, got 2")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var first, second string
+		require.ErrorContains(t, cmd.DecodeArgs(&first, &second), "decode argument 1")
+	})
+
+	t.Run("no arguments", func(t *testing.T) {
+		require.NoError(t, NewCommand("Run", "run").DecodeArgs())
+	})
+}
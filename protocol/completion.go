@@ -0,0 +1,284 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides a fluent builder for the CompletionOptions a server
+// advertises as its completionProvider, support for completionItem/resolve,
+// and merging of completion results from several providers.
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// NewCompletionOptions returns CompletionOptions that trigger completion
+// automatically on the given characters, in addition to identifier
+// characters, which never need to be listed.
+//
+//	caps.CompletionProvider = new(protocol.NewCompletionOptions(".", ":").
+//		WithResolve().
+//		WithLabelDetails())
+func NewCompletionOptions(triggers ...string) CompletionOptions {
+	return CompletionOptions{TriggerCharacters: triggers} //nolint:exhaustruct
+}
+
+// WithCommitCharacters returns a copy of o whose items are committed by
+// typing any of chars, for clients without per-item commit characters.
+func (o CompletionOptions) WithCommitCharacters(chars ...string) CompletionOptions {
+	o.AllCommitCharacters = chars
+	return o
+}
+
+// WithResolve returns a copy of o announcing that the server handles
+// completionItem/resolve.
+func (o CompletionOptions) WithResolve() CompletionOptions {
+	o.ResolveProvider = new(true)
+	return o
+}
+
+// WithLabelDetails returns a copy of o announcing that the server fills in
+// CompletionItem.LabelDetails when resolving items.
+func (o CompletionOptions) WithLabelDetails() CompletionOptions {
+	o.CompletionItem = &ServerCompletionItemOptions{LabelDetailsSupport: new(true)}
+	return o
+}
+
+// MergeResolved returns item with every field that resolved sets filled in
+// from resolved, as a completionItem/resolve handler does after computing
+// the expensive properties (documentation, additional text edits, ...) of
+// the bare item the client sent. Fields resolved leaves unset keep item's
+// value, and item's Data is always kept so the client can resolve again.
+func (item CompletionItem) MergeResolved(resolved CompletionItem) CompletionItem {
+	dst := reflect.ValueOf(&item).Elem()
+	src := reflect.ValueOf(resolved)
+
+	for i := range src.NumField() {
+		if dst.Type().Field(i).Name == "Data" || src.Field(i).IsZero() {
+			continue
+		}
+
+		dst.Field(i).Set(src.Field(i))
+	}
+
+	return item
+}
+
+// MergeCompletionLists combines the results of several completion providers
+// into one list. Items are concatenated in order and the result is
+// incomplete if any input is.
+//
+// An item default (and its apply kind) survives only if every list declares
+// the same value for it; otherwise each list's default is folded into its own
+// items, following the list's apply kinds, so that no item picks up a default
+// meant for another provider.
+func MergeCompletionLists(lists ...CompletionList) CompletionList {
+	merged := CompletionList{Items: []CompletionItem{}} //nolint:exhaustruct
+	if len(lists) == 0 {
+		return merged
+	}
+
+	keep := sharedCompletionDefaults(lists)
+
+	for _, list := range lists {
+		merged.IsIncomplete = merged.IsIncomplete || list.IsIncomplete
+
+		for _, item := range list.Items {
+			merged.Items = append(merged.Items, foldCompletionDefaults(item, list, keep))
+		}
+	}
+
+	first := lists[0]
+	defaults := completionDefaults(first)
+
+	var kept CompletionItemDefaults
+
+	if keep.commitCharacters {
+		kept.CommitCharacters = defaults.CommitCharacters
+	}
+
+	if keep.editRange {
+		kept.EditRange = defaults.EditRange
+	}
+
+	if keep.insertTextFormat {
+		kept.InsertTextFormat = defaults.InsertTextFormat
+	}
+
+	if keep.insertTextMode {
+		kept.InsertTextMode = defaults.InsertTextMode
+	}
+
+	if keep.data {
+		kept.Data = defaults.Data
+	}
+
+	if !reflect.ValueOf(kept).IsZero() {
+		merged.ItemDefaults = &kept
+	}
+
+	if first.ApplyKind != nil {
+		var kinds CompletionItemApplyKinds
+
+		if keep.commitCharacters && kept.CommitCharacters != nil {
+			kinds.CommitCharacters = first.ApplyKind.CommitCharacters
+		}
+
+		if keep.data && kept.Data != nil {
+			kinds.Data = first.ApplyKind.Data
+		}
+
+		if kinds.CommitCharacters != nil || kinds.Data != nil {
+			merged.ApplyKind = &kinds
+		}
+	}
+
+	return merged
+}
+
+// keptDefaults records which item defaults all merged lists agree on.
+type keptDefaults struct {
+	commitCharacters, editRange, insertTextFormat, insertTextMode, data bool
+}
+
+func sharedCompletionDefaults(lists []CompletionList) keptDefaults {
+	keep := keptDefaults{
+		commitCharacters: true,
+		editRange:        true,
+		insertTextFormat: true,
+		insertTextMode:   true,
+		data:             true,
+	}
+
+	first := completionDefaults(lists[0])
+
+	for _, list := range lists[1:] {
+		other := completionDefaults(list)
+
+		keep.commitCharacters = keep.commitCharacters &&
+			slices.Equal(first.CommitCharacters, other.CommitCharacters) &&
+			commitCharactersKind(lists[0]) == commitCharactersKind(list)
+		keep.editRange = keep.editRange && reflect.DeepEqual(first.EditRange, other.EditRange)
+		keep.insertTextFormat = keep.insertTextFormat &&
+			reflect.DeepEqual(first.InsertTextFormat, other.InsertTextFormat)
+		keep.insertTextMode = keep.insertTextMode &&
+			reflect.DeepEqual(first.InsertTextMode, other.InsertTextMode)
+		keep.data = keep.data &&
+			LSPAnyEqual(lspAnyValue(first.Data), lspAnyValue(other.Data)) &&
+			dataKind(lists[0]) == dataKind(list)
+	}
+
+	return keep
+}
+
+// foldCompletionDefaults returns item with the defaults of list that are not
+// kept in the merged list applied to it.
+func foldCompletionDefaults(item CompletionItem, list CompletionList, keep keptDefaults) CompletionItem {
+	defaults := completionDefaults(list)
+
+	if !keep.commitCharacters && defaults.CommitCharacters != nil {
+		switch {
+		case commitCharactersKind(list) == ApplyKindMerge:
+			union := slices.Clone(defaults.CommitCharacters)
+			for _, ch := range item.CommitCharacters {
+				if !slices.Contains(union, ch) {
+					union = append(union, ch)
+				}
+			}
+
+			item.CommitCharacters = union
+		case item.CommitCharacters == nil:
+			item.CommitCharacters = defaults.CommitCharacters
+		}
+	}
+
+	if !keep.editRange && defaults.EditRange != nil && item.TextEdit == nil {
+		item.TextEdit = new(editFromDefaultRange(*defaults.EditRange, item))
+	}
+
+	if !keep.insertTextFormat && item.InsertTextFormat == nil {
+		item.InsertTextFormat = defaults.InsertTextFormat
+	}
+
+	if !keep.insertTextMode && item.InsertTextMode == nil {
+		item.InsertTextMode = defaults.InsertTextMode
+	}
+
+	if !keep.data && defaults.Data != nil {
+		switch {
+		case item.Data == nil || *item.Data == nil:
+			item.Data = defaults.Data
+		case dataKind(list) == ApplyKindMerge:
+			base, baseOK := (*defaults.Data).(map[string]any)
+			own, ownOK := (*item.Data).(map[string]any)
+
+			if baseOK && ownOK {
+				shallow := maps.Clone(base)
+				maps.Copy(shallow, own)
+
+				var data LSPAny = shallow
+
+				item.Data = &data
+			}
+		}
+	}
+
+	return item
+}
+
+// editFromDefaultRange builds the text edit an item without one gets from
+// the list's default edit range: a TextEdit for a Range, or an
+// InsertReplaceEdit for an insert/replace pair. The new text is the item's
+// TextEditText, falling back to its label.
+func editFromDefaultRange(
+	editRange OrRangeEditRangeWithInsertReplace,
+	item CompletionItem,
+) OrTextEditInsertReplaceEdit {
+	newText := item.Label
+	if item.TextEditText != nil {
+		newText = *item.TextEditText
+	}
+
+	var edit OrTextEditInsertReplaceEdit
+
+	if r, ok := editRange.AsEditRangeWithInsertReplace(); ok {
+		edit.SetInsertReplaceEdit(InsertReplaceEdit{NewText: newText, Insert: r.Insert, Replace: r.Replace})
+	} else if r, ok := editRange.AsRange(); ok {
+		edit.SetTextEdit(TextEdit{Range: r, NewText: newText})
+	}
+
+	return edit
+}
+
+func completionDefaults(list CompletionList) CompletionItemDefaults {
+	if list.ItemDefaults == nil {
+		return CompletionItemDefaults{} //nolint:exhaustruct
+	}
+
+	return *list.ItemDefaults
+}
+
+func commitCharactersKind(list CompletionList) ApplyKind {
+	if list.ApplyKind == nil || list.ApplyKind.CommitCharacters == nil {
+		return ApplyKindReplace
+	}
+
+	return *list.ApplyKind.CommitCharacters
+}
+
+func dataKind(list CompletionList) ApplyKind {
+	if list.ApplyKind == nil || list.ApplyKind.Data == nil {
+		return ApplyKindReplace
+	}
+
+	return *list.ApplyKind.Data
+}
+
+func lspAnyValue(v *LSPAny) any {
+	if v == nil {
+		return nil
+	}
+
+	return *v
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCompletionOptions(t *testing.T) {
+	t.Run("triggers only", func(t *testing.T) {
+		data, err := json.Marshal(NewCompletionOptions(".", ":"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"triggerCharacters": [".", ":"]}`, string(data))
+	})
+
+	t.Run("fully populated", func(t *testing.T) {
+		opts := NewCompletionOptions(".").
+			WithCommitCharacters("(", ";").
+			WithResolve().
+			WithLabelDetails()
+
+		data, err := json.Marshal(opts)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"triggerCharacters": ["."],
+			"allCommitCharacters": ["(", ";"],
+			"resolveProvider": true,
+			"completionItem": {"labelDetailsSupport": true}
+		}`, string(data))
+	})
+
+	t.Run("builders return copies", func(t *testing.T) {
+		base := NewCompletionOptions(".")
+		_ = base.WithResolve()
+
+		assert.Nil(t, base.ResolveProvider)
+	})
+}
+
+func TestCompletionItem_MergeResolved(t *testing.T) {
+	var data LSPAny = map[string]any{"symbol": "fmt.Println"}
+
+	bare := CompletionItem{
+		Label:  "Println",
+		Kind:   new(CompletionItemKindFunction),
+		Detail: new("func(a ...any)"),
+		Data:   &data,
+	}
+	edits := []TextEdit{{
+		Range:   Range{Start: Position{Line: 2, Character: 0}, End: Position{Line: 2, Character: 0}},
+		NewText: "import \"fmt\"\n",
+	}}
+
+	got := bare.MergeResolved(CompletionItem{
+		Label:               "Println",
+		Documentation:       newMarkdownContent("Println formats using the default formats."),
+		AdditionalTextEdits: edits,
+	})
+
+	assert.Equal(t, "Println", got.Label)
+	assert.Equal(t, CompletionItemKindFunction, *got.Kind)
+	assert.Equal(t, "func(a ...any)", *got.Detail, "unset fields keep the original value")
+	assert.Equal(t, edits, got.AdditionalTextEdits)
+	assert.Equal(t, &data, got.Data)
+
+	text, kind, ok := got.DocumentationText()
+	require.True(t, ok)
+	assert.Equal(t, MarkupKindMarkdown, kind)
+	assert.Equal(t, "Println formats using the default formats.", text)
+
+	assert.Nil(t, bare.Documentation, "the original item must not be modified")
+}
+
+func TestMergeCompletionLists(t *testing.T) {
+	editRange := Range{Start: Position{Line: 3, Character: 4}, End: Position{Line: 3, Character: 7}}
+
+	var defaultRange OrRangeEditRangeWithInsertReplace
+	defaultRange.SetRange(editRange)
+
+	local := CompletionList{
+		IsIncomplete: true,
+		ItemDefaults: &CompletionItemDefaults{
+			CommitCharacters: []string{"."},
+			EditRange:        &defaultRange,
+			InsertTextFormat: new(InsertTextFormatSnippet),
+		},
+		Items: []CompletionItem{
+			{Label: "fmt", TextEditText: new("fmt.")},
+			{Label: "flag", InsertTextFormat: new(InsertTextFormatPlainText)},
+		},
+	}
+	keywords := CompletionList{
+		ItemDefaults: &CompletionItemDefaults{
+			CommitCharacters: []string{"."},
+			InsertTextFormat: new(InsertTextFormatPlainText),
+		},
+		Items: []CompletionItem{{Label: "func"}},
+	}
+
+	merged := MergeCompletionLists(local, keywords)
+
+	assert.True(t, merged.IsIncomplete, "incomplete if any input is")
+	assert.Equal(t, &CompletionItemDefaults{CommitCharacters: []string{"."}}, merged.ItemDefaults,
+		"only the agreed default survives")
+	require.Len(t, merged.Items, 3)
+
+	require.NotNil(t, merged.Items[0].TextEdit)
+	edit, ok := merged.Items[0].TextEdit.AsTextEdit()
+	require.True(t, ok)
+	assert.Equal(t, TextEdit{Range: editRange, NewText: "fmt."}, edit)
+	assert.Equal(t, InsertTextFormatSnippet, *merged.Items[0].InsertTextFormat)
+
+	require.NotNil(t, merged.Items[1].TextEdit)
+	edit, ok = merged.Items[1].TextEdit.AsTextEdit()
+	require.True(t, ok)
+	assert.Equal(t, TextEdit{Range: editRange, NewText: "flag"}, edit)
+	assert.Equal(t, InsertTextFormatPlainText, *merged.Items[1].InsertTextFormat, "item values win")
+
+	assert.Nil(t, merged.Items[2].TextEdit, "no edit range leaks into other lists")
+	assert.Equal(t, InsertTextFormatPlainText, *merged.Items[2].InsertTextFormat)
+	assert.Nil(t, merged.Items[2].CommitCharacters)
+
+	t.Run("all complete", func(t *testing.T) {
+		merged := MergeCompletionLists(keywords, CompletionList{Items: []CompletionItem{{Label: "go"}}})
+
+		assert.False(t, merged.IsIncomplete)
+		assert.Nil(t, merged.ItemDefaults)
+		assert.Equal(t, []string{"."}, merged.Items[0].CommitCharacters)
+		assert.Nil(t, merged.Items[1].CommitCharacters)
+	})
+
+	t.Run("merge apply kinds", func(t *testing.T) {
+		var data LSPAny = map[string]any{"provider": "snippets", "rank": 1}
+
+		var own LSPAny = map[string]any{"rank": 2}
+
+		snippets := CompletionList{
+			ItemDefaults: &CompletionItemDefaults{CommitCharacters: []string{"("}, Data: &data},
+			ApplyKind: &CompletionItemApplyKinds{
+				CommitCharacters: new(ApplyKindMerge),
+				Data:             new(ApplyKindMerge),
+			},
+			Items: []CompletionItem{{Label: "forr", CommitCharacters: []string{"{"}, Data: &own}},
+		}
+
+		merged := MergeCompletionLists(snippets, CompletionList{Items: []CompletionItem{{Label: "for"}}})
+
+		assert.Nil(t, merged.ApplyKind)
+		assert.Equal(t, []string{"(", "{"}, merged.Items[0].CommitCharacters)
+		assert.Equal(t, map[string]any{"provider": "snippets", "rank": 2}, *merged.Items[0].Data)
+		assert.Nil(t, merged.Items[1].Data)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		data, err := json.Marshal(MergeCompletionLists())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"isIncomplete": false, "items": []}`, string(data))
+	})
+}
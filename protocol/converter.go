@@ -0,0 +1,48 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides PositionConverter, which confines the position encoding
+// negotiated with the client to the protocol boundary so that server logic
+// can work with byte offsets throughout.
+
+// PositionConverter translates between the Positions and Ranges exchanged
+// with a client, measured in the negotiated position encoding, and byte
+// offsets into a document. It is a Mapper narrowed to those conversions,
+// and is immutable and safe for concurrent use.
+type PositionConverter struct {
+	mapper *Mapper
+}
+
+// NewPositionConverter returns a PositionConverter for text using encoding,
+// typically InitializeResult.PositionEncoding(). An empty encoding means
+// UTF-16.
+func NewPositionConverter(encoding PositionEncodingKind, text string) *PositionConverter {
+	return &PositionConverter{mapper: NewMapperWithEncoding(text, encoding)}
+}
+
+// Encoding returns the negotiated position encoding.
+func (c *PositionConverter) Encoding() PositionEncodingKind {
+	return c.mapper.Encoding()
+}
+
+// Offset converts an incoming client position to a byte offset.
+func (c *PositionConverter) Offset(pos Position) (int, error) {
+	return c.mapper.Offset(pos)
+}
+
+// Position converts a byte offset to an outgoing client position.
+func (c *PositionConverter) Position(offset int) (Position, error) {
+	return c.mapper.PositionAt(offset)
+}
+
+// Offsets converts an incoming client range to start and end byte offsets.
+func (c *PositionConverter) Offsets(r Range) (int, int, error) {
+	return c.mapper.Offsets(r)
+}
+
+// Range converts the byte offsets [start, end) to an outgoing client range.
+func (c *PositionConverter) Range(start, end int) (Range, error) {
+	return c.mapper.RangeAt(start, end)
+}
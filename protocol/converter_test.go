@@ -0,0 +1,66 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionConverter(t *testing.T) {
+	// 😀 is four bytes in UTF-8 and a surrogate pair in UTF-16.
+	const doc = "s := \"😀\" + name\n"
+
+	nameOffset := strings.Index(doc, "name")
+	conv16 := NewPositionConverter(PositionEncodingKindUTF16, doc)
+	conv8 := NewPositionConverter(PositionEncodingKindUTF8, doc)
+
+	assert.Equal(t, PositionEncodingKindUTF16, NewPositionConverter("", doc).Encoding())
+
+	// The same byte offset is a different column in each encoding.
+	pos16, err := conv16.Position(nameOffset)
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 0, Character: 12}, pos16)
+
+	pos8, err := conv8.Position(nameOffset)
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 0, Character: 14}, pos8)
+
+	// A UTF-16 position from a client round-trips to a UTF-8 position via
+	// the byte offset.
+	offset, err := conv16.Offset(pos16)
+	require.NoError(t, err)
+	assert.Equal(t, nameOffset, offset)
+
+	got, err := conv8.Position(offset)
+	require.NoError(t, err)
+	assert.Equal(t, pos8, got)
+
+	t.Run("ranges", func(t *testing.T) {
+		emoji := strings.Index(doc, "😀")
+
+		r, err := conv16.Range(emoji, emoji+len("😀"))
+		require.NoError(t, err)
+		assert.Equal(t, Range{Start: Position{Character: 6}, End: Position{Character: 8}}, r)
+
+		start, end, err := conv16.Offsets(r)
+		require.NoError(t, err)
+		assert.Equal(t, "😀", doc[start:end])
+
+		r8, err := conv8.Range(start, end)
+		require.NoError(t, err)
+		assert.Equal(t, Range{Start: Position{Character: 6}, End: Position{Character: 10}}, r8)
+	})
+
+	t.Run("inside the emoji", func(t *testing.T) {
+		_, err := conv16.Offset(Position{Line: 0, Character: 7})
+		require.Error(t, err)
+
+		_, _, err = conv8.Offsets(Range{End: Position{Line: 0, Character: 8}})
+		require.Error(t, err)
+	})
+}
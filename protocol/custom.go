@@ -0,0 +1,74 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file implements the registry of custom (non-spec) methods whose
+// params should be decoded into a concrete Go type before they reach the
+// Server.Request catch-all, e.g. `$/`-prefixed extensions with known shapes.
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+var (
+	customMethodsMu sync.RWMutex                    //nolint:gochecknoglobals
+	customMethods   = make(map[string]reflect.Type) //nolint:gochecknoglobals
+)
+
+// RegisterCustomMethod registers paramsType as the params type of a custom
+// method. When a request or notification for method reaches the
+// Server.Request catch-all, its params are decoded into a new value of
+// paramsType and passed as a pointer to it (e.g. *MyParams for
+// reflect.TypeFor[MyParams]()), mirroring the typed Server methods.
+//
+// Registering the same method again replaces the previous type. Methods that
+// are not registered keep receiving params decoded into a plain any.
+func RegisterCustomMethod(method string, paramsType reflect.Type) {
+	customMethodsMu.Lock()
+	defer customMethodsMu.Unlock()
+
+	customMethods[method] = paramsType
+}
+
+// isCustomMethod reports whether method was registered with
+// RegisterCustomMethod.
+func isCustomMethod(method string) bool {
+	customMethodsMu.RLock()
+	defer customMethodsMu.RUnlock()
+
+	_, ok := customMethods[method]
+
+	return ok
+}
+
+// decodeCustomParams decodes the params of a method routed to the
+// Server.Request catch-all, honoring types registered via
+// RegisterCustomMethod.
+func decodeCustomParams(method string, raw json.RawMessage) (any, error) {
+	if raw == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	customMethodsMu.RLock()
+	typ, ok := customMethods[method]
+	customMethodsMu.RUnlock()
+
+	if ok {
+		ptr := reflect.New(typ)
+		if err := json.Unmarshal(raw, ptr.Interface()); err != nil { //nolint:noinlineerr
+			return nil, err //nolint:wrapcheck
+		}
+
+		return ptr.Interface(), nil
+	}
+
+	var params any
+	if err := json.Unmarshal(raw, &params); err != nil { //nolint:noinlineerr
+		return nil, err //nolint:wrapcheck
+	}
+
+	return params, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides DiagnosticCache, which lets a server answer
+// textDocument/diagnostic pulls with an "unchanged" report when the client
+// already holds the latest result.
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_pullDiagnostics
+
+import "sync"
+
+type (
+	// DiagnosticCache records, per document, the result ID and diagnostics
+	// of the last full report sent to the client. It is safe for concurrent
+	// use.
+	DiagnosticCache struct {
+		mu      sync.Mutex
+		entries map[DocumentURI]diagnosticEntry
+	}
+
+	diagnosticEntry struct {
+		resultID string
+		diags    []Diagnostic
+	}
+)
+
+// NewDiagnosticCache returns an empty DiagnosticCache.
+func NewDiagnosticCache() *DiagnosticCache {
+	return &DiagnosticCache{ //nolint:exhaustruct
+		entries: make(map[DocumentURI]diagnosticEntry),
+	}
+}
+
+// ShouldRecompute reports whether the diagnostics of uri must be computed
+// and sent in full. It is false only if previousResultID, as sent by the
+// client in DocumentDiagnosticParams, is the result ID last stored for uri,
+// in which case the server can reply with NewUnchangedDiagnosticReport.
+func (c *DiagnosticCache) ShouldRecompute(uri DocumentURI, previousResultID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[uri]
+
+	return !ok || previousResultID == "" || entry.resultID != previousResultID
+}
+
+// Store records diags as the full report sent for uri under resultID.
+func (c *DiagnosticCache) Store(uri DocumentURI, resultID string, diags []Diagnostic) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[uri] = diagnosticEntry{resultID: resultID, diags: diags}
+}
+
+// Diagnostics returns the diagnostics and result ID last stored for uri.
+func (c *DiagnosticCache) Diagnostics(uri DocumentURI) ([]Diagnostic, string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[uri]
+
+	return entry.diags, entry.resultID, ok
+}
+
+// Invalidate forgets uri, typically after its content changed or it was
+// closed, so the next pull recomputes its diagnostics.
+func (c *DiagnosticCache) Invalidate(uri DocumentURI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, uri)
+}
+
+// NewFullDiagnosticReport returns a full textDocument/diagnostic report of
+// diags tagged with resultID.
+func NewFullDiagnosticReport(resultID string, diags []Diagnostic) RelatedFullDocumentDiagnosticReport {
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+
+	return RelatedFullDocumentDiagnosticReport{ //nolint:exhaustruct
+		Kind:     string(DocumentDiagnosticReportKindFull),
+		ResultId: &resultID,
+		Items:    diags,
+	}
+}
+
+// NewUnchangedDiagnosticReport returns a textDocument/diagnostic report
+// telling the client that the diagnostics of resultID are still current.
+func NewUnchangedDiagnosticReport(resultID string) RelatedUnchangedDocumentDiagnosticReport {
+	return RelatedUnchangedDocumentDiagnosticReport{ //nolint:exhaustruct
+		Kind:     string(DocumentDiagnosticReportKindUnchanged),
+		ResultId: resultID,
+	}
+}
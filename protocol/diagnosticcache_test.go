@@ -0,0 +1,67 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticCache(t *testing.T) {
+	const uri DocumentURI = "file:///main.go"
+
+	diags := []Diagnostic{{Message: "unused variable x"}}
+
+	t.Run("unknown document", func(t *testing.T) {
+		cache := NewDiagnosticCache()
+		assert.True(t, cache.ShouldRecompute(uri, ""))
+		assert.True(t, cache.ShouldRecompute(uri, "1"))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		cache := NewDiagnosticCache()
+		cache.Store(uri, "1", diags)
+
+		assert.False(t, cache.ShouldRecompute(uri, "1"))
+
+		data, err := json.Marshal(NewUnchangedDiagnosticReport("1"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"kind": "unchanged", "resultId": "1"}`, string(data))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		cache := NewDiagnosticCache()
+		cache.Store(uri, "1", diags)
+
+		assert.True(t, cache.ShouldRecompute(uri, ""), "client has no previous result")
+		assert.True(t, cache.ShouldRecompute(uri, "0"), "client holds a stale result")
+		assert.True(t, cache.ShouldRecompute("file:///other.go", "1"))
+
+		cache.Store(uri, "2", nil)
+		assert.True(t, cache.ShouldRecompute(uri, "1"))
+
+		got, resultID, ok := cache.Diagnostics(uri)
+		require.True(t, ok)
+		assert.Equal(t, "2", resultID)
+		assert.Empty(t, got)
+
+		data, err := json.Marshal(NewFullDiagnosticReport(resultID, got))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"kind": "full", "resultId": "2", "items": []}`, string(data))
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		cache := NewDiagnosticCache()
+		cache.Store(uri, "1", diags)
+		cache.Invalidate(uri)
+
+		assert.True(t, cache.ShouldRecompute(uri, "1"))
+
+		_, _, ok := cache.Diagnostics(uri)
+		assert.False(t, ok)
+	})
+}
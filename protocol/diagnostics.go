@@ -0,0 +1,32 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for building Diagnostics.
+
+import "errors"
+
+// rangeError is implemented by errors that know where in a document they
+// occurred.
+type rangeError interface {
+	error
+	Range() Range
+}
+
+// DiagnosticFromError returns a Diagnostic reporting err with the given
+// severity. If err, or an error it wraps, has a `Range() Range` method, that
+// range is used; otherwise the diagnostic is placed at r. err must not be
+// nil.
+func DiagnosticFromError(r Range, err error, severity DiagnosticSeverity) Diagnostic {
+	var withRange rangeError
+	if errors.As(err, &withRange) {
+		r = withRange.Range()
+	}
+
+	return Diagnostic{ //nolint:exhaustruct
+		Range:    r,
+		Severity: &severity,
+		Message:  err.Error(),
+	}
+}
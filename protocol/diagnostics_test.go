@@ -0,0 +1,48 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// posError is an analyzer error carrying its own range.
+type posError struct {
+	rng Range
+	msg string
+}
+
+func (e posError) Error() string { return e.msg }
+func (e posError) Range() Range  { return e.rng }
+
+func TestDiagnosticFromError(t *testing.T) {
+	fallback := Range{End: Position{Line: 0, Character: 1}}
+	own := Range{
+		Start: Position{Line: 12, Character: 4},
+		End:   Position{Line: 12, Character: 9},
+	}
+
+	t.Run("plain error", func(t *testing.T) {
+		diag := DiagnosticFromError(fallback, errors.New("undefined: foo"), DiagnosticSeverityError)
+
+		assert.Equal(t, Diagnostic{
+			Range:    fallback,
+			Severity: new(DiagnosticSeverityError),
+			Message:  "undefined: foo",
+		}, diag)
+	})
+
+	t.Run("error with range", func(t *testing.T) {
+		err := fmt.Errorf("vet: %w", posError{rng: own, msg: "unreachable code"})
+		diag := DiagnosticFromError(fallback, err, DiagnosticSeverityWarning)
+
+		assert.Equal(t, own, diag.Range)
+		assert.Equal(t, DiagnosticSeverityWarning, *diag.Severity)
+		assert.Equal(t, "vet: unreachable code", diag.Message)
+	})
+}
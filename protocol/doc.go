@@ -7,16 +7,50 @@
 //
 // Generated files (DO NOT EDIT):
 //   - types_gen.go  — structures, enumerations, type aliases
-//   - server_gen.go — Server interface, method constants, dispatch
+//   - server_gen.go — Server interface, method constants and sets, dispatch
 //   - client_gen.go — Client interface, ClientDispatcher
+//   - types_roundtrip_gen_test.go — JSON round-trip tests for representative types
 //
 // Hand-written files:
 //   - doc.go      — this file
 //   - uri.go      — DocumentURI / URI types and helpers
 //   - errors.go   — LSP error codes and helpers
-//   - handler.go  — ServerHandler (adapts Server to jsonrpc2.Handler)
+//   - handler.go  — ServerHandler (adapts Server to jsonrpc2.Handler) and Middleware
 //   - logger.go   — Logger interface and NopLogger
 //   - compat.go   — backward-compatible aliases for go.lsp.dev/protocol v0.12.0
+//   - clienthelpers.go — convenience wrappers for common server→client calls
+//   - capabilities.go — client capability view and server capability defaults
+//   - custom.go   — registry of typed params for custom methods
+//   - edits.go    — helpers for text edits and workspace edits
+//   - mapper.go   — Mapper (Position ↔ byte offset in the negotiated encoding)
+//   - progress.go — SendProgress, work done progress values, PartialResultReporter
+//   - codeaction.go — fluent builder for CodeAction
+//   - trace.go    — TraceStream / TraceHook and the NDJSON transcript hook
+//   - markup.go   — accessors for string | MarkupContent unions
+//   - inlayhint.go — fluent helpers for InlayHint
+//   - semantictokens.go — SemanticTokensLegend, SemanticTokensBuilder and delta decoding
+//   - watchers.go — file system watcher registration and glob matching
+//   - color.go    — Color / ColorInformation / ColorPresentation constructors
+//   - lspany.go   — structural equality and merging of LSPAny trees
+//   - diagnostics.go — helpers for building Diagnostics
+//   - initialize.go — nil-safe accessors for InitializeParams, InitializeResult builder
+//   - providers.go — BoolOrOptions for boolean | XOptions fields, provider accessors
+//   - typehierarchy.go — TypeHierarchyItem construction and Data helpers
+//   - range.go — Position and Range computations
+//   - completion.go — CompletionOptions builder, resolve and list merging
+//   - documents.go — DocumentStore tracking open text documents
+//   - workspacesymbol.go — WorkspaceSymbol full and lazy locations
+//   - marshal.go — deterministic JSON encoding of protocol values
+//   - diagnosticcache.go — DiagnosticCache for pull diagnostic result IDs
+//   - converter.go — PositionConverter between client positions and byte offsets
+//   - selectionrange.go — SelectionRangeParams for multiple positions
+//   - notebook.go — NotebookStore tracking open notebooks and their cells
+//   - hover.go — HoverContents, the typed Hover.Contents union
+//   - cache.go — ResultCache for results keyed by document version
+//   - command.go — Command construction and argument decoding
+//   - codelens.go — CodeLens construction for lazy resolve and Data helpers
+//   - documentsymbol.go — walking and searching DocumentSymbol trees
+//   - workspacediagnostic.go — WorkspaceDiagnosticReport builder for workspace/diagnostic
 package protocol
 
 //go:generate go run github.com/modern-dev/go-lsp/cmd/generate -o .
@@ -0,0 +1,270 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file implements DocumentStore, which tracks the content of the text
+// documents a client has opened by applying the textDocument/didOpen,
+// didChange, didSave and didClose notifications.
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+type (
+	// Document is a snapshot of an open text document.
+	Document struct {
+		URI        DocumentURI
+		LanguageID LanguageKind
+		Version    int32
+		Text       string
+	}
+
+	// DocumentStore holds the open text documents of a session, keyed by
+	// DocumentURI.CanonicalKey, so that URIs differing only in
+	// percent-encoding, or file URIs differing only in query or fragment,
+	// name the same document. It is safe for concurrent use.
+	DocumentStore struct {
+		encoding PositionEncodingKind
+
+		mu   sync.RWMutex
+		docs map[DocumentURI]Document
+	}
+)
+
+// NewDocumentStore returns an empty DocumentStore that interprets the ranges
+// of incremental changes in the given position encoding. An empty encoding
+// means UTF-16.
+func NewDocumentStore(encoding PositionEncodingKind) *DocumentStore {
+	return &DocumentStore{ //nolint:exhaustruct
+		encoding: encoding,
+		docs:     make(map[DocumentURI]Document),
+	}
+}
+
+// Get returns the document stored for uri.
+func (s *DocumentStore) Get(uri DocumentURI) (Document, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	doc, ok := s.docs[uri.CanonicalKey()]
+
+	return doc, ok
+}
+
+// DidOpen stores the opened document, replacing any previous content.
+func (s *DocumentStore) DidOpen(params *DidOpenTextDocumentParams) {
+	item := params.TextDocument
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.docs[item.URI.CanonicalKey()] = Document{
+		URI:        item.URI,
+		LanguageID: item.LanguageId,
+		Version:    item.Version,
+		Text:       item.Text,
+	}
+}
+
+// DidChange applies the content changes in order and records the new
+// version. It returns an error, leaving the document unchanged, if the
+// document is not open or a change cannot be applied.
+func (s *DocumentStore) DidChange(params *DidChangeTextDocumentParams) error {
+	uri := params.TextDocument.URI
+	key := uri.CanonicalKey()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, ok := s.docs[key]
+	if !ok {
+		return fmt.Errorf("document %s is not open", uri) //nolint:err113
+	}
+
+	text, err := ApplyContentChanges(doc.Text, s.encoding, params.ContentChanges...)
+	if err != nil {
+		return fmt.Errorf("document %s: %w", uri, err)
+	}
+
+	doc.Text = text
+	doc.Version = params.TextDocument.Version
+	s.docs[key] = doc
+
+	return nil
+}
+
+// DidSave replaces the stored content with the saved text if the
+// notification carries it, and otherwise leaves the document unchanged.
+// Saves of documents that are not open are ignored.
+func (s *DocumentStore) DidSave(params *DidSaveTextDocumentParams) {
+	if !params.HasText() {
+		return
+	}
+
+	key := params.TextDocument.URI.CanonicalKey()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if doc, ok := s.docs[key]; ok {
+		doc.Text = *params.Text
+		s.docs[key] = doc
+	}
+}
+
+// DidClose forgets the closed document.
+func (s *DocumentStore) DidClose(params *DidCloseTextDocumentParams) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.docs, params.TextDocument.URI.CanonicalKey())
+}
+
+// HasText reports whether the save notification carries the document
+// content. Clients include it only if the server asked for it by setting
+// IncludeText in the SaveOptions of its textDocumentSync capability (or of
+// a dynamic registration); otherwise the server must rely on the content
+// tracked through didOpen and didChange.
+func (p *DidSaveTextDocumentParams) HasText() bool {
+	return p != nil && p.Text != nil
+}
+
+// NewIncrementalChange returns the textDocument/didChange params moving uri
+// to version by applying changes in order, typically built with
+// NewRangeChange.
+func NewIncrementalChange(
+	uri DocumentURI,
+	version int32,
+	changes ...TextDocumentContentChangeEvent,
+) DidChangeTextDocumentParams {
+	if changes == nil {
+		changes = []TextDocumentContentChangeEvent{}
+	}
+
+	return DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: version},
+		ContentChanges: changes,
+	}
+}
+
+// NewFullChange returns the textDocument/didChange params replacing the
+// whole content of uri with text at version.
+func NewFullChange(uri DocumentURI, version int32, text string) DidChangeTextDocumentParams {
+	var change TextDocumentContentChangeEvent
+	change.SetTextDocumentContentChangeWholeDocument(TextDocumentContentChangeWholeDocument{Text: text})
+
+	return NewIncrementalChange(uri, version, change)
+}
+
+// NewRangeChange returns the content change replacing r with text.
+func NewRangeChange(r Range, text string) TextDocumentContentChangeEvent {
+	var change TextDocumentContentChangeEvent
+	change.SetTextDocumentContentChangePartial(
+		TextDocumentContentChangePartial{Range: r, Text: text}, //nolint:exhaustruct
+	)
+
+	return change
+}
+
+// ComputeContentChange returns a single content change turning before into
+// after, replacing the shortest range of before that differs, with positions
+// measured in UTF-16. Applying it to before with ApplyContentChanges yields
+// after; it is useful to simulate edits in tests or to send incremental
+// changes from a client that only knows the old and new text.
+func ComputeContentChange(before, after string) TextDocumentContentChangeEvent {
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+
+	for !isPositionBoundary(before, prefix) {
+		prefix--
+	}
+
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	for !isPositionBoundary(before, len(before)-suffix) {
+		suffix--
+	}
+
+	// Both offsets are character boundaries within before, so they map to
+	// positions without error.
+	m := NewMapper(before)
+	start, _ := m.PositionAt(prefix)
+	end, _ := m.PositionAt(len(before) - suffix)
+
+	return NewRangeChange(Range{Start: start, End: end}, after[prefix:len(after)-suffix])
+}
+
+// isPositionBoundary reports whether a position can point at offset of text:
+// it must start a character and must not split a \r\n line terminator.
+func isPositionBoundary(text string, offset int) bool {
+	if offset <= 0 || offset >= len(text) {
+		return true
+	}
+
+	return utf8.RuneStart(text[offset]) && (text[offset-1] != '\r' || text[offset] != '\n')
+}
+
+// ApplyContentChanges returns text with changes applied in order, each
+// change seeing the result of the previous one as the specification
+// requires. Ranges are measured in encoding; an empty encoding means UTF-16.
+func ApplyContentChanges(
+	text string,
+	encoding PositionEncodingKind,
+	changes ...TextDocumentContentChangeEvent,
+) (string, error) {
+	for _, change := range changes {
+		var err error
+
+		text, err = applyContentChange(text, change, encoding)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return text, nil
+}
+
+// applyContentChange returns text with change applied.
+func applyContentChange(
+	text string,
+	change TextDocumentContentChangeEvent,
+	encoding PositionEncodingKind,
+) (string, error) {
+	if whole, ok := change.AsTextDocumentContentChangeWholeDocument(); ok {
+		return whole.Text, nil
+	}
+
+	partial, ok := change.AsTextDocumentContentChangePartial()
+	if !ok {
+		return "", errors.New("empty content change") //nolint:err113
+	}
+
+	m := NewMapperWithEncoding(text, encoding)
+
+	start, err := m.Offset(partial.Range.Start)
+	if err != nil {
+		return "", err
+	}
+
+	end, err := m.Offset(partial.Range.End)
+	if err != nil {
+		return "", err
+	}
+
+	if end < start {
+		return "", fmt.Errorf( //nolint:err113
+			"content change range %v ends before it starts", partial.Range)
+	}
+
+	return text[:start] + partial.Text + text[end:], nil
+}
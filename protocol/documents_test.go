@@ -0,0 +1,279 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const storeURI DocumentURI = "file:///main.go"
+
+// openStore returns a DocumentStore holding storeURI at version 1.
+func openStore(t *testing.T, text string) *DocumentStore {
+	t.Helper()
+
+	store := NewDocumentStore("")
+	store.DidOpen(&DidOpenTextDocumentParams{TextDocument: TextDocumentItem{
+		URI:        storeURI,
+		LanguageId: LanguageKindGo,
+		Version:    1,
+		Text:       text,
+	}})
+
+	return store
+}
+
+func TestDocumentStore_DidChange(t *testing.T) {
+	t.Run("incremental and full changes", func(t *testing.T) {
+		store := openStore(t, "package main\n\nfunc main() {}\n")
+
+		var decoded TextDocumentContentChangeEvent
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"range": {"start": {"line": 2, "character": 13}, "end": {"line": 2, "character": 13}},
+			"text": "\n\tprintln(\"héllo\")\n"
+		}`), &decoded))
+
+		require.NoError(t, store.DidChange(&DidChangeTextDocumentParams{
+			TextDocument: VersionedTextDocumentIdentifier{URI: storeURI, Version: 2},
+			ContentChanges: []TextDocumentContentChangeEvent{
+				NewRangeChange(Range{
+					Start: Position{Line: 0, Character: 8},
+					End:   Position{Line: 0, Character: 12},
+				}, "app"),
+				decoded,
+			},
+		}))
+
+		doc, ok := store.Get(storeURI)
+		require.True(t, ok)
+		assert.Equal(t, int32(2), doc.Version)
+		assert.Equal(t, LanguageKindGo, doc.LanguageID)
+		assert.Equal(t, "package app\n\nfunc main() {\n\tprintln(\"héllo\")\n}\n", doc.Text)
+
+		var whole TextDocumentContentChangeEvent
+		require.NoError(t, json.Unmarshal([]byte(`{"text": "package b\n"}`), &whole))
+
+		require.NoError(t, store.DidChange(&DidChangeTextDocumentParams{
+			TextDocument:   VersionedTextDocumentIdentifier{URI: storeURI, Version: 3},
+			ContentChanges: []TextDocumentContentChangeEvent{whole},
+		}))
+
+		doc, _ = store.Get(storeURI)
+		assert.Equal(t, "package b\n", doc.Text)
+	})
+
+	t.Run("not open", func(t *testing.T) {
+		err := NewDocumentStore("").DidChange(&DidChangeTextDocumentParams{
+			TextDocument: VersionedTextDocumentIdentifier{URI: storeURI, Version: 2},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid range leaves document unchanged", func(t *testing.T) {
+		store := openStore(t, "one line")
+
+		params := NewIncrementalChange(storeURI, 2,
+			NewFullChange(storeURI, 2, "replaced").ContentChanges[0],
+			NewRangeChange(Range{Start: Position{Line: 5}, End: Position{Line: 5}}, ""),
+		)
+
+		err := store.DidChange(&params)
+		require.Error(t, err)
+
+		doc, _ := store.Get(storeURI)
+		assert.Equal(t, "one line", doc.Text)
+		assert.Equal(t, int32(1), doc.Version)
+	})
+}
+
+func TestDocumentStore_DidSave(t *testing.T) {
+	t.Run("with text", func(t *testing.T) {
+		store := openStore(t, "package main\n")
+		params := &DidSaveTextDocumentParams{
+			TextDocument: TextDocumentIdentifier{URI: storeURI},
+			Text:         new("package main // saved\n"),
+		}
+
+		assert.True(t, params.HasText())
+		store.DidSave(params)
+
+		doc, _ := store.Get(storeURI)
+		assert.Equal(t, "package main // saved\n", doc.Text)
+		assert.Equal(t, int32(1), doc.Version)
+	})
+
+	t.Run("without text", func(t *testing.T) {
+		store := openStore(t, "package main\n")
+		params := &DidSaveTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: storeURI}}
+
+		assert.False(t, params.HasText())
+		store.DidSave(params)
+
+		doc, _ := store.Get(storeURI)
+		assert.Equal(t, "package main\n", doc.Text)
+	})
+
+	t.Run("nil params", func(t *testing.T) {
+		var params *DidSaveTextDocumentParams
+		assert.False(t, params.HasText())
+	})
+}
+
+func TestDocumentStore_DidClose(t *testing.T) {
+	store := openStore(t, "package main\n")
+	store.DidClose(&DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: storeURI}})
+
+	_, ok := store.Get(storeURI)
+	assert.False(t, ok)
+}
+
+func TestDocumentStore_CanonicalKey(t *testing.T) {
+	store := openStore(t, "package main\n")
+	versioned := storeURI + "?version=2"
+
+	doc, ok := store.Get(versioned)
+	require.True(t, ok, "URIs differing only in query name the same document")
+	assert.Equal(t, storeURI, doc.URI)
+
+	change := NewFullChange(versioned, 2, "package app\n")
+	require.NoError(t, store.DidChange(&change))
+
+	doc, _ = store.Get(storeURI + "#L1")
+	assert.Equal(t, "package app\n", doc.Text)
+
+	store.DidClose(&DidCloseTextDocumentParams{
+		TextDocument: TextDocumentIdentifier{URI: versioned},
+	})
+
+	_, ok = store.Get(storeURI)
+	assert.False(t, ok)
+}
+
+func TestNewIncrementalChange(t *testing.T) {
+	params := NewIncrementalChange(storeURI, 7,
+		NewRangeChange(Position{Line: 0, Character: 8}.To(Position{Line: 0, Character: 12}), "app"),
+		NewRangeChange(Position{Line: 1}.To(Position{Line: 1}), "// x\n"),
+	)
+
+	data, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"textDocument": {"uri": "file:///main.go", "version": 7},
+		"contentChanges": [
+			{
+				"range": {
+					"start": {"line": 0, "character": 8},
+					"end": {"line": 0, "character": 12}
+				},
+				"text": "app"
+			},
+			{
+				"range": {"start": {"line": 1, "character": 0}, "end": {"line": 1, "character": 0}},
+				"text": "// x\n"
+			}
+		]
+	}`, string(data))
+
+	text, err := ApplyContentChanges("package main\n", "", params.ContentChanges...)
+	require.NoError(t, err)
+	assert.Equal(t, "package app\n// x\n", text)
+
+	data, err = json.Marshal(NewIncrementalChange(storeURI, 8))
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"textDocument": {"uri": "file:///main.go", "version": 8}, "contentChanges": []}`,
+		string(data))
+}
+
+func TestNewFullChange(t *testing.T) {
+	params := NewFullChange(storeURI, 3, "package b\n")
+
+	data, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"textDocument": {"uri": "file:///main.go", "version": 3},
+		"contentChanges": [{"text": "package b\n"}]
+	}`, string(data))
+
+	store := openStore(t, "package main\n")
+	require.NoError(t, store.DidChange(&params))
+
+	doc, _ := store.Get(storeURI)
+	assert.Equal(t, "package b\n", doc.Text)
+	assert.Equal(t, int32(3), doc.Version)
+}
+
+func TestComputeContentChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after string
+		want          TextDocumentContentChangeEvent
+	}{
+		{
+			"insertion",
+			"package main\n", "package main\n\nfunc main() {}\n",
+			NewRangeChange(rng(1, 0, 1, 0), "\nfunc main() {}\n"),
+		},
+		{
+			"deletion",
+			"a\nbcd\ne\n", "a\nbd\ne\n",
+			NewRangeChange(rng(1, 1, 1, 2), ""),
+		},
+		{
+			"replacement",
+			"package main\n", "package app\n",
+			NewRangeChange(rng(0, 8, 0, 12), "app"),
+		},
+		{
+			"repeated characters",
+			"aaa", "aaaa",
+			NewRangeChange(rng(0, 3, 0, 3), "a"),
+		},
+		{
+			"UTF-16 columns",
+			"s := \"😀x\"", "s := \"😀y\"",
+			NewRangeChange(rng(0, 8, 0, 9), "y"),
+		},
+		{
+			"shared leading bytes of a character",
+			"é", "è",
+			NewRangeChange(rng(0, 0, 0, 1), "è"),
+		},
+		{
+			"CRLF split",
+			"a\r\nb", "a\rb",
+			NewRangeChange(rng(0, 1, 1, 0), "\r"),
+		},
+		{
+			"CRLF join",
+			"a\r\nb", "ax\nb",
+			NewRangeChange(rng(0, 1, 1, 0), "x\n"),
+		},
+		{
+			"unchanged",
+			"same\n", "same\n",
+			NewRangeChange(rng(1, 0, 1, 0), ""),
+		},
+		{
+			"from empty",
+			"", "text",
+			NewRangeChange(rng(0, 0, 0, 0), "text"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := ComputeContentChange(tt.before, tt.after)
+			assert.Equal(t, tt.want, change)
+
+			text, err := ApplyContentChanges(tt.before, PositionEncodingKindUTF16, change)
+			require.NoError(t, err)
+			assert.Equal(t, tt.after, text)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for the hierarchical DocumentSymbol results of
+// textDocument/documentSymbol, whose nested symbols hang off Children.
+
+// WalkDocumentSymbols calls fn for every symbol of the trees in syms, in
+// depth-first order, visiting each symbol before its children. depth is 0 for
+// the symbols of syms and grows by one per level. When fn returns false, the
+// children of that symbol are skipped and the walk continues with its next
+// sibling.
+func WalkDocumentSymbols(syms []DocumentSymbol, fn func(sym DocumentSymbol, depth int) bool) {
+	walkDocumentSymbols(syms, 0, fn)
+}
+
+func walkDocumentSymbols(syms []DocumentSymbol, depth int, fn func(DocumentSymbol, int) bool) {
+	for _, sym := range syms {
+		if fn(sym, depth) {
+			walkDocumentSymbols(sym.Children, depth+1, fn)
+		}
+	}
+}
+
+// FindSymbol returns the first symbol named name in the trees of syms, in the
+// order of WalkDocumentSymbols. The result points into syms, so changes made
+// through it are visible in the tree.
+func FindSymbol(syms []DocumentSymbol, name string) (*DocumentSymbol, bool) {
+	for idx := range syms {
+		if syms[idx].Name == name {
+			return &syms[idx], true
+		}
+
+		if sym, ok := FindSymbol(syms[idx].Children, name); ok {
+			return sym, true
+		}
+	}
+
+	return nil, false
+}
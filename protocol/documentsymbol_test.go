@@ -0,0 +1,86 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// symbolTree returns the symbols of a file declaring a Server type with two
+// methods, followed by a main function.
+func symbolTree() []DocumentSymbol {
+	sym := func(name string, kind SymbolKind, line uint32, children ...DocumentSymbol,
+	) DocumentSymbol {
+		return DocumentSymbol{
+			Name:           name,
+			Kind:           kind,
+			Range:          rng(line, 0, line+1, 0),
+			SelectionRange: rng(line, 5, line, 5+uint32(len(name))),
+			Children:       children,
+		}
+	}
+
+	return []DocumentSymbol{
+		sym("Server", SymbolKindStruct, 2,
+			sym("Start", SymbolKindMethod, 4),
+			sym("Stop", SymbolKindMethod, 6),
+		),
+		sym("main", SymbolKindFunction, 9),
+	}
+}
+
+func TestWalkDocumentSymbols(t *testing.T) {
+	t.Run("order and depth", func(t *testing.T) {
+		var visited []string
+
+		WalkDocumentSymbols(symbolTree(), func(sym DocumentSymbol, depth int) bool {
+			visited = append(visited, fmt.Sprintf("%s@%d", sym.Name, depth))
+			return true
+		})
+
+		assert.Equal(t, []string{"Server@0", "Start@1", "Stop@1", "main@0"}, visited)
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+		var visited []string
+
+		WalkDocumentSymbols(symbolTree(), func(sym DocumentSymbol, _ int) bool {
+			visited = append(visited, sym.Name)
+			return sym.Kind != SymbolKindStruct
+		})
+
+		assert.Equal(t, []string{"Server", "main"}, visited)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		WalkDocumentSymbols(nil, func(DocumentSymbol, int) bool {
+			t.Fatal("fn called for an empty tree")
+			return true
+		})
+	})
+}
+
+func TestFindSymbol(t *testing.T) {
+	syms := symbolTree()
+
+	sym, ok := FindSymbol(syms, "Stop")
+	require.True(t, ok)
+	assert.Equal(t, SymbolKindMethod, sym.Kind)
+	assert.Equal(t, rng(6, 0, 7, 0), sym.Range)
+
+	sym.Detail = new("func()")
+	assert.Equal(t, new("func()"), syms[0].Children[1].Detail, "the result points into the tree")
+
+	sym, ok = FindSymbol(syms, "main")
+	require.True(t, ok)
+	assert.Equal(t, SymbolKindFunction, sym.Kind)
+
+	sym, ok = FindSymbol(syms, "Run")
+	assert.False(t, ok)
+	assert.Nil(t, sym)
+}
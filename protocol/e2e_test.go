@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,6 +22,10 @@ import (
 type e2eServer struct {
 	initialized bool
 	opened      map[protocol.DocumentURI]string
+
+	// hoverFromConfig makes Hover fetch its prefix from the client's
+	// "e2e.hoverPrefix" configuration section.
+	hoverFromConfig bool
 }
 
 func newE2EServer() *e2eServer {
@@ -31,19 +36,27 @@ func (s *e2eServer) Initialize(
 	_ context.Context,
 	_ *protocol.InitializeParams,
 ) (*protocol.InitializeResult, error) {
+	var sync protocol.OrTextDocumentSyncOptionsTextDocumentSyncKind
+	sync.SetTextDocumentSyncOptions(protocol.TextDocumentSyncOptions{
+		OpenClose: new(true),
+		Change:    new(protocol.TextDocumentSyncKindFull),
+	})
+
+	caps := protocol.ServerCapabilities{
+		TextDocumentSync:   &sync,
+		CompletionProvider: &protocol.CompletionOptions{},
+	}
+	caps.SetHoverProvider(true)
+	caps.SetDefinitionProvider(true)
+	caps.SetDocumentSymbolProvider(true)
+	caps.SetCodeActionProviderOptions(protocol.CodeActionOptions{
+		CodeActionKinds: []protocol.CodeActionKind{protocol.CodeActionKindQuickFix},
+		ResolveProvider: new(true),
+	})
+	caps.SetDocumentFormattingProvider(true)
+
 	return &protocol.InitializeResult{
-		Capabilities: protocol.ServerCapabilities{
-			TextDocumentSync: &protocol.TextDocumentSyncOptions{
-				OpenClose: new(true),
-				Change:    new(protocol.TextDocumentSyncKindFull),
-			},
-			HoverProvider:              true,
-			CompletionProvider:         &protocol.CompletionOptions{},
-			DefinitionProvider:         true,
-			DocumentSymbolProvider:     true,
-			CodeActionProvider:         true,
-			DocumentFormattingProvider: true,
-		},
+		Capabilities: caps,
 		ServerInfo: &protocol.ServerInfo{
 			Name:    "e2e-test-server",
 			Version: new("0.0.1-test"),
@@ -60,7 +73,7 @@ func (s *e2eServer) Shutdown(_ context.Context) (any, error) { return nil, nil }
 func (s *e2eServer) Exit(_ context.Context) error            { return nil }
 
 func (s *e2eServer) DidOpen(_ context.Context, params *protocol.DidOpenTextDocumentParams) error {
-	s.opened[params.TextDocument.URI] = params.TextDocument.Text
+	s.opened[params.TextDocument.URI.CanonicalKey()] = params.TextDocument.Text
 	return nil
 }
 
@@ -69,7 +82,7 @@ func (s *e2eServer) DidChange(_ context.Context, _ *protocol.DidChangeTextDocume
 }
 
 func (s *e2eServer) DidClose(_ context.Context, params *protocol.DidCloseTextDocumentParams) error {
-	delete(s.opened, params.TextDocument.URI)
+	delete(s.opened, params.TextDocument.URI.CanonicalKey())
 	return nil
 }
 
@@ -78,51 +91,89 @@ func (s *e2eServer) DidSave(_ context.Context, _ *protocol.DidSaveTextDocumentPa
 }
 
 func (s *e2eServer) Hover(
-	_ context.Context,
+	ctx context.Context,
 	params *protocol.HoverParams,
 ) (*protocol.Hover, error) {
-	text, ok := s.opened[params.TextDocument.URI]
+	text, ok := s.opened[params.TextDocument.URI.CanonicalKey()]
 	if !ok {
 		return nil, nil
 	}
+
+	prefix := "Hovering over"
+	if s.hoverFromConfig {
+		client, ok := protocol.ClientFrom(ctx)
+		if !ok {
+			return nil, fmt.Errorf("no client in context")
+		}
+		section := "e2e.hoverPrefix"
+		values, err := client.Configuration(ctx, &protocol.ConfigurationParams{
+			Items: []protocol.ConfigurationItem{{Section: &section}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 1 {
+			if v, ok := values[0].(string); ok {
+				prefix = v
+			}
+		}
+	}
+
 	return &protocol.Hover{
-		Contents: protocol.MarkupContent{
+		Contents: protocol.NewMarkupHoverContents(protocol.MarkupContent{
 			Kind: protocol.MarkupKindMarkdown,
 			Value: fmt.Sprintf(
-				"Hovering over `%s` at %d:%d",
+				"%s `%s` at %d:%d",
+				prefix,
 				text,
 				params.Position.Line,
 				params.Position.Character,
 			),
-		},
+		}),
 	}, nil
 }
 
-func (s *e2eServer) Completion(_ context.Context, _ *protocol.CompletionParams) (any, error) {
-	return &protocol.CompletionList{
+func (s *e2eServer) Completion(
+	_ context.Context,
+	_ *protocol.CompletionParams,
+) (*protocol.OrCompletionItemArrayCompletionList, error) {
+	var result protocol.OrCompletionItemArrayCompletionList
+	result.SetCompletionList(protocol.CompletionList{
 		IsIncomplete: false,
 		Items: []protocol.CompletionItem{
 			{Label: "fmt"},
 			{Label: "func"},
 		},
-	}, nil
+	})
+
+	return &result, nil
 }
 
-func (s *e2eServer) Definition(_ context.Context, params *protocol.DefinitionParams) (any, error) {
-	return &protocol.Location{
+func (s *e2eServer) Definition(
+	_ context.Context,
+	params *protocol.DefinitionParams,
+) (*protocol.OrDefinitionDefinitionLinkArray, error) {
+	var def protocol.Definition
+	def.SetLocation(protocol.Location{
 		URI: params.TextDocument.URI,
 		Range: protocol.Range{
 			Start: protocol.Position{Line: 0, Character: 0},
 			End:   protocol.Position{Line: 0, Character: 10},
 		},
-	}, nil
+	})
+
+	var result protocol.OrDefinitionDefinitionLinkArray
+	result.SetDefinition(def)
+
+	return &result, nil
 }
 
 func (s *e2eServer) DocumentSymbol(
 	_ context.Context,
 	_ *protocol.DocumentSymbolParams,
-) (any, error) {
-	return []protocol.DocumentSymbol{
+) (*protocol.OrSymbolInformationArrayDocumentSymbolArray, error) {
+	var result protocol.OrSymbolInformationArrayDocumentSymbolArray
+	result.SetDocumentSymbolArray([]protocol.DocumentSymbol{
 		{
 			Name: "main",
 			Kind: protocol.SymbolKindFunction,
@@ -135,10 +186,15 @@ func (s *e2eServer) DocumentSymbol(
 				End:   protocol.Position{Line: 2, Character: 9},
 			},
 		},
-	}, nil
+	})
+
+	return &result, nil
 }
 
-func (s *e2eServer) CodeAction(_ context.Context, _ *protocol.CodeActionParams) ([]any, error) {
+func (s *e2eServer) CodeAction(
+	_ context.Context,
+	_ *protocol.CodeActionParams,
+) ([]protocol.OrCommandCodeAction, error) {
 	return nil, nil
 }
 
@@ -250,7 +306,10 @@ func (s *e2eServer) ColorPresentation(
 	return nil, nil
 }
 
-func (s *e2eServer) Declaration(context.Context, *protocol.DeclarationParams) (any, error) {
+func (s *e2eServer) Declaration(
+	context.Context,
+	*protocol.DeclarationParams,
+) (*protocol.OrDeclarationDeclarationLinkArray, error) {
 	return nil, nil
 }
 
@@ -258,7 +317,7 @@ func (s *e2eServer) Diagnostic(
 	context.Context,
 	*protocol.DocumentDiagnosticParams,
 ) (protocol.DocumentDiagnosticReport, error) {
-	return nil, fmt.Errorf("not implemented")
+	return protocol.DocumentDiagnosticReport{}, fmt.Errorf("not implemented")
 }
 
 func (s *e2eServer) DocumentColor(
@@ -289,7 +348,10 @@ func (s *e2eServer) FoldingRanges(
 	return nil, nil
 }
 
-func (s *e2eServer) Implementation(context.Context, *protocol.ImplementationParams) (any, error) {
+func (s *e2eServer) Implementation(
+	context.Context,
+	*protocol.ImplementationParams,
+) (*protocol.OrDefinitionDefinitionLinkArray, error) {
 	return nil, nil
 }
 
@@ -354,10 +416,35 @@ func (s *e2eServer) RangeFormatting(
 }
 
 func (s *e2eServer) References(
-	context.Context,
-	*protocol.ReferenceParams,
+	ctx context.Context,
+	params *protocol.ReferenceParams,
 ) ([]protocol.Location, error) {
-	return nil, nil
+	client, _ := protocol.ClientFrom(ctx)
+	rep := protocol.NewPartialResultReporter(client, params.PartialResultToken)
+
+	var all []protocol.Location
+
+	for line := range uint32(2) {
+		chunk := []protocol.Location{{
+			URI: params.TextDocument.URI,
+			Range: protocol.Range{
+				Start: protocol.Position{Line: line, Character: 0},
+				End:   protocol.Position{Line: line, Character: 4},
+			},
+		}}
+
+		if rep.Enabled() {
+			if err := rep.Report(ctx, chunk); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		all = append(all, chunk...)
+	}
+
+	return all, nil
 }
 
 func (s *e2eServer) Rename(
@@ -384,7 +471,7 @@ func (s *e2eServer) SemanticTokensFull(
 func (s *e2eServer) SemanticTokensFullDelta(
 	context.Context,
 	*protocol.SemanticTokensDeltaParams,
-) (any, error) {
+) (*protocol.OrSemanticTokensSemanticTokensDelta, error) {
 	return nil, nil
 }
 
@@ -402,7 +489,10 @@ func (s *e2eServer) SignatureHelp(
 	return nil, nil
 }
 
-func (s *e2eServer) TypeDefinition(context.Context, *protocol.TypeDefinitionParams) (any, error) {
+func (s *e2eServer) TypeDefinition(
+	context.Context,
+	*protocol.TypeDefinitionParams,
+) (*protocol.OrDefinitionDefinitionLinkArray, error) {
 	return nil, nil
 }
 
@@ -494,7 +584,10 @@ func (s *e2eServer) ExecuteCommand(
 	return nil, nil
 }
 
-func (s *e2eServer) Symbols(context.Context, *protocol.WorkspaceSymbolParams) (any, error) {
+func (s *e2eServer) Symbols(
+	context.Context,
+	*protocol.WorkspaceSymbolParams,
+) (*protocol.OrSymbolInformationArrayWorkspaceSymbolArray, error) {
 	return nil, nil
 }
 
@@ -532,18 +625,29 @@ var _ protocol.Server = (*e2eServer)(nil)
 func setupE2E(t *testing.T) (context.Context, jsonrpc2.Conn, jsonrpc2.Conn, *e2eServer) {
 	t.Helper()
 
+	return setupE2EWithClientHandler(t, jsonrpc2.MethodNotFoundHandler)
+}
+
+// setupE2EWithClientHandler is like setupE2E, but serves server→client
+// messages with clientHandler.
+func setupE2EWithClientHandler(
+	t *testing.T,
+	clientHandler jsonrpc2.Handler,
+) (context.Context, jsonrpc2.Conn, jsonrpc2.Conn, *e2eServer) {
+	t.Helper()
+
 	srv := newE2EServer()
-	handler := protocol.ServerHandler(srv, nil)
 
 	clientConn, serverConn := net.Pipe()
 
 	serverStream := jsonrpc2.NewStream(serverConn)
 	sConn := jsonrpc2.NewConn(serverStream)
+	handler := protocol.ServerHandlerWithClient(srv, protocol.ClientDispatcher(sConn, nil), nil)
 	sConn.Go(context.Background(), handler)
 
 	clientStream := jsonrpc2.NewStream(clientConn)
 	cConn := jsonrpc2.NewConn(clientStream)
-	cConn.Go(context.Background(), jsonrpc2.MethodNotFoundHandler)
+	cConn.Go(context.Background(), clientHandler)
 
 	t.Cleanup(func() {
 		_ = cConn.Close()
@@ -573,8 +677,15 @@ func TestE2E_InitializeLifecycle(t *testing.T) {
 	assert.Equal(t, "e2e-test-server", initResult.ServerInfo.Name)
 	require.NotNil(t, initResult.ServerInfo.Version)
 	assert.Equal(t, "0.0.1-test", *initResult.ServerInfo.Version)
-	assert.Equal(t, true, initResult.Capabilities.HoverProvider)
-	assert.Equal(t, true, initResult.Capabilities.DefinitionProvider)
+	assert.True(t, initResult.Capabilities.HoverEnabled())
+	assert.True(t, initResult.Capabilities.DefinitionEnabled())
+
+	codeAction := initResult.Capabilities.CodeActionProvider
+	require.NotNil(t, codeAction)
+	require.NotNil(t, codeAction.Options, "options must survive the round trip")
+	assert.Equal(t, []protocol.CodeActionKind{protocol.CodeActionKindQuickFix},
+		codeAction.Options.CodeActionKinds)
+	assert.Equal(t, new(true), codeAction.Options.ResolveProvider)
 
 	// 2. initialized
 	require.NoError(t, clientConn.Notify(ctx, "initialized", protocol.InitializedParams{}))
@@ -607,13 +718,10 @@ func TestE2E_TextDocumentDidOpen(t *testing.T) {
 	}, &hover)
 	require.NoError(t, err)
 
-	contentsMap, ok := hover.Contents.(map[string]any)
-	require.True(t, ok, "hover.Contents should be map[string]any, got %T", hover.Contents)
-
-	val, ok := contentsMap["value"].(string)
-	require.True(t, ok)
-	assert.NotEmpty(t, val)
-	assert.Contains(t, val, "package main")
+	content, ok := hover.Contents.AsMarkupContent()
+	require.True(t, ok, "hover.Contents should hold a MarkupContent")
+	assert.NotEmpty(t, content.Value)
+	assert.Contains(t, content.Value, "package main")
 }
 
 func TestE2E_Completion(t *testing.T) {
@@ -738,3 +846,101 @@ func TestE2E_MultipleRequests(t *testing.T) {
 	_, err = clientConn.Call(ctx, "shutdown", nil, &shutdownResult)
 	require.NoError(t, err)
 }
+
+func TestE2E_PartialResults(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		chunks []protocol.ProgressParams
+	)
+
+	ctx, _, clientConn, _ := setupE2EWithClientHandler(
+		t,
+		func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+			if req.Method() != protocol.MethodProgress {
+				return jsonrpc2.MethodNotFoundHandler(ctx, reply, req)
+			}
+
+			var params protocol.ProgressParams
+			if err := json.Unmarshal(req.Params(), &params); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			chunks = append(chunks, params)
+			mu.Unlock()
+
+			return reply(ctx, nil, nil)
+		},
+	)
+
+	var token protocol.ProgressToken
+	token.SetString("refs-1")
+
+	var result []protocol.Location
+	_, err := clientConn.Call(ctx, "textDocument/references", protocol.ReferenceParams{
+		TextDocument:       protocol.TextDocumentIdentifier{URI: "file:///workspace/main.go"},
+		PartialResultToken: &token,
+	}, &result)
+	require.NoError(t, err)
+	assert.Empty(t, result, "streamed results must not be repeated in the final response")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, chunks, 2)
+
+	for line, chunk := range chunks {
+		assert.Equal(t, token, chunk.Token)
+
+		raw, err := json.Marshal(chunk.Value)
+		require.NoError(t, err)
+
+		var locs []protocol.Location
+		require.NoError(t, json.Unmarshal(raw, &locs))
+		require.Len(t, locs, 1)
+		assert.Equal(t, uint32(line), locs[0].Range.Start.Line)
+	}
+}
+
+func TestE2E_ServerCallsClientDuringRequest(t *testing.T) {
+	var sections []string
+
+	ctx, _, clientConn, srv := setupE2EWithClientHandler(
+		t,
+		func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+			if req.Method() != protocol.MethodWorkspaceConfiguration {
+				return jsonrpc2.MethodNotFoundHandler(ctx, reply, req)
+			}
+
+			var params protocol.ConfigurationParams
+			if err := json.Unmarshal(req.Params(), &params); err != nil {
+				return err
+			}
+			for _, item := range params.Items {
+				sections = append(sections, *item.Section)
+			}
+
+			return reply(ctx, []any{"Configured hover for"}, nil)
+		},
+	)
+	srv.hoverFromConfig = true
+
+	err := clientConn.Notify(ctx, "textDocument/didOpen", protocol.DidOpenTextDocumentParams{
+		TextDocument: protocol.TextDocumentItem{
+			URI: "file:///workspace/main.go", LanguageId: "go", Version: 1, Text: "package main",
+		},
+	})
+	require.NoError(t, err)
+
+	var hover struct {
+		Contents protocol.MarkupContent `json:"contents"`
+	}
+	_, err = clientConn.Call(ctx, "textDocument/hover", protocol.HoverParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: "file:///workspace/main.go"},
+		Position:     protocol.Position{Line: 0, Character: 8},
+	}, &hover)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"e2e.hoverPrefix"}, sections)
+	assert.Equal(t, "Configured hover for `package main` at 0:8", hover.Contents.Value)
+}
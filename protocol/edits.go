@@ -0,0 +1,84 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for building text edits and workspace edits.
+
+import "slices"
+
+// NewTextDocumentEdit returns a TextDocumentEdit applying edits to the
+// document identified by uri.
+//
+// A nil version serializes as `"version": null`, meaning the edit applies
+// regardless of the document version the client currently has. A non-nil
+// version makes the client reject the edit if the document has changed since.
+func NewTextDocumentEdit(uri DocumentURI, version *int32, edits []TextEdit) TextDocumentEdit {
+	items := make([]OrTextEditAnnotatedTextEdit, len(edits))
+	for idx, edit := range edits {
+		items[idx].SetTextEdit(edit)
+	}
+
+	return TextDocumentEdit{
+		TextDocument: OptionalVersionedTextDocumentIdentifier{
+			Version: version,
+			URI:     uri,
+		},
+		Edits: items,
+	}
+}
+
+// CoalesceTextEdits merges runs of contiguous edits, where one edit ends
+// exactly where the next begins, into a single edit replacing their combined
+// range with the concatenated text. Applying the result is equivalent to
+// applying edits; the edits are returned sorted by start position, with the
+// array order of edits starting at the same position preserved (the
+// specification applies same-position inserts in array order).
+//
+// This is useful for formatters that produce many tiny adjacent edits.
+func CoalesceTextEdits(edits []TextEdit) []TextEdit {
+	if len(edits) == 0 {
+		return edits
+	}
+
+	sorted := slices.Clone(edits)
+	slices.SortStableFunc(sorted, func(a, b TextEdit) int {
+		return a.Range.Start.Compare(b.Range.Start)
+	})
+
+	out := make([]TextEdit, 0, len(sorted))
+	out = append(out, sorted[0])
+
+	for _, edit := range sorted[1:] {
+		last := &out[len(out)-1]
+		if last.Range.End != edit.Range.Start {
+			out = append(out, edit)
+			continue
+		}
+
+		last.Range.End = edit.Range.End
+		last.NewText += edit.NewText
+	}
+
+	return out
+}
+
+// IsEmpty reports whether e changes nothing: Changes holds no text edits and
+// DocumentChanges holds neither text edits nor file operations. A server
+// should not send an empty edit, as clients may still record it as an undo
+// step.
+func (e WorkspaceEdit) IsEmpty() bool {
+	for _, edits := range e.Changes {
+		if len(edits) > 0 {
+			return false
+		}
+	}
+
+	for _, change := range e.DocumentChanges {
+		if edit, ok := change.AsTextDocumentEdit(); !ok || len(edit.Edits) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
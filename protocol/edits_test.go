@@ -0,0 +1,179 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTextDocumentEdit(t *testing.T) {
+	edits := []TextEdit{{
+		Range: Range{
+			Start: Position{Line: 0, Character: 0},
+			End:   Position{Line: 0, Character: 3},
+		},
+		NewText: "bar",
+	}}
+
+	t.Run("null version", func(t *testing.T) {
+		data, err := json.Marshal(NewTextDocumentEdit("file:///a.go", nil, edits))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"textDocument": {"uri": "file:///a.go", "version": null},
+			"edits": [{
+				"range": {"start": {"line": 0, "character": 0}, "end": {"line": 0, "character": 3}},
+				"newText": "bar"
+			}]
+		}`, string(data))
+	})
+
+	t.Run("numbered version", func(t *testing.T) {
+		data, err := json.Marshal(NewTextDocumentEdit("file:///a.go", new(int32(4)), edits))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"textDocument":{"version":4,"uri":"file:///a.go"}`)
+	})
+
+	t.Run("no edits", func(t *testing.T) {
+		data, err := json.Marshal(NewTextDocumentEdit("file:///a.go", nil, nil))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"edits":[]`)
+	})
+}
+
+func TestCoalesceTextEdits(t *testing.T) {
+	at := func(line, start, end uint32, text string) TextEdit {
+		return TextEdit{
+			Range: Range{
+				Start: Position{Line: line, Character: start},
+				End:   Position{Line: line, Character: end},
+			},
+			NewText: text,
+		}
+	}
+
+	// apply applies edits to a single-line document, rightmost first.
+	apply := func(doc string, edits []TextEdit) string {
+		sorted := slices.Clone(edits)
+		slices.SortStableFunc(sorted, func(a, b TextEdit) int {
+			return b.Range.Start.Compare(a.Range.Start)
+		})
+
+		for _, e := range sorted {
+			doc = doc[:e.Range.Start.Character] + e.NewText + doc[e.Range.End.Character:]
+		}
+
+		return doc
+	}
+
+	t.Run("adjacent replacements collapse", func(t *testing.T) {
+		// Replace "abcd" character by character with "WXYZ", out of order.
+		edits := []TextEdit{at(0, 2, 3, "Y"), at(0, 0, 1, "W"), at(0, 3, 4, "Z"), at(0, 1, 2, "X")}
+
+		got := CoalesceTextEdits(edits)
+		assert.Equal(t, []TextEdit{at(0, 0, 4, "WXYZ")}, got)
+		assert.Equal(t, apply("abcd!", edits), apply("abcd!", got))
+	})
+
+	t.Run("same position inserts keep order", func(t *testing.T) {
+		edits := []TextEdit{at(0, 1, 1, "x"), at(0, 1, 1, "y"), at(0, 1, 1, "z")}
+
+		assert.Equal(t, []TextEdit{at(0, 1, 1, "xyz")}, CoalesceTextEdits(edits))
+	})
+
+	t.Run("non-adjacent edits left alone", func(t *testing.T) {
+		edits := []TextEdit{at(0, 0, 1, "A"), at(0, 2, 3, "C"), at(1, 0, 0, "new ")}
+
+		assert.Equal(t, edits, CoalesceTextEdits(edits))
+	})
+
+	t.Run("input not modified", func(t *testing.T) {
+		edits := []TextEdit{at(0, 1, 2, "b"), at(0, 0, 1, "a")}
+
+		_ = CoalesceTextEdits(edits)
+		assert.Equal(t, []TextEdit{at(0, 1, 2, "b"), at(0, 0, 1, "a")}, edits)
+	})
+
+	assert.Empty(t, CoalesceTextEdits(nil))
+}
+
+func TestWorkspaceEditIsEmpty(t *testing.T) {
+	const uri DocumentURI = "file:///a.go"
+
+	edit := TextEdit{Range: Position{Line: 1}.To(Position{Line: 1, Character: 3}), NewText: "x"}
+
+	decode := func(t *testing.T, raw string) WorkspaceEdit {
+		t.Helper()
+
+		var e WorkspaceEdit
+		require.NoError(t, json.Unmarshal([]byte(raw), &e))
+
+		return e
+	}
+
+	textDocumentEdit := func(edits []TextEdit) WorkspaceEdit {
+		var change OrTextDocumentEditCreateFileRenameFileDeleteFile
+		change.SetTextDocumentEdit(NewTextDocumentEdit(uri, nil, edits))
+
+		return WorkspaceEdit{DocumentChanges: []OrTextDocumentEditCreateFileRenameFileDeleteFile{change}}
+	}
+
+	var create OrTextDocumentEditCreateFileRenameFileDeleteFile
+	create.SetCreateFile(CreateFile{Kind: "create", URI: uri})
+
+	tests := []struct {
+		name string
+		edit WorkspaceEdit
+		want bool
+	}{
+		{"zero value", WorkspaceEdit{}, true},
+		{
+			"empty text edit list",
+			WorkspaceEdit{Changes: map[DocumentURI][]TextEdit{uri: {}}},
+			true,
+		},
+		{
+			"empty text document edit",
+			textDocumentEdit(nil),
+			true,
+		},
+		{
+			"text edit",
+			WorkspaceEdit{Changes: map[DocumentURI][]TextEdit{uri: {edit}}},
+			false,
+		},
+		{
+			"text document edit",
+			textDocumentEdit([]TextEdit{edit}),
+			false,
+		},
+		{
+			"file operation",
+			WorkspaceEdit{DocumentChanges: []OrTextDocumentEditCreateFileRenameFileDeleteFile{create}},
+			false,
+		},
+		{
+			"decoded empty edit",
+			decode(t, `{"documentChanges": [
+				{"textDocument": {"uri": "file:///a.go", "version": null}, "edits": []}
+			]}`),
+			true,
+		},
+		{
+			"decoded file operation",
+			decode(t, `{"documentChanges": [{"kind": "delete", "uri": "file:///a.go"}]}`),
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.edit.IsEmpty())
+		})
+	}
+}
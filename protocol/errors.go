@@ -4,8 +4,14 @@
 package protocol
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"syscall"
 
 	"go.lsp.dev/jsonrpc2"
 )
@@ -41,8 +47,265 @@ const (
 	CodeContentModified int64 = -32801
 )
 
+// Sentinel errors for the LSP error codes. A *ResponseError matches any other
+// with its code under errors.Is, whatever its message or data, so a handler
+// can return one directly and a client can test the error returned by a
+// request made through ClientDispatcher:
+//
+//	if errors.Is(err, protocol.ErrContentModified) {
+//		// the document changed; retry with the new version
+//	}
+//
+// To attach data, build a new error with NewResponseError or Errorf rather
+// than modifying a sentinel.
+//
+//nolint:gochecknoglobals
+var (
+	// ErrServerNotInitialized has CodeServerNotInitialized.
+	ErrServerNotInitialized = NewResponseError(CodeServerNotInitialized, "server not initialized")
+
+	// ErrInvalidRequest has CodeInvalidRequest.
+	ErrInvalidRequest = NewResponseError(CodeInvalidRequest, "invalid request")
+
+	// ErrMethodNotFound has CodeMethodNotFound.
+	ErrMethodNotFound = NewResponseError(CodeMethodNotFound, "method not found")
+
+	// ErrInvalidParams has CodeInvalidParams.
+	ErrInvalidParams = NewResponseError(CodeInvalidParams, "invalid params")
+
+	// ErrInternalError has CodeInternalError.
+	ErrInternalError = NewResponseError(CodeInternalError, "internal error")
+
+	// ErrParseError has CodeParseError.
+	ErrParseError = NewResponseError(CodeParseError, "parse error")
+
+	// ErrRequestCancelled has CodeRequestCancelled.
+	ErrRequestCancelled = NewResponseError(CodeRequestCancelled, "request cancelled")
+
+	// ErrContentModified has CodeContentModified.
+	ErrContentModified = NewResponseError(CodeContentModified, "content modified")
+)
+
+// ErrUnsupported is returned by helpers that make server→client calls when
+// the client's capabilities show it does not support the call. Test for it
+// with errors.Is. It wraps errors.ErrUnsupported, so a Server method that
+// passes it on replies with CodeMethodNotFound under DefaultErrorCodes.
+var ErrUnsupported = fmt.Errorf("not supported by the client: %w", errors.ErrUnsupported)
+
+// ResponseError is an error a Server method returns to reply with a specific
+// LSP error code and optional structured data, for example CodeContentModified
+// so the client retries the request instead of reporting a failure.
+//
+// It converts to *jsonrpc2.Error with errors.As, so it is sent with its code
+// even when wrapped, and IsTransient, ErrorData and TelemetryError treat it
+// like a JSON-RPC error.
+type ResponseError struct {
+	// Code is the LSP or JSON-RPC error code, such as CodeRequestCancelled.
+	Code int64
+	// Message is a short description of the error.
+	Message string
+	// Data is additional information about the error, encoded as JSON. It is
+	// left out of the reply if it cannot be encoded.
+	Data any
+}
+
+// NewResponseError returns a ResponseError with code and msg and no data.
+func NewResponseError(code int64, msg string) *ResponseError {
+	return &ResponseError{Code: code, Message: msg, Data: nil}
+}
+
+// Errorf returns a ResponseError with code and a message formatted according
+// to format.
+func Errorf(code int64, format string, args ...any) *ResponseError {
+	return NewResponseError(code, fmt.Sprintf(format, args...))
+}
+
+// Error implements error.
+func (e *ResponseError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is a *ResponseError with the same code as e,
+// such as ErrContentModified.
+func (e *ResponseError) Is(target error) bool {
+	t, ok := target.(*ResponseError)
+
+	return ok && t.Code == e.Code
+}
+
+// As sets target to the *jsonrpc2.Error for e if target is a
+// **jsonrpc2.Error, so errors.As finds a JSON-RPC error in chains holding e.
+func (e *ResponseError) As(target any) bool {
+	rpcErr, ok := target.(**jsonrpc2.Error)
+	if ok {
+		*rpcErr = e.jsonrpc2Error()
+	}
+
+	return ok
+}
+
+func (e *ResponseError) jsonrpc2Error() *jsonrpc2.Error {
+	rpcErr := jsonrpc2.NewError(jsonrpc2.Code(e.Code), e.Message)
+
+	if e.Data != nil {
+		if data, err := json.Marshal(e.Data); err == nil { //nolint:noinlineerr
+			raw := json.RawMessage(data)
+			rpcErr.Data = &raw
+		}
+	}
+
+	return rpcErr
+}
+
+// CodeOf returns the code of the first *ResponseError or *jsonrpc2.Error in
+// err's chain. It reports false if there is none.
+func CodeOf(err error) (int64, bool) {
+	var rpcErr *jsonrpc2.Error
+	if !errors.As(err, &rpcErr) {
+		return 0, false
+	}
+
+	return int64(rpcErr.Code), true
+}
+
+// responseErrorConn is a jsonrpc2.Conn whose Call returns error responses as
+// *ResponseError, so errors.Is matches them against ErrContentModified and
+// the like.
+type responseErrorConn struct {
+	jsonrpc2.Conn
+}
+
+// Call implements jsonrpc2.Conn.
+func (c responseErrorConn) Call(
+	ctx context.Context,
+	method string,
+	params, result any,
+) (jsonrpc2.ID, error) {
+	id, err := c.Conn.Call(ctx, method, params, result)
+
+	var rpcErr *jsonrpc2.Error
+	if errors.As(err, &rpcErr) {
+		respErr := NewResponseError(int64(rpcErr.Code), rpcErr.Message)
+		if rpcErr.Data != nil {
+			respErr.Data = json.RawMessage(*rpcErr.Data)
+		}
+
+		return id, respErr
+	}
+
+	return id, err //nolint:wrapcheck
+}
+
+// IsTransient reports whether err is a transport-level failure, such as the
+// peer closing the stream, a broken pipe, a reset connection or a timeout,
+// after which reconnecting and retrying may succeed. JSON-RPC errors carrying
+// a code are application errors and are never transient, even if they wrap a
+// transport error.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var rpcErr *jsonrpc2.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// ErrorData finds the first *jsonrpc2.Error in err's chain and unmarshals its
+// Data into target, which must be a non-nil pointer. It reports false when
+// there is no such error, the error carries no data (or null), or the data
+// does not decode into target.
+//
+// It lets clients act on structured error payloads:
+//
+//	var detail struct{ Retry bool }
+//
+//	_, err := conn.Call(ctx, method, params, &result)
+//	if ErrorData(err, &detail) && detail.Retry {
+//		// try again later
+//	}
+func ErrorData(err error, target any) bool {
+	var rpcErr *jsonrpc2.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Data == nil {
+		return false
+	}
+
+	data := bytes.TrimSpace(*rpcErr.Data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return false
+	}
+
+	return json.Unmarshal(data, target) == nil
+}
+
 // replyParseError sends a parse error reply. This is used by the generated
 // dispatch code when JSON unmarshalling of parameters fails.
 func replyParseError(ctx context.Context, reply jsonrpc2.Replier, err error) error {
-	return reply(ctx, nil, fmt.Errorf("invalid params: %w", err))
+	return reply(ctx, nil, jsonrpc2.Errorf(jsonrpc2.Code(CodeInvalidParams), "invalid params: %v", err))
+}
+
+// ErrorCodeMapping maps the errors matching Err under errors.Is to Code when
+// a Server method returns them (see HandlerOptions.ErrorCodes).
+type ErrorCodeMapping struct {
+	Err  error
+	Code int64
+}
+
+// DefaultErrorCodes returns the error mapping used by ServerHandler: context
+// cancellation and deadlines are reported as CodeRequestCancelled, and
+// errors.ErrUnsupported, which a Server.Request catch-all can return for
+// methods it does not know, as CodeMethodNotFound.
+func DefaultErrorCodes() []ErrorCodeMapping {
+	return []ErrorCodeMapping{
+		{Err: context.Canceled, Code: CodeRequestCancelled},
+		{Err: context.DeadlineExceeded, Code: CodeRequestCancelled},
+		{Err: errors.ErrUnsupported, Code: CodeMethodNotFound},
+	}
+}
+
+// replyErrors wraps reply so that errors returned by Server methods reach the
+// client with a proper JSON-RPC code. A *jsonrpc2.Error or *ResponseError
+// found anywhere in the error chain (via errors.As) is forwarded with its code
+// and data. Otherwise the code of the first entry of codes matching the error
+// is used, and CodeInternalError if none does; the error's text becomes the
+// message. A nil *ResponseError, as returned by a handler declaring one, is
+// no error.
+func replyErrors(reply jsonrpc2.Replier, codes []ErrorCodeMapping) jsonrpc2.Replier {
+	return func(ctx context.Context, result any, err error) error {
+		if respErr, ok := err.(*ResponseError); err == nil || ok && respErr == nil { //nolint:errorlint
+			return reply(ctx, result, nil)
+		}
+
+		var rpcErr *jsonrpc2.Error
+		if !errors.As(err, &rpcErr) {
+			code := CodeInternalError
+
+			for _, mapping := range codes {
+				if errors.Is(err, mapping.Err) {
+					code = mapping.Code
+					break
+				}
+			}
+
+			rpcErr = jsonrpc2.NewError(jsonrpc2.Code(code), err.Error())
+		}
+
+		return reply(ctx, nil, rpcErr)
+	}
 }
@@ -0,0 +1,244 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/jsonrpc2"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"eof", io.EOF, true},
+		{"unexpected eof", fmt.Errorf("read header: %w", io.ErrUnexpectedEOF), true},
+		{"closed pipe", io.ErrClosedPipe, true},
+		{"closed conn", &net.OpError{Op: "read", Net: "tcp", Err: net.ErrClosed}, true},
+		{"broken pipe", &os.SyscallError{Syscall: "write", Err: syscall.EPIPE}, true},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, true},
+		{"read deadline", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, true},
+		{"context deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), true},
+		{"context canceled", context.Canceled, false},
+		{"method not found", jsonrpc2.NewError(-32601, "no such method"), false},
+		{"content modified", fmt.Errorf("hover: %w", jsonrpc2.NewError(-32801, "stale")), false},
+		{"plain application error", errors.New("index not ready"), false},
+		{"unsupported", ErrUnsupported, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransient(tt.err))
+		})
+	}
+}
+
+func TestErrorData(t *testing.T) {
+	type detail struct {
+		Retry bool   `json:"retry"`
+		After string `json:"after"`
+	}
+
+	data := json.RawMessage(`{"retry": true, "after": "5s"}`)
+	custom := &jsonrpc2.Error{Code: -32099, Message: "index not ready", Data: &data}
+
+	clientPipe, serverPipe := net.Pipe()
+
+	serverConn := jsonrpc2.NewConn(jsonrpc2.NewStream(serverPipe))
+	srv := &stubServer{hoverErr: fmt.Errorf("hover: %w", custom)}
+	serverConn.Go(context.Background(), ServerHandler(srv, nil))
+
+	clientConn := jsonrpc2.NewConn(jsonrpc2.NewStream(clientPipe))
+	clientConn.Go(context.Background(), jsonrpc2.MethodNotFoundHandler)
+
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+		<-clientConn.Done()
+		<-serverConn.Done()
+	})
+
+	var result any
+	_, err := clientConn.Call(context.Background(), MethodTextDocumentHover, HoverParams{}, &result)
+	require.Error(t, err)
+
+	var got detail
+	require.True(t, ErrorData(fmt.Errorf("client: %w", err), &got))
+	assert.Equal(t, detail{Retry: true, After: "5s"}, got)
+
+	var mismatched []string
+	assert.False(t, ErrorData(err, &mismatched), "data that does not fit target")
+
+	null := json.RawMessage(`null`)
+	assert.False(t, ErrorData(&jsonrpc2.Error{Code: -32099, Data: &null}, &got))
+	assert.False(t, ErrorData(jsonrpc2.NewError(jsonrpc2.Code(CodeInternalError), "boom"), &got))
+	assert.False(t, ErrorData(errors.New("boom"), &got))
+	assert.False(t, ErrorData(nil, &got))
+}
+
+func TestResponseError(t *testing.T) {
+	t.Run("constructors", func(t *testing.T) {
+		err := Errorf(CodeContentModified, "document %s changed", "a.go")
+		assert.Equal(t, CodeContentModified, err.Code)
+		assert.Equal(t, "document a.go changed", err.Error())
+		assert.Nil(t, err.Data)
+
+		assert.Equal(t, &ResponseError{Code: CodeRequestCancelled, Message: "cancelled"},
+			NewResponseError(CodeRequestCancelled, "cancelled"))
+	})
+
+	t.Run("as jsonrpc2 error", func(t *testing.T) {
+		err := fmt.Errorf("hover: %w", &ResponseError{
+			Code:    CodeContentModified,
+			Message: "stale",
+			Data:    map[string]bool{"retry": true},
+		})
+
+		var rpcErr *jsonrpc2.Error
+		require.ErrorAs(t, err, &rpcErr)
+		assert.Equal(t, jsonrpc2.Code(CodeContentModified), rpcErr.Code)
+		assert.Equal(t, "stale", rpcErr.Message)
+		require.NotNil(t, rpcErr.Data)
+		assert.JSONEq(t, `{"retry": true}`, string(*rpcErr.Data))
+
+		var respErr *ResponseError
+		require.ErrorAs(t, err, &respErr)
+		assert.False(t, IsTransient(err))
+	})
+
+	t.Run("unencodable data dropped", func(t *testing.T) {
+		var rpcErr *jsonrpc2.Error
+		require.ErrorAs(t, &ResponseError{Code: -32099, Message: "bad", Data: func() {}}, &rpcErr)
+		assert.Nil(t, rpcErr.Data)
+	})
+
+	t.Run("reply", func(t *testing.T) {
+		clientPipe, serverPipe := net.Pipe()
+
+		serverConn := jsonrpc2.NewConn(jsonrpc2.NewStream(serverPipe))
+		srv := &stubServer{hoverErr: fmt.Errorf("hover: %w", &ResponseError{
+			Code:    CodeContentModified,
+			Message: "document changed",
+			Data:    map[string]int{"version": 3},
+		})}
+		serverConn.Go(context.Background(), ServerHandler(srv, nil))
+
+		clientConn := jsonrpc2.NewConn(jsonrpc2.NewStream(clientPipe))
+		clientConn.Go(context.Background(), jsonrpc2.MethodNotFoundHandler)
+
+		t.Cleanup(func() {
+			_ = clientConn.Close()
+			_ = serverConn.Close()
+			<-clientConn.Done()
+			<-serverConn.Done()
+		})
+
+		var result any
+		_, err := clientConn.Call(
+			context.Background(), MethodTextDocumentHover, HoverParams{}, &result)
+
+		var rpcErr *jsonrpc2.Error
+		require.ErrorAs(t, err, &rpcErr)
+		assert.Equal(t, jsonrpc2.Code(CodeContentModified), rpcErr.Code)
+		assert.Equal(t, "document changed", rpcErr.Message)
+
+		var data struct{ Version int }
+		require.True(t, ErrorData(err, &data))
+		assert.Equal(t, 3, data.Version)
+	})
+}
+
+func TestResponseError_Is(t *testing.T) {
+	err := fmt.Errorf("hover: %w", Errorf(CodeContentModified, "document %s changed", "a.go"))
+	require.ErrorIs(t, err, ErrContentModified)
+	assert.NotErrorIs(t, err, ErrRequestCancelled)
+
+	require.ErrorIs(t, ErrMethodNotFound, ErrMethodNotFound)
+	assert.NotErrorIs(t, jsonrpc2.NewError(jsonrpc2.Code(CodeContentModified), "stale"),
+		ErrContentModified, "a bare *jsonrpc2.Error is not converted")
+	assert.NotErrorIs(t, errors.New("content modified"), ErrContentModified)
+
+	withData := &ResponseError{Code: CodeContentModified, Message: "stale", Data: 3}
+	require.ErrorIs(t, withData, ErrContentModified, "data is ignored")
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   int64
+		wantOK bool
+	}{
+		{"nil", nil, 0, false},
+		{"plain", errors.New("boom"), 0, false},
+		{"sentinel", ErrInvalidParams, CodeInvalidParams, true},
+		{"response error", fmt.Errorf("x: %w", NewResponseError(-32099, "busy")), -32099, true},
+		{"jsonrpc2 error", fmt.Errorf("x: %w", jsonrpc2.NewError(-32601, "nope")), -32601, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := CodeOf(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+func TestClientDispatcher_ResponseErrors(t *testing.T) {
+	serverPipe, clientPipe := net.Pipe()
+
+	data := json.RawMessage(`{"retry": true}`)
+	handler := func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		if req.Method() == MethodWindowShowDocument {
+			return reply(ctx, nil, &jsonrpc2.Error{Code: -32801, Message: "stale", Data: &data})
+		}
+
+		return jsonrpc2.MethodNotFoundHandler(ctx, reply, req)
+	}
+
+	clientConn := jsonrpc2.NewConn(jsonrpc2.NewStream(clientPipe))
+	clientConn.Go(context.Background(), handler)
+
+	serverConn := jsonrpc2.NewConn(jsonrpc2.NewStream(serverPipe))
+	serverConn.Go(context.Background(), jsonrpc2.MethodNotFoundHandler)
+
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+		<-clientConn.Done()
+		<-serverConn.Done()
+	})
+
+	client := ClientDispatcher(serverConn, nil)
+
+	_, err := client.ShowDocument(context.Background(), &ShowDocumentParams{URI: "file:///a.go"})
+	require.ErrorIs(t, err, ErrContentModified)
+	assert.Equal(t, "stale", err.Error())
+
+	var detail struct{ Retry bool }
+	require.True(t, ErrorData(err, &detail))
+	assert.True(t, detail.Retry)
+
+	_, err = client.ShowMessageRequest(context.Background(), &ShowMessageRequestParams{})
+	require.ErrorIs(t, err, ErrMethodNotFound)
+
+	code, ok := CodeOf(err)
+	require.True(t, ok)
+	assert.Equal(t, CodeMethodNotFound, code)
+}
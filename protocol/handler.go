@@ -5,16 +5,70 @@ package protocol
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
 
 	"go.lsp.dev/jsonrpc2"
 )
 
+// HandlerOptions configures the behaviour of ServerHandlerWithOptions.
+type HandlerOptions struct {
+	// NormalizeNilSlices replies with an empty array `[]` instead of `null`
+	// when a list-returning Server method (e.g. References, CodeLens) returns
+	// a nil slice. The specification allows `null` for most list results, but
+	// some clients treat it as an error.
+	NormalizeNilSlices bool
+
+	// MaxConcurrency, if positive, bounds the number of requests handled at
+	// once, so that a client flooding the server cannot pile up an unbounded
+	// number of them; requests beyond the limit wait for a free slot.
+	// Notifications bypass the limit and are still handled in the order they
+	// arrive.
+	MaxConcurrency int
+
+	// MaxQueued bounds the number of requests waiting for a slot when
+	// MaxConcurrency is set. Requests arriving while the queue is full are
+	// rejected with CodeInternalError ("server busy"). Zero means no queue:
+	// every request past MaxConcurrency is rejected.
+	MaxQueued int
+
+	// WarnCatchAll logs a warning naming the method of every request and
+	// notification routed to the Server.Request catch-all, so that operators
+	// notice features the server does not implement. Custom methods
+	// registered with RegisterCustomMethod are expected there and are not
+	// logged.
+	WarnCatchAll bool
+
+	// ErrorCodes maps errors returned by Server methods to the code of the
+	// error reply; the first entry whose Err matches under errors.Is wins.
+	// Errors carrying their own code (*ResponseError, *jsonrpc2.Error) keep
+	// it, and errors matching no entry are sent as CodeInternalError. Nil
+	// means DefaultErrorCodes; an empty slice disables the mapping.
+	ErrorCodes []ErrorCodeMapping
+}
+
 // ServerHandler returns a jsonrpc2.Handler that dispatches incoming requests
 // and notifications to the given Server implementation.
 //
 // The logger parameter is used for protocol-level logging.  Pass NopLogger()
 // (or nil) to disable logging.
 //
+// An error returned by a Server method is sent to the client as-is if it is
+// (or wraps) a *jsonrpc2.Error or *ResponseError. Other errors are mapped to
+// a code by DefaultErrorCodes, so that a handler returning ctx.Err() after
+// $/cancelRequest replies with CodeRequestCancelled, and sent as an internal
+// error if they match none.
+//
+// Each request is handled on its own goroutine with its own cancellable
+// context, which is cancelled when the client sends $/cancelRequest for it
+// (see IsCancelled). Keeping requests off the connection's read loop is what
+// lets the cancellation be read while a request is running. A request
+// reusing the id of a request still in flight is rejected with
+// CodeInvalidRequest. Notifications are handled on the read loop, in the
+// order they arrive, so that a request sees the effect of every notification
+// (e.g. didChange) sent before it.
+//
 // Usage:
 //
 //	var s protocol.Server = &myServer{}
@@ -22,11 +76,289 @@ import (
 //	conn := jsonrpc2.NewConn(stream)
 //	conn.Go(ctx, handler)
 func ServerHandler(server Server, logger Logger) jsonrpc2.Handler {
+	return ServerHandlerWithOptions(server, logger, HandlerOptions{}) //nolint:exhaustruct
+}
+
+// ServerHandlerWithOptions is like ServerHandler, but applies opts to every
+// reply sent by the dispatcher.
+func ServerHandlerWithOptions(server Server, logger Logger, opts HandlerOptions) jsonrpc2.Handler {
 	if logger == nil {
-		logger = NopLogger() //nolint:ineffassign,staticcheck,wastedassign
+		logger = NopLogger()
+	}
+
+	if opts.WarnCatchAll {
+		server = catchAllWarner{Server: server, logger: logger}
+	}
+
+	codes := opts.ErrorCodes
+	if codes == nil {
+		codes = DefaultErrorCodes()
+	}
+
+	calls := &inflightCalls{cancels: make(map[jsonrpc2.ID]context.CancelFunc)}
+
+	var queue *dispatchQueue
+	if opts.MaxConcurrency > 0 {
+		queue = newDispatchQueue(opts.MaxConcurrency, max(opts.MaxQueued, 0))
 	}
 
 	return func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
-		return serverDispatch(ctx, server, reply, req)
+		reply = replyErrors(replyUncancelled(reply), codes)
+
+		if opts.NormalizeNilSlices {
+			reply = normalizeNilSlices(reply)
+		}
+
+		call, ok := req.(*jsonrpc2.Call)
+		if !ok {
+			if req.Method() == MethodCancelRequest {
+				calls.cancel(req.Params())
+			}
+
+			return serverDispatch(ctx, server, reply, req)
+		}
+
+		ctx, done, ok := calls.start(ctx, call.ID())
+		if !ok {
+			logger.Warn("request id already in use", "method", call.Method(), "id", call.ID())
+
+			return reply(ctx, nil, jsonrpc2.Errorf(
+				jsonrpc2.Code(CodeInvalidRequest), "request id %v is already in use", call.ID()))
+		}
+
+		// The id is free again once the call is answered, as the client may
+		// reuse it as soon as it reads the response.
+		answer := reply
+		reply = func(ctx context.Context, result any, err error) error {
+			done()
+			return answer(ctx, result, err)
+		}
+
+		if queue != nil {
+			return queue.dispatch(ctx, reply, call, done, func(ctx context.Context) {
+				_ = serverDispatch(ctx, server, reply, call)
+			})
+		}
+
+		go func() {
+			defer done()
+
+			_ = serverDispatch(ctx, server, reply, call)
+		}()
+
+		return nil
+	}
+}
+
+// catchAllWarner is a Server logging the methods routed to its Request
+// catch-all (see HandlerOptions.WarnCatchAll).
+type catchAllWarner struct {
+	Server
+
+	logger Logger
+}
+
+func (s catchAllWarner) Request(ctx context.Context, method string, params any) (any, error) {
+	if !isCustomMethod(method) {
+		s.logger.Warn("unhandled method routed to the Request catch-all", "method", method)
+	}
+
+	return s.Server.Request(ctx, method, params) //nolint:wrapcheck
+}
+
+// IsCancelled reports whether ctx has been cancelled, for example because the
+// client sent $/cancelRequest for the request being handled. Server methods
+// that loop for a long time, or fan out to goroutines, should check it
+// periodically and stop early.
+func IsCancelled(ctx context.Context) bool {
+	return ctx.Err() != nil
+}
+
+// inflightCalls tracks the cancel functions of the calls being handled, keyed
+// by request id, so that $/cancelRequest can cancel the matching context.
+type inflightCalls struct {
+	mu      sync.Mutex
+	cancels map[jsonrpc2.ID]context.CancelFunc
+}
+
+// start derives the context passed to the Server method handling call id.
+// The returned function, which must be called once the call is answered and
+// may be called more than once, cancels the context and frees the id. start
+// reports false if a call with the same id is still in flight.
+func (c *inflightCalls) start(ctx context.Context, id jsonrpc2.ID) (context.Context, func(), bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.cancels[id]; ok {
+		return ctx, nil, false
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancels[id] = cancel
+
+	return ctx, sync.OnceFunc(func() {
+		c.mu.Lock()
+		delete(c.cancels, id)
+		c.mu.Unlock()
+		cancel()
+	}), true
+}
+
+// cancel cancels the call named by the raw $/cancelRequest params, if it is
+// still in flight.
+func (c *inflightCalls) cancel(raw json.RawMessage) {
+	var params struct {
+		ID jsonrpc2.ID `json:"id"`
 	}
+
+	if err := json.Unmarshal(raw, &params); err != nil { //nolint:noinlineerr
+		return
+	}
+
+	c.mu.Lock()
+	cancel, ok := c.cancels[params.ID]
+	c.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
+// dispatchQueue bounds the requests handled concurrently by
+// ServerHandlerWithOptions (see HandlerOptions.MaxConcurrency).
+type dispatchQueue struct {
+	// running holds a token for every request running.
+	running chan struct{}
+	// waiting holds a token for every request waiting for a running slot.
+	waiting chan struct{}
+}
+
+func newDispatchQueue(maxConcurrency, maxQueued int) *dispatchQueue {
+	return &dispatchQueue{
+		running: make(chan struct{}, maxConcurrency),
+		waiting: make(chan struct{}, maxQueued),
+	}
+}
+
+// dispatch runs handle on its own goroutine once a slot is free, or replies
+// "server busy" right away if too many requests are already waiting. A
+// request cancelled while it waits is answered with CodeRequestCancelled
+// without being handled. done is called once the request is finished with.
+func (q *dispatchQueue) dispatch(
+	ctx context.Context,
+	reply jsonrpc2.Replier,
+	call *jsonrpc2.Call,
+	done func(),
+	handle func(ctx context.Context),
+) error {
+	// Take a free slot right away, so that a request arriving while one is
+	// free never waits behind a request that arrived later.
+	var slot bool
+
+	select {
+	case q.running <- struct{}{}:
+		slot = true
+	default:
+		select {
+		case q.waiting <- struct{}{}:
+		default:
+			defer done()
+
+			return reply(ctx, nil, jsonrpc2.Errorf(
+				jsonrpc2.Code(CodeInternalError), "server busy: %s not handled", call.Method()))
+		}
+	}
+
+	go func() {
+		defer done()
+
+		if !slot {
+			select {
+			case q.running <- struct{}{}:
+				<-q.waiting
+			case <-ctx.Done():
+				<-q.waiting
+
+				_ = reply(ctx, nil, jsonrpc2.NewError(
+					jsonrpc2.Code(CodeRequestCancelled), ctx.Err().Error()))
+
+				return
+			}
+		}
+
+		defer func() { <-q.running }()
+
+		handle(ctx)
+	}()
+
+	return nil
+}
+
+// replyUncancelled wraps reply so that the reply is sent even if the request
+// context was cancelled: a request cancelled by $/cancelRequest must still be
+// answered, and jsonrpc2 streams refuse to write with a cancelled context.
+func replyUncancelled(reply jsonrpc2.Replier) jsonrpc2.Replier {
+	return func(ctx context.Context, result any, err error) error {
+		return reply(context.WithoutCancel(ctx), result, err)
+	}
+}
+
+// normalizeNilSlices wraps reply so that a nil slice result is sent as an
+// empty array rather than `null`.
+func normalizeNilSlices(reply jsonrpc2.Replier) jsonrpc2.Replier {
+	return func(ctx context.Context, result any, err error) error {
+		if err == nil && result != nil {
+			if v := reflect.ValueOf(result); v.Kind() == reflect.Slice && v.IsNil() {
+				result = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+			}
+		}
+
+		return reply(ctx, result, err)
+	}
+}
+
+// clientKey is the context key under which ServerHandlerWithClient stores
+// the Client.
+type clientKey struct{}
+
+// ServerHandlerWithClient is like ServerHandler, but makes client available
+// to every Server method through ClientFrom, so that a method can issue
+// server→client calls (e.g. workspace/configuration) while it is handling a
+// request:
+//
+//	conn := jsonrpc2.NewConn(stream)
+//	client := protocol.ClientDispatcher(conn, logger)
+//	conn.Go(ctx, protocol.ServerHandlerWithClient(s, client, logger))
+//
+// jsonrpc2 reads the response to such a call on the same loop that invokes
+// the handler; ServerHandler handles requests on their own goroutine, so that
+// loop stays free while a method waits for the client.
+func ServerHandlerWithClient(server Server, client Client, logger Logger) jsonrpc2.Handler {
+	handler := ServerHandler(server, logger)
+
+	return func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		return handler(context.WithValue(ctx, clientKey{}, client), reply, req)
+	}
+}
+
+// ClientFrom returns the Client injected by ServerHandlerWithClient, if any.
+func ClientFrom(ctx context.Context) (Client, bool) {
+	client, ok := ctx.Value(clientKey{}).(Client)
+	return client, ok
+}
+
+// Middleware wraps a jsonrpc2.Handler to observe or alter the messages it
+// handles, e.g. for logging, metrics or test recording.
+type Middleware func(next jsonrpc2.Handler) jsonrpc2.Handler
+
+// WithMiddleware returns handler wrapped in mws. The first middleware is the
+// outermost: it sees each message first and its reply last.
+//
+//	handler := protocol.WithMiddleware(protocol.ServerHandler(s, nil), logging, metrics)
+func WithMiddleware(handler jsonrpc2.Handler, mws ...Middleware) jsonrpc2.Handler {
+	for idx := len(mws) - 1; idx >= 0; idx-- {
+		handler = mws[idx](handler)
+	}
+
+	return handler
 }
@@ -4,15 +4,76 @@
 package protocol
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.lsp.dev/jsonrpc2"
 )
 
+// discardReply is a jsonrpc2.Replier that drops the reply.
+func discardReply(context.Context, any, error) error { return nil }
+
+// handleAndWait calls h with req and, if req is a call, waits until reply has
+// been called, since ServerHandler answers calls on their own goroutine.
+func handleAndWait(
+	t *testing.T,
+	h jsonrpc2.Handler,
+	reply jsonrpc2.Replier,
+	req jsonrpc2.Request,
+) error {
+	t.Helper()
+
+	replied := make(chan struct{})
+	err := h(context.Background(), func(ctx context.Context, result any, err error) error {
+		defer close(replied)
+		return reply(ctx, result, err)
+	}, req)
+
+	if _, ok := req.(*jsonrpc2.Call); ok {
+		select {
+		case <-replied:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no reply to", req.Method())
+		}
+	}
+
+	return err
+}
+
+// connPair connects a client jsonrpc2.Conn to a server conn running handler
+// over an in-memory pipe framed by framer, and closes both at cleanup.
+func connPair(t *testing.T, framer jsonrpc2.Framer, handler jsonrpc2.Handler) jsonrpc2.Conn {
+	t.Helper()
+
+	clientPipe, serverPipe := net.Pipe()
+
+	serverConn := jsonrpc2.NewConn(framer(serverPipe))
+	serverConn.Go(context.Background(), handler)
+
+	clientConn := jsonrpc2.NewConn(framer(clientPipe))
+	clientConn.Go(context.Background(), jsonrpc2.MethodNotFoundHandler)
+
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+		<-clientConn.Done()
+		<-serverConn.Done()
+	})
+
+	return clientConn
+}
+
 func TestServerHandlerNilLogger(t *testing.T) {
 	h := ServerHandler(&stubServer{}, nil)
 	require.NotNil(t, h)
@@ -39,7 +100,7 @@ func TestServerDispatchInitialize(t *testing.T) {
 		return nil
 	}
 
-	require.NoError(t, h(context.Background(), replier, req))
+	require.NoError(t, handleAndWait(t, h, replier, req))
 	assert.True(t, replied, "replier should have been called")
 	assert.True(t, srv.initializeCalled, "Initialize should have been called")
 	assert.NotNil(t, replyResult)
@@ -79,12 +140,12 @@ func TestServerDispatchHover(t *testing.T) {
 		return nil
 	}
 
-	require.NoError(t, h(context.Background(), replier, req))
+	require.NoError(t, handleAndWait(t, h, replier, req))
 	assert.True(t, srv.hoverCalled)
 
 	hover, ok := replyResult.(*Hover)
 	require.True(t, ok, "reply should be *Hover, got %T", replyResult)
-	assert.Equal(t, "hello", hover.Contents)
+	assert.Equal(t, NewMarkedStringHoverContents(markedText("hello")), hover.Contents)
 }
 
 func TestServerDispatchUnknownMethod(t *testing.T) {
@@ -103,7 +164,7 @@ func TestServerDispatchUnknownMethod(t *testing.T) {
 		return nil
 	}
 
-	require.NoError(t, h(context.Background(), replier, req))
+	require.NoError(t, handleAndWait(t, h, replier, req))
 	assert.True(t, replied)
 	assert.True(t, srv.requestCalled, "Request catch-all should have been called")
 	assert.Equal(t, "custom/method", srv.requestMethod)
@@ -120,8 +181,12 @@ func TestServerDispatchInvalidParams(t *testing.T) {
 		return nil
 	}
 
-	_ = h(context.Background(), replier, req)
+	_ = handleAndWait(t, h, replier, req)
 	assert.Error(t, replyErr, "should reply with parse error for invalid params")
+
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, replyErr, &rpcErr)
+	assert.Equal(t, jsonrpc2.Code(CodeInvalidParams), rpcErr.Code)
 }
 
 func TestServerDispatchShutdown(t *testing.T) {
@@ -136,7 +201,702 @@ func TestServerDispatchShutdown(t *testing.T) {
 		return nil
 	}
 
-	require.NoError(t, h(context.Background(), replier, req))
+	require.NoError(t, handleAndWait(t, h, replier, req))
 	assert.True(t, replied)
 	assert.True(t, srv.shutdownCalled)
 }
+
+func TestServerDispatchRegisteredCustomMethod(t *testing.T) {
+	type rustAnalyzerParams struct {
+		TextDocument TextDocumentIdentifier `json:"textDocument"`
+		Expand       bool                   `json:"expand"`
+	}
+
+	RegisterCustomMethod("$/rust-analyzer/expand", reflect.TypeFor[rustAnalyzerParams]())
+	t.Cleanup(func() {
+		customMethodsMu.Lock()
+		delete(customMethods, "$/rust-analyzer/expand")
+		customMethodsMu.Unlock()
+	})
+
+	srv := &stubServer{}
+	h := ServerHandler(srv, nil)
+
+	req, _ := jsonrpc2.NewCall(
+		jsonrpc2.NewNumberID(6),
+		"$/rust-analyzer/expand",
+		json.RawMessage(`{"textDocument":{"uri":"file:///lib.rs"},"expand":true}`),
+	)
+
+	nopReplier := func(ctx context.Context, result any, err error) error { return nil }
+	require.NoError(t, handleAndWait(t, h, nopReplier, req))
+	require.True(t, srv.requestCalled)
+
+	params, ok := srv.requestParams.(*rustAnalyzerParams)
+	require.True(t, ok, "params should be *rustAnalyzerParams, got %T", srv.requestParams)
+	assert.Equal(t, DocumentURI("file:///lib.rs"), params.TextDocument.URI)
+	assert.True(t, params.Expand)
+}
+
+// recordingLogger is a Logger recording the warnings logged through it.
+type recordingLogger struct {
+	nopLogger
+
+	warnings []string
+}
+
+func (l *recordingLogger) Warn(msg string, fields ...any) {
+	l.warnings = append(l.warnings, fmt.Sprint(append([]any{msg}, fields...)...))
+}
+
+func TestServerHandlerWarnCatchAll(t *testing.T) {
+	RegisterCustomMethod("$/custom/known", reflect.TypeFor[map[string]any]())
+	t.Cleanup(func() {
+		customMethodsMu.Lock()
+		delete(customMethods, "$/custom/known")
+		customMethodsMu.Unlock()
+	})
+
+	call := func(t *testing.T, h jsonrpc2.Handler, method string) {
+		t.Helper()
+
+		req, err := jsonrpc2.NewCall(jsonrpc2.NewNumberID(1), method, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		require.NoError(t, handleAndWait(t, h, discardReply, req))
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		logger := &recordingLogger{}
+		srv := &stubServer{}
+		h := ServerHandlerWithOptions(srv, logger, HandlerOptions{WarnCatchAll: true})
+
+		call(t, h, "custom/unknown")
+		assert.True(t, srv.requestCalled, "the catch-all must still be called")
+		call(t, h, "$/custom/known")
+		call(t, h, "shutdown")
+
+		require.Len(t, logger.warnings, 1)
+		assert.Contains(t, logger.warnings[0], "catch-all")
+		assert.Contains(t, logger.warnings[0], "custom/unknown")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		logger := &recordingLogger{}
+		h := ServerHandlerWithOptions(&stubServer{}, logger, HandlerOptions{})
+
+		call(t, h, "custom/unknown")
+		assert.Empty(t, logger.warnings)
+	})
+}
+
+func TestServerHandlerNormalizeNilSlices(t *testing.T) {
+	params := ReferenceParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///test.go"},
+		Position:     Position{Line: 1, Character: 5},
+	}
+	raw, _ := json.Marshal(params)
+	req, _ := jsonrpc2.NewCall(
+		jsonrpc2.NewNumberID(1), "textDocument/references", json.RawMessage(raw))
+
+	tests := []struct {
+		name string
+		opts HandlerOptions
+		want string
+	}{
+		{name: "disabled", opts: HandlerOptions{}, want: "null"},
+		{name: "enabled", opts: HandlerOptions{NormalizeNilSlices: true}, want: "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := ServerHandlerWithOptions(&stubServer{}, nil, tt.opts)
+
+			var got []byte
+			replier := func(ctx context.Context, result any, err error) error {
+				require.NoError(t, err)
+				got, err = json.Marshal(result)
+				return err
+			}
+
+			require.NoError(t, handleAndWait(t, h, replier, req))
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestServerHandlerForwardsJSONRPCErrors(t *testing.T) {
+	data := json.RawMessage(`{"retry":true}`)
+	custom := &jsonrpc2.Error{Code: -32099, Message: "index not ready", Data: &data}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode jsonrpc2.Code
+		wantMsg  string
+		wantData string
+	}{
+		{"direct", custom, -32099, "index not ready", `{"retry":true}`},
+		{"wrapped", fmt.Errorf("hover: %w", custom), -32099, "index not ready", `{"retry":true}`},
+		{"plain", errors.New("boom"), jsonrpc2.Code(CodeInternalError), "boom", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientPipe, serverPipe := net.Pipe()
+
+			serverConn := jsonrpc2.NewConn(jsonrpc2.NewStream(serverPipe))
+			serverConn.Go(context.Background(), ServerHandler(&stubServer{hoverErr: tt.err}, nil))
+
+			clientConn := jsonrpc2.NewConn(jsonrpc2.NewStream(clientPipe))
+			clientConn.Go(context.Background(), jsonrpc2.MethodNotFoundHandler)
+
+			t.Cleanup(func() {
+				_ = clientConn.Close()
+				_ = serverConn.Close()
+				<-clientConn.Done()
+				<-serverConn.Done()
+			})
+
+			var result any
+			_, err := clientConn.Call(
+				context.Background(), MethodTextDocumentHover, HoverParams{}, &result)
+
+			var rpcErr *jsonrpc2.Error
+			require.ErrorAs(t, err, &rpcErr)
+			assert.Equal(t, tt.wantCode, rpcErr.Code)
+			assert.Equal(t, tt.wantMsg, rpcErr.Message)
+
+			if tt.wantData == "" {
+				assert.Nil(t, rpcErr.Data)
+			} else {
+				require.NotNil(t, rpcErr.Data)
+				assert.JSONEq(t, tt.wantData, string(*rpcErr.Data))
+			}
+		})
+	}
+}
+
+func TestServerHandlerErrorCodes(t *testing.T) {
+	errIndexing := errors.New("indexing")
+
+	tests := []struct {
+		name     string
+		opts     HandlerOptions
+		err      error
+		wantCode int64
+	}{
+		{"cancelled", HandlerOptions{}, fmt.Errorf("%w", context.Canceled), CodeRequestCancelled},
+		{"deadline", HandlerOptions{}, context.DeadlineExceeded, CodeRequestCancelled},
+		{"unsupported", HandlerOptions{}, errors.ErrUnsupported, CodeMethodNotFound},
+		{"client unsupported", HandlerOptions{}, fmt.Errorf("hover: %w", ErrUnsupported),
+			CodeMethodNotFound},
+		{"own code wins", HandlerOptions{}, errors.Join(ErrContentModified, context.Canceled),
+			CodeContentModified},
+		{"unmapped", HandlerOptions{}, errIndexing, CodeInternalError},
+		{
+			"custom table",
+			HandlerOptions{ErrorCodes: []ErrorCodeMapping{{Err: errIndexing, Code: -32099}}},
+			fmt.Errorf("hover: %w", errIndexing),
+			-32099,
+		},
+		{
+			"custom table replaces defaults",
+			HandlerOptions{ErrorCodes: []ErrorCodeMapping{}},
+			context.Canceled,
+			CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := ServerHandlerWithOptions(&stubServer{hoverErr: tt.err}, nil, tt.opts)
+			req, _ := jsonrpc2.NewCall(
+				jsonrpc2.NewNumberID(1), MethodTextDocumentHover, json.RawMessage(`{}`))
+
+			var replyErr error
+			replier := func(ctx context.Context, result any, err error) error {
+				replyErr = err
+				return nil
+			}
+
+			require.NoError(t, handleAndWait(t, h, replier, req))
+
+			var rpcErr *jsonrpc2.Error
+			require.ErrorAs(t, replyErr, &rpcErr)
+			assert.Equal(t, jsonrpc2.Code(tt.wantCode), rpcErr.Code)
+		})
+	}
+}
+
+func TestServerHandlerNilResponseError(t *testing.T) {
+	var noErr *ResponseError
+
+	h := ServerHandler(&stubServer{hoverErr: noErr}, nil)
+	req, _ := jsonrpc2.NewCall(
+		jsonrpc2.NewNumberID(1), MethodTextDocumentHover, json.RawMessage(`{}`))
+
+	var replyErr error
+	replier := func(ctx context.Context, result any, err error) error {
+		replyErr = err
+		return nil
+	}
+
+	require.NoError(t, handleAndWait(t, h, replier, req))
+	assert.NoError(t, replyErr, "a typed nil *ResponseError is not an error")
+}
+
+// rawServer serves handler on a pipe framed by framer and returns the other
+// end, on which a test writes and reads the bytes a client would.
+func rawServer(t *testing.T, framer jsonrpc2.Framer, handler jsonrpc2.Handler) net.Conn {
+	t.Helper()
+
+	clientPipe, serverPipe := net.Pipe()
+	require.NoError(t, clientPipe.SetDeadline(time.Now().Add(5*time.Second)))
+
+	serverConn := jsonrpc2.NewConn(framer(serverPipe))
+	serverConn.Go(context.Background(), handler)
+
+	t.Cleanup(func() {
+		_ = clientPipe.Close()
+		_ = serverConn.Close()
+		<-serverConn.Done()
+	})
+
+	return clientPipe
+}
+
+// initializeResponse is the part of the reply to the initialize request
+// written by the framing tests that they check.
+type initializeResponse struct {
+	ID     int64 `json:"id"`
+	Result *struct {
+		Capabilities json.RawMessage `json:"capabilities"`
+	} `json:"result"`
+	Error json.RawMessage `json:"error"`
+}
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize",` +
+	`"params":{"processId":null}}`
+
+func TestServerHandlerContentLengthFraming(t *testing.T) {
+	pipe := rawServer(t, jsonrpc2.NewStream, ServerHandler(&stubServer{}, nil))
+
+	_, err := fmt.Fprintf(pipe, "Content-Length: %d\r\n\r\n%s",
+		len(initializeRequest), initializeRequest)
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(pipe)
+
+	header, err := reader.ReadString('\n')
+	require.NoError(t, err)
+
+	var length int
+	_, err = fmt.Sscanf(header, "Content-Length: %d\r\n", &length)
+	require.NoError(t, err, "header %q", header)
+
+	blank, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "\r\n", blank, "the header ends with an empty line")
+
+	body := make([]byte, length)
+	_, err = io.ReadFull(reader, body)
+	require.NoError(t, err)
+
+	var resp initializeResponse
+	require.NoError(t, json.Unmarshal(body, &resp), "the body is exactly one message: %s", body)
+	assert.Equal(t, int64(1), resp.ID)
+	require.NotNil(t, resp.Result, "reply: %s", body)
+	assert.NotEmpty(t, resp.Result.Capabilities)
+	assert.Empty(t, resp.Error)
+}
+
+func TestServerHandlerCustomFramer(t *testing.T) {
+	var framed atomic.Int32
+
+	// framer sends bare JSON values instead of Content-Length framed ones.
+	var framer jsonrpc2.Framer = func(rwc io.ReadWriteCloser) jsonrpc2.Stream {
+		framed.Add(1)
+		return jsonrpc2.NewRawStream(rwc)
+	}
+
+	pipe := rawServer(t, framer, ServerHandler(&stubServer{}, nil))
+
+	_, err := io.WriteString(pipe, initializeRequest)
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(pipe)
+
+	first, err := reader.Peek(1)
+	require.NoError(t, err)
+	assert.Equal(t, "{", string(first), "the reply has no header")
+
+	var raw json.RawMessage
+	require.NoError(t, json.NewDecoder(reader).Decode(&raw))
+
+	var resp initializeResponse
+	require.NoError(t, json.Unmarshal(raw, &resp))
+	assert.Equal(t, int64(1), resp.ID)
+	require.NotNil(t, resp.Result, "reply: %s", raw)
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, int32(1), framed.Load())
+}
+
+func TestServerHandlerCancelRequest(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan bool, 1)
+
+	srv := &stubServer{hoverHook: func(ctx context.Context) {
+		close(started)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !IsCancelled(ctx) && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		cancelled <- IsCancelled(ctx)
+	}}
+	h := ServerHandler(srv, nil)
+
+	call, _ := jsonrpc2.NewCall(
+		jsonrpc2.NewStringID("hover-1"), MethodTextDocumentHover, HoverParams{})
+	replied := make(chan error, 1)
+
+	go func() {
+		_ = h(context.Background(), func(_ context.Context, _ any, err error) error {
+			replied <- err
+			return nil
+		}, call)
+	}()
+
+	<-started
+
+	// Cancelling an unrelated id must not affect the call.
+	other, _ := jsonrpc2.NewNotification(MethodCancelRequest, numericCancel(42))
+	require.NoError(t, h(context.Background(), discardReply, other))
+
+	select {
+	case <-cancelled:
+		t.Fatal("hover observed cancellation of another request")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel, _ := jsonrpc2.NewNotification(MethodCancelRequest, stringCancel("hover-1"))
+	require.NoError(t, h(context.Background(), discardReply, cancel))
+
+	assert.True(t, <-cancelled, "hover should observe the cancellation")
+	assert.NoError(t, <-replied)
+	assert.False(t, IsCancelled(context.Background()))
+}
+
+func TestIsCancelledOverConn(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan bool, 1)
+
+	// Hover polls IsCancelled, as a long loop in a Server method would, and
+	// only returns once the client's $/cancelRequest has been read off the
+	// connection while it runs.
+	srv := &stubServer{hoverHook: func(ctx context.Context) {
+		close(started)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !IsCancelled(ctx) && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		cancelled <- IsCancelled(ctx)
+	}}
+	client := connPair(t, jsonrpc2.NewStream, ServerHandler(srv, nil))
+
+	done := make(chan error, 1)
+
+	go func() {
+		var hover Hover
+		_, err := client.Call(context.Background(), MethodTextDocumentHover, HoverParams{}, &hover)
+		done <- err
+	}()
+
+	<-started
+
+	// The first call of a connection gets the numeric id 1.
+	require.NoError(t, client.Notify(context.Background(), MethodCancelRequest, numericCancel(1)))
+
+	assert.True(t, <-cancelled, "hover should observe the cancellation through IsCancelled")
+	<-done
+}
+
+// slowHoverServer is a Server whose Hover blocks until its context is done.
+type slowHoverServer struct {
+	stubServer
+
+	started  chan struct{}
+	observed chan bool
+}
+
+func (s *slowHoverServer) Hover(ctx context.Context, _ *HoverParams) (*Hover, error) {
+	close(s.started)
+
+	select {
+	case <-ctx.Done():
+		s.observed <- true
+		return nil, ctx.Err()
+	case <-time.After(5 * time.Second):
+		s.observed <- false
+		return nil, nil
+	}
+}
+
+func TestServerHandlerCancelRequestOverConn(t *testing.T) {
+	srv := &slowHoverServer{started: make(chan struct{}), observed: make(chan bool, 1)}
+	clientConn := connPair(t, jsonrpc2.NewStream, ServerHandler(srv, nil))
+
+	type result struct {
+		id  jsonrpc2.ID
+		err error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		var hover Hover
+		id, err := clientConn.Call(
+			context.Background(), MethodTextDocumentHover, HoverParams{}, &hover)
+		done <- result{id: id, err: err}
+	}()
+
+	<-srv.started
+
+	// The first call of a connection gets the numeric id 1.
+	cancel := numericCancel(1)
+	require.NoError(t, clientConn.Notify(context.Background(), MethodCancelRequest, cancel))
+
+	assert.True(t, <-srv.observed, "hover should observe ctx.Done()")
+
+	res := <-done
+	assert.Equal(t, jsonrpc2.NewNumberID(1), res.id)
+
+	code, ok := CodeOf(res.err)
+	require.True(t, ok, "got %v", res.err)
+	assert.Equal(t, CodeRequestCancelled, code)
+}
+
+// gatedServer is a stubServer whose Hover runs hover, which may block.
+type gatedServer struct {
+	stubServer
+
+	hover func(ctx context.Context)
+}
+
+func (s *gatedServer) Hover(ctx context.Context, _ *HoverParams) (*Hover, error) {
+	s.hover(ctx)
+	return &Hover{Contents: NewMarkedStringHoverContents(markedText("gated"))}, nil
+}
+
+func TestServerHandlerMaxConcurrency(t *testing.T) {
+	const requests = 7
+
+	var running, peak atomic.Int32
+
+	started := make(chan struct{}, requests)
+	release := make(chan struct{})
+
+	srv := &gatedServer{hover: func(context.Context) {
+		n := running.Add(1)
+		for cur := peak.Load(); n > cur && !peak.CompareAndSwap(cur, n); cur = peak.Load() {
+		}
+
+		started <- struct{}{}
+		<-release
+		running.Add(-1)
+	}}
+	h := ServerHandlerWithOptions(srv, nil, HandlerOptions{MaxConcurrency: 2, MaxQueued: 3})
+
+	replies := make(chan error, requests)
+	replier := func(_ context.Context, _ any, err error) error {
+		replies <- err
+		return nil
+	}
+
+	for i := range requests {
+		call, _ := jsonrpc2.NewCall(
+			jsonrpc2.NewNumberID(int32(i)), MethodTextDocumentHover, HoverParams{})
+		require.NoError(t, h(context.Background(), replier, call),
+			"requests must not block the read loop")
+	}
+
+	// Two running and three queued leave two requests to be rejected.
+	for range 2 {
+		var rpcErr *jsonrpc2.Error
+		require.ErrorAs(t, <-replies, &rpcErr)
+		assert.Equal(t, jsonrpc2.Code(CodeInternalError), rpcErr.Code)
+		assert.Contains(t, rpcErr.Message, "server busy")
+	}
+
+	<-started
+	<-started
+
+	select {
+	case <-started:
+		t.Fatal("more than MaxConcurrency requests running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Notifications are not held back by the full queue.
+	open, _ := jsonrpc2.NewNotification(MethodTextDocumentDidOpen, DidOpenTextDocumentParams{})
+	require.NoError(t, h(context.Background(), replier, open))
+	assert.True(t, srv.didOpenCalled)
+
+	close(release)
+
+	for range requests - 2 {
+		assert.NoError(t, <-replies)
+	}
+
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestServerHandlerMaxConcurrencyNoQueue(t *testing.T) {
+	release := make(chan struct{})
+	srv := &gatedServer{hover: func(context.Context) { <-release }}
+	h := ServerHandlerWithOptions(srv, nil, HandlerOptions{MaxConcurrency: 1})
+
+	replies := make(chan error, 3)
+	replier := func(_ context.Context, _ any, err error) error {
+		replies <- err
+		return nil
+	}
+
+	for i := range 2 {
+		call, _ := jsonrpc2.NewCall(
+			jsonrpc2.NewNumberID(int32(i)), MethodTextDocumentHover, HoverParams{})
+		require.NoError(t, h(context.Background(), replier, call))
+	}
+
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, <-replies, &rpcErr, "no request may wait without MaxQueued")
+	assert.Contains(t, rpcErr.Message, "server busy")
+
+	close(release)
+	require.NoError(t, <-replies)
+
+	// The slot is freed once the first request returns, and admits the next.
+	id := int32(1)
+	require.Eventually(t, func() bool {
+		id++
+		call, _ := jsonrpc2.NewCall(jsonrpc2.NewNumberID(id), MethodTextDocumentHover, HoverParams{})
+
+		return handleAndWait(t, h, replier, call) == nil && <-replies == nil
+	}, time.Second, time.Millisecond)
+}
+
+func TestServerHandlerMaxConcurrencyCancelQueued(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	srv := &gatedServer{hover: func(context.Context) {
+		close(started)
+		<-release
+	}}
+	h := ServerHandlerWithOptions(srv, nil, HandlerOptions{MaxConcurrency: 1, MaxQueued: 1})
+
+	replies := map[string]chan error{"running": make(chan error, 1), "queued": make(chan error, 1)}
+	for _, id := range []string{"running", "queued"} {
+		call, _ := jsonrpc2.NewCall(
+			jsonrpc2.NewStringID(id), MethodTextDocumentHover, HoverParams{})
+		require.NoError(t, h(context.Background(), func(_ context.Context, _ any, err error) error {
+			replies[id] <- err
+			return nil
+		}, call))
+	}
+
+	<-started
+
+	cancel, _ := jsonrpc2.NewNotification(MethodCancelRequest, stringCancel("queued"))
+	require.NoError(t, h(context.Background(), discardReply, cancel))
+
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, <-replies["queued"], &rpcErr)
+	assert.Equal(t, jsonrpc2.Code(CodeRequestCancelled), rpcErr.Code)
+
+	close(release)
+	assert.NoError(t, <-replies["running"])
+}
+
+func TestServerHandlerDuplicateID(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	srv := &stubServer{hoverHook: func(context.Context) {
+		close(started)
+		<-release
+	}}
+	logger := &recordingLogger{}
+	h := ServerHandler(srv, logger)
+
+	first := make(chan error, 1)
+	hover, _ := jsonrpc2.NewCall(jsonrpc2.NewNumberID(1), MethodTextDocumentHover, HoverParams{})
+	require.NoError(t, h(context.Background(), func(_ context.Context, _ any, err error) error {
+		first <- err
+		return nil
+	}, hover))
+
+	<-started
+
+	var dupErr error
+
+	dup, _ := jsonrpc2.NewCall(jsonrpc2.NewNumberID(1), MethodShutdown, nil)
+	require.NoError(t, h(context.Background(), func(_ context.Context, _ any, err error) error {
+		dupErr = err
+		return nil
+	}, dup))
+
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, dupErr, &rpcErr, "the duplicate is rejected on the read loop")
+	assert.Equal(t, jsonrpc2.Code(CodeInvalidRequest), rpcErr.Code)
+	require.Len(t, logger.warnings, 1)
+	assert.Contains(t, logger.warnings[0], "already in use")
+	assert.False(t, srv.shutdownCalled)
+
+	close(release)
+	require.NoError(t, <-first)
+
+	// Once the first call is answered, the id may be used again.
+	require.NoError(t, handleAndWait(t, h, discardReply, dup))
+	assert.True(t, srv.shutdownCalled)
+}
+
+func TestWithMiddlewareOrder(t *testing.T) {
+	var order []string
+
+	mw := func(name string) Middleware {
+		return func(next jsonrpc2.Handler) jsonrpc2.Handler {
+			return func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+				order = append(order, name+" in")
+				err := next(ctx, reply, req)
+				order = append(order, name+" out")
+				return err
+			}
+		}
+	}
+
+	h := WithMiddleware(ServerHandler(&stubServer{}, nil), mw("outer"), mw("inner"))
+
+	req, _ := jsonrpc2.NewCall(jsonrpc2.NewNumberID(1), MethodShutdown, nil)
+	require.NoError(t, h(context.Background(), discardReply, req))
+	assert.Equal(t, []string{"outer in", "inner in", "inner out", "outer out"}, order)
+}
+
+// numericCancel returns the $/cancelRequest params for the numeric id.
+func numericCancel(id int32) CancelParams {
+	var params CancelParams
+	params.ID.SetInt32(id)
+
+	return params
+}
+
+// stringCancel returns the $/cancelRequest params for the string id.
+func stringCancel(id string) CancelParams {
+	var params CancelParams
+	params.ID.SetString(id)
+
+	return params
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides HoverContents, the type of Hover.Contents. The
+// specification defines it as `MarkupContent | MarkedString | MarkedString[]`;
+// rather than leaving it as `any`, the generator emits this hand-written type,
+// which decodes each form into typed values.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// HoverContents is the content of a Hover: a MarkupContent, a single
+// MarkedString, or a list of MarkedStrings. MarkedString is deprecated in
+// favour of MarkupContent, but older clients and servers still use it.
+//
+// A MarkedString holds either a string (Markdown) or a
+// MarkedStringWithLanguage (a code block).
+//
+// The zero HoverContents is encoded as empty plain text.
+type HoverContents struct {
+	// value is a MarkupContent, MarkedString or []MarkedString, or nil for
+	// the zero value.
+	value any
+}
+
+// NewMarkupHoverContents returns HoverContents holding content.
+func NewMarkupHoverContents(content MarkupContent) HoverContents {
+	return HoverContents{value: content}
+}
+
+// NewMarkedStringHoverContents returns HoverContents holding the single
+// MarkedString s.
+func NewMarkedStringHoverContents(s MarkedString) HoverContents {
+	return HoverContents{value: s}
+}
+
+// NewMarkedStringsHoverContents returns HoverContents holding a list of
+// MarkedStrings. The list is sent as an array even if it has a single
+// element.
+func NewMarkedStringsHoverContents(items ...MarkedString) HoverContents {
+	if items == nil {
+		items = []MarkedString{}
+	}
+
+	return HoverContents{value: items}
+}
+
+// AsMarkupContent returns the MarkupContent held by c. The boolean is false
+// if c holds MarkedStrings. The zero HoverContents holds empty plain text.
+func (c HoverContents) AsMarkupContent() (MarkupContent, bool) {
+	switch v := c.value.(type) {
+	case nil:
+		return MarkupContent{Kind: MarkupKindPlainText, Value: ""}, true
+	case MarkupContent:
+		return v, true
+	default:
+		return MarkupContent{}, false //nolint:exhaustruct
+	}
+}
+
+// AsMarkedString returns the single MarkedString held by c. The boolean is
+// false if c holds a MarkupContent or a list.
+func (c HoverContents) AsMarkedString() (MarkedString, bool) {
+	s, ok := c.value.(MarkedString)
+	return s, ok
+}
+
+// AsMarkedStrings returns the list of MarkedStrings held by c. The boolean
+// is false if c holds a MarkupContent or a single MarkedString.
+func (c HoverContents) AsMarkedStrings() ([]MarkedString, bool) {
+	items, ok := c.value.([]MarkedString)
+	return items, ok
+}
+
+// MarshalJSON encodes the form held by c.
+func (c HoverContents) MarshalJSON() ([]byte, error) {
+	if c.value == nil {
+		return json.Marshal(MarkupContent{Kind: MarkupKindPlainText, Value: ""}) //nolint:wrapcheck
+	}
+
+	return json.Marshal(c.value) //nolint:wrapcheck
+}
+
+// UnmarshalJSON decodes a MarkupContent, a MarkedString or a list of
+// MarkedStrings. null decodes as the zero HoverContents.
+func (c *HoverContents) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		c.value = nil
+	case bytes.HasPrefix(data, []byte("[")):
+		var items []MarkedString
+		if err := json.Unmarshal(data, &items); err != nil { //nolint:noinlineerr
+			return fmt.Errorf("hover contents: %w", err)
+		}
+
+		c.value = items
+	default:
+		var probe struct {
+			Kind *MarkupKind `json:"kind"`
+		}
+
+		if bytes.HasPrefix(data, []byte("{")) {
+			if err := json.Unmarshal(data, &probe); err != nil { //nolint:noinlineerr
+				return fmt.Errorf("hover contents: %w", err)
+			}
+		}
+
+		if probe.Kind != nil {
+			var content MarkupContent
+			if err := json.Unmarshal(data, &content); err != nil { //nolint:noinlineerr
+				return fmt.Errorf("hover contents: %w", err)
+			}
+
+			c.value = content
+
+			return nil
+		}
+
+		var s MarkedString
+		if err := json.Unmarshal(data, &s); err != nil { //nolint:noinlineerr
+			return fmt.Errorf("hover contents: %w", err)
+		}
+
+		c.value = s
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// markedText returns a MarkedString holding the Markdown text md.
+func markedText(md string) MarkedString {
+	var s MarkedString
+	s.SetString(md)
+
+	return s
+}
+
+// markedCode returns a MarkedString holding a code block in language.
+func markedCode(language, code string) MarkedString {
+	var s MarkedString
+	s.SetMarkedStringWithLanguage(MarkedStringWithLanguage{Language: language, Value: code})
+
+	return s
+}
+
+func TestHoverContentsJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents HoverContents
+		json     string
+	}{
+		{
+			name: "markup",
+			contents: NewMarkupHoverContents(
+				MarkupContent{Kind: MarkupKindMarkdown, Value: "**x**"}),
+			json: `{"kind": "markdown", "value": "**x**"}`,
+		},
+		{
+			name:     "marked string",
+			contents: NewMarkedStringHoverContents(markedText("`x`")),
+			json:     `"` + "`x`" + `"`,
+		},
+		{
+			name:     "code block",
+			contents: NewMarkedStringHoverContents(markedCode("go", "var x int")),
+			json:     `{"language": "go", "value": "var x int"}`,
+		},
+		{
+			name: "list",
+			contents: NewMarkedStringsHoverContents(
+				markedCode("go", "var x int"),
+				markedText("x counts things."),
+			),
+			json: `[{"language": "go", "value": "var x int"}, "x counts things."]`,
+		},
+		{
+			name:     "single element list",
+			contents: NewMarkedStringsHoverContents(markedText("x")),
+			json:     `["x"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.contents)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(data))
+
+			var got HoverContents
+			require.NoError(t, json.Unmarshal(data, &got))
+			assert.Equal(t, tt.contents, got)
+		})
+	}
+}
+
+func TestHoverContentsAccessors(t *testing.T) {
+	markup := MarkupContent{Kind: MarkupKindMarkdown, Value: "**x**"}
+
+	content, ok := NewMarkupHoverContents(markup).AsMarkupContent()
+	assert.True(t, ok)
+	assert.Equal(t, markup, content)
+
+	_, ok = NewMarkupHoverContents(markup).AsMarkedString()
+	assert.False(t, ok)
+
+	s, ok := NewMarkedStringHoverContents(markedText("x")).AsMarkedString()
+	assert.True(t, ok)
+	assert.Equal(t, markedText("x"), s)
+
+	_, ok = NewMarkedStringHoverContents(markedText("x")).AsMarkedStrings()
+	assert.False(t, ok)
+
+	items, ok := NewMarkedStringsHoverContents(markedText("a"), markedText("b")).AsMarkedStrings()
+	assert.True(t, ok)
+	assert.Equal(t, []MarkedString{markedText("a"), markedText("b")}, items)
+
+	_, ok = NewMarkedStringsHoverContents(markedText("a")).AsMarkupContent()
+	assert.False(t, ok)
+}
+
+func TestHoverContentsZero(t *testing.T) {
+	var zero HoverContents
+
+	content, ok := zero.AsMarkupContent()
+	assert.True(t, ok)
+	assert.Equal(t, MarkupContent{Kind: MarkupKindPlainText, Value: ""}, content)
+
+	data, err := json.Marshal(Hover{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contents": {"kind": "plaintext", "value": ""}}`, string(data))
+
+	var hover Hover
+	require.NoError(t, json.Unmarshal([]byte(`{"contents": null}`), &hover))
+	assert.Equal(t, zero, hover.Contents)
+}
+
+func TestHoverContentsUnmarshalErrors(t *testing.T) {
+	for _, input := range []string{`42`, `{"value": "x"}`, `[1]`, `["x", {"kind": 1}]`} {
+		var got HoverContents
+		assert.Error(t, json.Unmarshal([]byte(input), &got), input)
+	}
+}
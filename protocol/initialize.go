@@ -0,0 +1,199 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides nil-safe accessors for the optional fields of the
+// "initialize" request, and ServerInfo constructors and a builder for its
+// result.
+
+import "runtime/debug"
+
+// ClientName returns the client's self-reported name, or "" if the client
+// did not send clientInfo.
+func (p *InitializeParams) ClientName() string {
+	if p == nil || p.ClientInfo == nil {
+		return ""
+	}
+
+	return p.ClientInfo.Name
+}
+
+// ClientVersion returns the client's self-reported version, or "" if the
+// client did not send one.
+func (p *InitializeParams) ClientVersion() string {
+	if p == nil || p.ClientInfo == nil || p.ClientInfo.Version == nil {
+		return ""
+	}
+
+	return *p.ClientInfo.Version
+}
+
+// InitialTrace returns the trace setting requested by the client, defaulting
+// to TraceValueOff as the specification prescribes when it is absent. The
+// client may change it later with $/setTrace.
+func (p *InitializeParams) InitialTrace() TraceValue {
+	if p == nil || p.Trace == nil || *p.Trace == "" {
+		return TraceValueOff
+	}
+
+	return *p.Trace
+}
+
+// NewServerInfo returns a ServerInfo naming the server. version is omitted
+// from the result when empty.
+func NewServerInfo(name, version string) *ServerInfo {
+	info := &ServerInfo{Name: name} //nolint:exhaustruct
+	if version != "" {
+		info.Version = &version
+	}
+
+	return info
+}
+
+// NewServerInfoFromBuild is like NewServerInfo, but when version is empty it
+// is taken from the binary's build information: the main module version if
+// the binary was built from a tagged module (e.g. with go install), otherwise
+// the VCS revision stamped by go build, shortened to 12 characters and
+// suffixed with "-dirty" for uncommitted changes. The version is omitted if
+// neither is available.
+func NewServerInfoFromBuild(name, version string) *ServerInfo {
+	info, _ := debug.ReadBuildInfo()
+	return serverInfoFromBuild(name, version, info)
+}
+
+func serverInfoFromBuild(name, version string, info *debug.BuildInfo) *ServerInfo {
+	if version != "" || info == nil {
+		return NewServerInfo(name, version)
+	}
+
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		return NewServerInfo(name, v)
+	}
+
+	var revision, modified string
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		}
+	}
+
+	if len(revision) > 12 { //nolint:mnd
+		revision = revision[:12]
+	}
+
+	if revision != "" && modified == "true" {
+		revision += "-dirty"
+	}
+
+	return NewServerInfo(name, revision)
+}
+
+// InitializeResultBuilder assembles the InitializeResult a server answers
+// "initialize" with, enabling features one call at a time on top of
+// DefaultServerCapabilities:
+//
+//	result := protocol.NewInitializeResultBuilder(params.Capabilities).
+//		ServerName("mylsp").
+//		ServerVersion(version).
+//		EnableHover().
+//		EnableCompletion(".").
+//		EnableDefinition().
+//		Build()
+type InitializeResultBuilder struct {
+	caps    ServerCapabilities
+	name    string
+	version string
+}
+
+// NewInitializeResultBuilder returns a builder starting from
+// DefaultServerCapabilities(caps).
+func NewInitializeResultBuilder(caps *ClientCapabilities) *InitializeResultBuilder {
+	return &InitializeResultBuilder{caps: DefaultServerCapabilities(caps)} //nolint:exhaustruct
+}
+
+// ServerName sets the name reported in serverInfo. Without a name the result
+// has no serverInfo.
+func (b *InitializeResultBuilder) ServerName(name string) *InitializeResultBuilder {
+	b.name = name
+	return b
+}
+
+// ServerVersion sets the version reported in serverInfo.
+func (b *InitializeResultBuilder) ServerVersion(version string) *InitializeResultBuilder {
+	b.version = version
+	return b
+}
+
+// EnableHover advertises textDocument/hover.
+func (b *InitializeResultBuilder) EnableHover() *InitializeResultBuilder {
+	b.caps.SetHoverProvider(true)
+	return b
+}
+
+// EnableCompletion advertises textDocument/completion, triggered
+// automatically on the given characters (see NewCompletionOptions).
+func (b *InitializeResultBuilder) EnableCompletion(triggers ...string) *InitializeResultBuilder {
+	b.caps.CompletionProvider = new(NewCompletionOptions(triggers...))
+	return b
+}
+
+// EnableSignatureHelp advertises textDocument/signatureHelp, opened by typing
+// one of triggers (see NewSignatureHelpOptions).
+func (b *InitializeResultBuilder) EnableSignatureHelp(triggers ...string) *InitializeResultBuilder {
+	b.caps.EnableSignatureHelp(NewSignatureHelpOptions(triggers, nil))
+	return b
+}
+
+// EnableDefinition advertises textDocument/definition.
+func (b *InitializeResultBuilder) EnableDefinition() *InitializeResultBuilder {
+	b.caps.SetDefinitionProvider(true)
+	return b
+}
+
+// EnableReferences advertises textDocument/references.
+func (b *InitializeResultBuilder) EnableReferences() *InitializeResultBuilder {
+	b.caps.SetReferencesProvider(true)
+	return b
+}
+
+// EnableDocumentSymbol advertises textDocument/documentSymbol.
+func (b *InitializeResultBuilder) EnableDocumentSymbol() *InitializeResultBuilder {
+	b.caps.SetDocumentSymbolProvider(true)
+	return b
+}
+
+// EnableFormatting advertises textDocument/formatting.
+func (b *InitializeResultBuilder) EnableFormatting() *InitializeResultBuilder {
+	b.caps.SetDocumentFormattingProvider(true)
+	return b
+}
+
+// EnableRename advertises textDocument/rename.
+func (b *InitializeResultBuilder) EnableRename() *InitializeResultBuilder {
+	b.caps.SetRenameProvider(true)
+	return b
+}
+
+// Capabilities calls configure with the capabilities built so far, for
+// features the builder has no method for.
+func (b *InitializeResultBuilder) Capabilities(
+	configure func(*ServerCapabilities),
+) *InitializeResultBuilder {
+	configure(&b.caps)
+	return b
+}
+
+// Build returns the assembled InitializeResult.
+func (b *InitializeResultBuilder) Build() *InitializeResult {
+	result := &InitializeResult{Capabilities: b.caps} //nolint:exhaustruct
+	if b.name != "" {
+		result.ServerInfo = NewServerInfo(b.name, b.version)
+	}
+
+	return result
+}
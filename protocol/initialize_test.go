@@ -0,0 +1,158 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitializeParamsAccessors(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantName    string
+		wantVersion string
+		wantTrace   TraceValue
+	}{
+		{
+			name:        "populated",
+			json:        `{"processId": 1, "rootUri": null, "capabilities": {}, "clientInfo": {"name": "Visual Studio Code", "version": "1.95.0"}, "trace": "verbose"}`,
+			wantName:    "Visual Studio Code",
+			wantVersion: "1.95.0",
+			wantTrace:   TraceValueVerbose,
+		},
+		{
+			name:      "name without version",
+			json:      `{"processId": 1, "rootUri": null, "capabilities": {}, "clientInfo": {"name": "neovim"}}`,
+			wantName:  "neovim",
+			wantTrace: TraceValueOff,
+		},
+		{
+			name:      "absent",
+			json:      `{"processId": null, "rootUri": null, "capabilities": {}}`,
+			wantTrace: TraceValueOff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params InitializeParams
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &params))
+
+			assert.Equal(t, tt.wantName, params.ClientName())
+			assert.Equal(t, tt.wantVersion, params.ClientVersion())
+			assert.Equal(t, tt.wantTrace, params.InitialTrace())
+		})
+	}
+
+	var nilParams *InitializeParams
+	assert.Empty(t, nilParams.ClientName())
+	assert.Empty(t, nilParams.ClientVersion())
+	assert.Equal(t, TraceValueOff, nilParams.InitialTrace())
+}
+
+func TestNewServerInfo(t *testing.T) {
+	data, err := json.Marshal(NewServerInfo("my-server", "1.2.3"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "my-server", "version": "1.2.3"}`, string(data))
+
+	data, err = json.Marshal(NewServerInfo("my-server", ""))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "my-server"}`, string(data))
+}
+
+func TestServerInfoFromBuild(t *testing.T) {
+	stamped := func(version string, settings ...debug.BuildSetting) *debug.BuildInfo {
+		return &debug.BuildInfo{
+			Main:     debug.Module{Path: "example.com/server", Version: version},
+			Settings: settings,
+		}
+	}
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	revision := debug.BuildSetting{Key: "vcs.revision", Value: sha}
+	clean := debug.BuildSetting{Key: "vcs.modified", Value: "false"}
+	dirty := debug.BuildSetting{Key: "vcs.modified", Value: "true"}
+
+	tests := []struct {
+		name    string
+		version string
+		info    *debug.BuildInfo
+		want    *string
+	}{
+		{"explicit version wins", "2.0.0", stamped("v1.4.0", revision), new("2.0.0")},
+		{"module version", "", stamped("v1.4.0", revision), new("v1.4.0")},
+		{"vcs revision", "", stamped("(devel)", revision, clean), new("0123456789ab")},
+		{"dirty vcs revision", "", stamped("(devel)", revision, dirty), new("0123456789ab-dirty")},
+		{"no vcs stamp", "", stamped("(devel)"), nil},
+		{"no build info", "", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := serverInfoFromBuild("my-server", tt.version, tt.info)
+			assert.Equal(t, "my-server", info.Name)
+			assert.Equal(t, tt.want, info.Version)
+		})
+	}
+
+	assert.Equal(t, "my-server", NewServerInfoFromBuild("my-server", "").Name)
+}
+
+func TestInitializeResultBuilder(t *testing.T) {
+	result := NewInitializeResultBuilder(nil).
+		ServerName("mylsp").
+		ServerVersion("1.2.0").
+		EnableHover().
+		EnableCompletion(".", ":").
+		Build()
+
+	require.NotNil(t, result.ServerInfo)
+	assert.Equal(t, "mylsp", result.ServerInfo.Name)
+	require.NotNil(t, result.ServerInfo.Version)
+	assert.Equal(t, "1.2.0", *result.ServerInfo.Version)
+
+	caps := result.Capabilities
+	assert.True(t, caps.HoverEnabled())
+	require.NotNil(t, caps.CompletionProvider)
+	assert.Equal(t, []string{".", ":"}, caps.CompletionProvider.TriggerCharacters)
+	assert.False(t, caps.DefinitionEnabled())
+	assert.Nil(t, caps.SignatureHelpProvider)
+	assert.NotNil(t, caps.TextDocumentSync, "starts from DefaultServerCapabilities")
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"capabilities": {
+			"positionEncoding": "utf-16",
+			"textDocumentSync": {"openClose": true, "change": 1},
+			"hoverProvider": true,
+			"completionProvider": {"triggerCharacters": [".", ":"]}
+		},
+		"serverInfo": {"name": "mylsp", "version": "1.2.0"}
+	}`, string(data))
+}
+
+func TestInitializeResultBuilderFeatures(t *testing.T) {
+	result := NewInitializeResultBuilder(nil).
+		EnableDefinition().
+		EnableReferences().
+		EnableSignatureHelp("(").
+		Capabilities(func(sc *ServerCapabilities) { sc.SetCodeActionProvider(true) }).
+		Build()
+
+	assert.Nil(t, result.ServerInfo, "no serverInfo without a name")
+
+	caps := result.Capabilities
+	assert.True(t, caps.DefinitionEnabled())
+	assert.True(t, caps.ReferencesEnabled())
+	assert.True(t, caps.CodeActionEnabled())
+	assert.False(t, caps.HoverEnabled())
+	require.NotNil(t, caps.SignatureHelpProvider)
+	assert.Equal(t, []string{"("}, caps.SignatureHelpProvider.TriggerCharacters)
+}
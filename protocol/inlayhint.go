@@ -0,0 +1,30 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides fluent helpers for decorating InlayHint values.
+
+// WithPadding returns a copy of h with padding before (left) and after
+// (right) the hint. Padding that is not requested is omitted from the wire,
+// which clients treat as false.
+func (h InlayHint) WithPadding(left, right bool) InlayHint {
+	h.PaddingLeft, h.PaddingRight = nil, nil
+
+	if left {
+		h.PaddingLeft = new(true)
+	}
+
+	if right {
+		h.PaddingRight = new(true)
+	}
+
+	return h
+}
+
+// WithMarkdownTooltip returns a copy of h whose tooltip is md rendered as
+// Markdown.
+func (h InlayHint) WithMarkdownTooltip(md string) InlayHint {
+	h.Tooltip = newMarkdownContent(md)
+	return h
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlayHintBuilders(t *testing.T) {
+	hint := InlayHint{
+		Position: Position{Line: 3, Character: 9},
+		Kind:     new(InlayHintKindType),
+	}
+	hint.Label.SetString("int")
+
+	t.Run("padding and tooltip", func(t *testing.T) {
+		data, err := json.Marshal(hint.WithPadding(true, false).WithMarkdownTooltip("`x` is an `int`"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"position": {"line": 3, "character": 9},
+			"label": "int",
+			"kind": 1,
+			"paddingLeft": true,
+			"tooltip": {"kind": "markdown", "value": "`+"`x` is an `int`"+`"}
+		}`, string(data))
+	})
+
+	t.Run("padding can be cleared", func(t *testing.T) {
+		padded := hint.WithPadding(true, true)
+		assert.True(t, *padded.PaddingLeft)
+		assert.True(t, *padded.PaddingRight)
+
+		cleared := padded.WithPadding(false, false)
+		assert.Nil(t, cleared.PaddingLeft)
+		assert.Nil(t, cleared.PaddingRight)
+		assert.Nil(t, hint.PaddingLeft, "the receiver is not modified")
+	})
+}
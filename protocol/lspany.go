@@ -0,0 +1,104 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for LSPAny trees, the map[string]any / []any /
+// scalar values carried by configuration, command arguments and other
+// untyped payloads.
+
+import (
+	"maps"
+	"reflect"
+)
+
+// LSPAnyEqual reports whether a and b are structurally equal LSPAny trees.
+// Maps are compared key by key, slices element by element, and numbers by
+// value regardless of their Go type, so float64(1) from decoded JSON equals
+// an int 1 built in Go.
+func LSPAnyEqual(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for key, av := range a {
+			bv, ok := b[key]
+			if !ok || !LSPAnyEqual(av, bv) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for idx := range a {
+			if !LSPAnyEqual(a[idx], b[idx]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if af, ok := lspAnyNumber(a); ok {
+		bf, ok := lspAnyNumber(b)
+		return ok && af == bf
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// MergeLSPAny layers override on top of base and returns the result. Maps
+// are merged recursively; anything else in override, including slices,
+// replaces the corresponding value in base. A nil override (or a nil map
+// entry) keeps the base value. Neither input is modified, but the result may
+// share unmodified subtrees with them.
+//
+//	cfg := protocol.MergeLSPAny(defaults, userSettings)
+func MergeLSPAny(base, override any) any {
+	if override == nil {
+		return base
+	}
+
+	baseMap, ok := base.(map[string]any)
+	if !ok {
+		return override
+	}
+
+	overrideMap, ok := override.(map[string]any)
+	if !ok {
+		return override
+	}
+
+	merged := make(map[string]any, len(baseMap)+len(overrideMap))
+	maps.Copy(merged, baseMap)
+
+	for key, value := range overrideMap {
+		merged[key] = MergeLSPAny(baseMap[key], value)
+	}
+
+	return merged
+}
+
+// lspAnyNumber returns v as a float64 if it has a numeric Go type.
+func lspAnyNumber(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
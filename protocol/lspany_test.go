@@ -0,0 +1,86 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLSPAny decodes JSON into an LSPAny tree, as the dispatcher would.
+func decodeLSPAny(t *testing.T, data string) any {
+	t.Helper()
+
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(data), &v))
+
+	return v
+}
+
+func TestLSPAnyEqual(t *testing.T) {
+	decoded := decodeLSPAny(t, `{"gopls": {"hints": {"parameterNames": true}, "buildFlags": ["-tags", "e2e"], "depth": 3}}`)
+	built := map[string]any{
+		"gopls": map[string]any{
+			"depth":      3,
+			"buildFlags": []any{"-tags", "e2e"},
+			"hints":      map[string]any{"parameterNames": true},
+		},
+	}
+
+	assert.True(t, LSPAnyEqual(decoded, built))
+	assert.True(t, LSPAnyEqual(nil, nil))
+	assert.True(t, LSPAnyEqual(uint32(7), 7.0))
+
+	assert.False(t, LSPAnyEqual(decoded, decodeLSPAny(t, `{"gopls": {}}`)))
+	assert.False(t, LSPAnyEqual([]any{"a", "b"}, []any{"b", "a"}))
+	assert.False(t, LSPAnyEqual(map[string]any{"a": nil}, map[string]any{"b": nil}))
+	assert.False(t, LSPAnyEqual("1", 1))
+	assert.False(t, LSPAnyEqual(nil, map[string]any{}))
+}
+
+func TestMergeLSPAny(t *testing.T) {
+	base := decodeLSPAny(t, `{
+		"gopls": {
+			"hints": {"parameterNames": false, "rangeVariableTypes": true},
+			"buildFlags": ["-tags", "dev"],
+			"verbose": false
+		},
+		"format": "gofmt"
+	}`)
+	override := decodeLSPAny(t, `{
+		"gopls": {
+			"hints": {"parameterNames": true},
+			"buildFlags": ["-race"],
+			"verbose": null
+		},
+		"lint": true
+	}`)
+
+	got := MergeLSPAny(base, override)
+
+	want := decodeLSPAny(t, `{
+		"gopls": {
+			"hints": {"parameterNames": true, "rangeVariableTypes": true},
+			"buildFlags": ["-race"],
+			"verbose": false
+		},
+		"format": "gofmt",
+		"lint": true
+	}`)
+	assert.True(t, LSPAnyEqual(want, got), "got %v", got)
+
+	// Inputs are left untouched.
+	assert.Equal(t, false, base.(map[string]any)["gopls"].(map[string]any)["hints"].(map[string]any)["parameterNames"])
+
+	t.Run("scalars and slices replace", func(t *testing.T) {
+		assert.Equal(t, "b", MergeLSPAny("a", "b"))
+		assert.Equal(t, []any{3.0}, MergeLSPAny([]any{1.0, 2.0}, []any{3.0}))
+		assert.Equal(t, "x", MergeLSPAny(map[string]any{"a": 1}, "x"))
+		assert.Equal(t, map[string]any{"a": 1}, MergeLSPAny("x", map[string]any{"a": 1}))
+		assert.Equal(t, "a", MergeLSPAny("a", nil))
+	})
+}
@@ -0,0 +1,356 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file implements Mapper, which converts between LSP Positions and byte
+// offsets within a document. LSP positions count characters in the position
+// encoding negotiated at initialization (UTF-16 unless both sides agreed on
+// something else); Go strings are indexed by byte.
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#positionEncodingKind
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mapper converts between Positions and byte offsets for a single document.
+// It is immutable and safe for concurrent use.
+type Mapper struct {
+	text     string
+	encoding PositionEncodingKind
+
+	// lineStarts holds the byte offset of the first character of each line.
+	lineStarts []int
+}
+
+// NewMapper returns a Mapper for text using the UTF-16 position encoding,
+// the LSP default.
+func NewMapper(text string) *Mapper {
+	return NewMapperWithEncoding(text, PositionEncodingKindUTF16)
+}
+
+// NewMapperWithEncoding returns a Mapper for text whose Position.Character
+// values are measured in the given encoding: bytes for UTF-8, UTF-16 code
+// units for UTF-16, and code points for UTF-32. An empty encoding means
+// UTF-16.
+func NewMapperWithEncoding(text string, encoding PositionEncodingKind) *Mapper {
+	if encoding == "" {
+		encoding = PositionEncodingKindUTF16
+	}
+
+	lineStarts := []int{0}
+
+	for idx := 0; idx < len(text); idx++ {
+		switch text[idx] {
+		case '\n':
+			lineStarts = append(lineStarts, idx+1)
+		case '\r':
+			if idx+1 < len(text) && text[idx+1] == '\n' {
+				idx++
+			}
+
+			lineStarts = append(lineStarts, idx+1)
+		}
+	}
+
+	return &Mapper{text: text, encoding: encoding, lineStarts: lineStarts}
+}
+
+// Text returns the document content the Mapper was built for.
+func (m *Mapper) Text() string {
+	return m.text
+}
+
+// Encoding returns the position encoding the Mapper uses.
+func (m *Mapper) Encoding() PositionEncodingKind {
+	return m.encoding
+}
+
+// LineCount returns the number of lines in the document. A trailing line
+// terminator starts a new, empty line.
+func (m *Mapper) LineCount() int {
+	return len(m.lineStarts)
+}
+
+// Offset returns the byte offset of pos. Per the specification, a character
+// value past the end of the line is clamped to the end of the line. It
+// returns an error if the line does not exist or pos points inside a
+// multi-unit character.
+func (m *Mapper) Offset(pos Position) (int, error) {
+	if int(pos.Line) >= len(m.lineStarts) {
+		return 0, fmt.Errorf( //nolint:err113
+			"line %d out of range (document has %d lines)",
+			pos.Line,
+			len(m.lineStarts),
+		)
+	}
+
+	start, end := m.lineBounds(int(pos.Line))
+	col := uint32(0)
+
+	for idx := start; idx < end; {
+		if col == pos.Character {
+			return idx, nil
+		}
+
+		if col > pos.Character {
+			break
+		}
+
+		r, size := utf8.DecodeRuneInString(m.text[idx:end])
+		col += m.units(r, size)
+		idx += size
+	}
+
+	if col > pos.Character {
+		return 0, fmt.Errorf( //nolint:err113
+			"position %d:%d points inside a character",
+			pos.Line,
+			pos.Character,
+		)
+	}
+
+	return end, nil
+}
+
+// PositionAt returns the Position of the given byte offset. Offsets inside a
+// line terminator map to the end of the line. It returns an error if offset
+// is outside the document or points inside a multi-byte character.
+func (m *Mapper) PositionAt(offset int) (Position, error) {
+	if offset < 0 || offset > len(m.text) {
+		return Position{}, fmt.Errorf( //nolint:err113
+			"offset %d out of range (document has %d bytes)",
+			offset,
+			len(m.text),
+		)
+	}
+
+	line := sort.Search(len(m.lineStarts), func(i int) bool {
+		return m.lineStarts[i] > offset
+	}) - 1
+
+	start, end := m.lineBounds(line)
+	offset = min(offset, end)
+
+	if offset < len(m.text) && !utf8.RuneStart(m.text[offset]) {
+		return Position{}, fmt.Errorf( //nolint:err113
+			"offset %d points inside a character",
+			offset,
+		)
+	}
+
+	col := uint32(0)
+
+	for idx := start; idx < offset; {
+		r, size := utf8.DecodeRuneInString(m.text[idx:offset])
+		col += m.units(r, size)
+		idx += size
+	}
+
+	return Position{Line: uint32(line), Character: col}, nil //nolint:gosec
+}
+
+// Offsets returns the byte offsets of the start and end of r, as Offset does
+// for a single position. With the Mapper built for the position encoding
+// negotiated with the client, it turns incoming ranges into byte offsets so
+// that server logic need not care about the encoding.
+func (m *Mapper) Offsets(r Range) (int, int, error) {
+	start, err := m.Offset(r.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := m.Offset(r.End)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start, end, nil
+}
+
+// RangeAt returns the Range spanning the byte offsets [start, end), as
+// PositionAt does for a single offset. It is the inverse of Offsets, for
+// ranges sent back to the client.
+func (m *Mapper) RangeAt(start, end int) (Range, error) {
+	startPos, err := m.PositionAt(start)
+	if err != nil {
+		return Range{}, err
+	}
+
+	endPos, err := m.PositionAt(end)
+	if err != nil {
+		return Range{}, err
+	}
+
+	return Range{Start: startPos, End: endPos}, nil
+}
+
+// WordRangeAt returns the range of the word surrounding pos, for example as
+// the default editable region of textDocument/prepareRename. A word is a
+// maximal run of runes for which isIdentChar reports true; a nil isIdentChar
+// matches Go identifier characters (letters, digits and '_').
+//
+// A position just past the end of a word selects that word. If pos is not
+// adjacent to any word character the result is the empty range at pos. It
+// returns an error if pos itself is invalid (see Offset).
+func (m *Mapper) WordRangeAt(pos Position, isIdentChar func(rune) bool) (Range, error) {
+	if isIdentChar == nil {
+		isIdentChar = isGoIdentChar
+	}
+
+	offset, err := m.Offset(pos)
+	if err != nil {
+		return Range{}, err
+	}
+
+	lineStart, lineEnd := m.lineBounds(int(pos.Line))
+
+	start := offset
+	for start > lineStart {
+		r, size := utf8.DecodeLastRuneInString(m.text[lineStart:start])
+		if !isIdentChar(r) {
+			break
+		}
+
+		start -= size
+	}
+
+	end := offset
+	for end < lineEnd {
+		r, size := utf8.DecodeRuneInString(m.text[end:lineEnd])
+		if !isIdentChar(r) {
+			break
+		}
+
+		end += size
+	}
+
+	startPos, err := m.PositionAt(start)
+	if err != nil {
+		return Range{}, err
+	}
+
+	endPos, err := m.PositionAt(end)
+	if err != nil {
+		return Range{}, err
+	}
+
+	return Range{Start: startPos, End: endPos}, nil
+}
+
+// PrefixAt returns the part of the word before pos, which a completion
+// server uses to filter its candidates, and its range, which ends at pos and
+// suits as the replace range of the completion items' text edits. Words are
+// delimited as by WordRangeAt; characters of the word after pos are not
+// included. At the start of a word, or away from any word, the prefix is
+// empty and the range is the empty range at pos. It returns an error if pos
+// is invalid (see Offset).
+func (m *Mapper) PrefixAt(pos Position, isIdentChar func(rune) bool) (string, Range, error) {
+	if isIdentChar == nil {
+		isIdentChar = isGoIdentChar
+	}
+
+	offset, err := m.Offset(pos)
+	if err != nil {
+		return "", Range{}, err
+	}
+
+	lineStart, _ := m.lineBounds(int(pos.Line))
+
+	start := offset
+	for start > lineStart {
+		r, size := utf8.DecodeLastRuneInString(m.text[lineStart:start])
+		if !isIdentChar(r) {
+			break
+		}
+
+		start -= size
+	}
+
+	startPos, err := m.PositionAt(start)
+	if err != nil {
+		return "", Range{}, err
+	}
+
+	// Offset clamps a character past the end of the line, so the range ends
+	// at the position of offset rather than at pos itself.
+	endPos, err := m.PositionAt(offset)
+	if err != nil {
+		return "", Range{}, err
+	}
+
+	return m.text[start:offset], Range{Start: startPos, End: endPos}, nil
+}
+
+// HoverAt returns a Markdown hover for the word at pos (see WordRangeAt)
+// whose Range covers that word, so the client highlights the hovered
+// symbol. If pos is not at a word the hover has no range.
+func (m *Mapper) HoverAt(pos Position, markdown string, isIdentChar func(rune) bool) (Hover, error) {
+	rng, err := m.WordRangeAt(pos, isIdentChar)
+	if err != nil {
+		return Hover{}, err
+	}
+
+	hover := NewMarkdownHover(markdown)
+	if rng.Start != rng.End {
+		hover.Range = &rng
+	}
+
+	return hover, nil
+}
+
+// isGoIdentChar reports whether r may appear in a Go identifier.
+func isGoIdentChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// lineBounds returns the byte offsets of the start of the given line and the
+// end of its content, excluding the line terminator.
+func (m *Mapper) lineBounds(line int) (int, int) {
+	start := m.lineStarts[line]
+	end := len(m.text)
+
+	if line+1 < len(m.lineStarts) {
+		end = m.lineStarts[line+1]
+		if end > start && m.text[end-1] == '\n' {
+			end--
+		}
+
+		if end > start && m.text[end-1] == '\r' {
+			end--
+		}
+	}
+
+	return start, end
+}
+
+// units returns the width of a rune of the given encoded size in the
+// Mapper's position encoding.
+func (m *Mapper) units(r rune, size int) uint32 {
+	switch m.encoding {
+	case PositionEncodingKindUTF8:
+		return uint32(size) //nolint:gosec
+	case PositionEncodingKindUTF32:
+		return 1
+	default:
+		if r >= 0x10000 { //nolint:mnd
+			return 2 //nolint:mnd
+		}
+
+		return 1
+	}
+}
+
+// PositionEncoding returns the position encoding the server selected in its
+// capabilities, defaulting to UTF-16 when none was announced. A nil result
+// also yields UTF-16.
+func (r *InitializeResult) PositionEncoding() PositionEncodingKind {
+	if r == nil || r.Capabilities.PositionEncoding == nil || *r.Capabilities.PositionEncoding == "" {
+		return PositionEncodingKindUTF16
+	}
+
+	return *r.Capabilities.PositionEncoding
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapperDoc mixes 1-, 2- and 4-byte UTF-8 characters: é is one UTF-16 unit,
+// 😀 is a surrogate pair.
+const mapperDoc = "package main\nx := \"é😀\" + y\r\nlast"
+
+func TestMapperEncodings(t *testing.T) {
+	// Byte offset of "y" on line 1.
+	yOffset := len("package main\n") + len("x := \"é😀\" + ")
+
+	tests := []struct {
+		encoding PositionEncodingKind
+		yColumn  uint32
+	}{
+		{PositionEncodingKindUTF8, 16},
+		{PositionEncodingKindUTF16, 13},
+		{PositionEncodingKindUTF32, 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			m := NewMapperWithEncoding(mapperDoc, tt.encoding)
+			assert.Equal(t, tt.encoding, m.Encoding())
+
+			pos, err := m.PositionAt(yOffset)
+			require.NoError(t, err)
+			assert.Equal(t, Position{Line: 1, Character: tt.yColumn}, pos)
+
+			offset, err := m.Offset(pos)
+			require.NoError(t, err)
+			assert.Equal(t, yOffset, offset)
+		})
+	}
+}
+
+func TestMapperDefaultsToUTF16(t *testing.T) {
+	assert.Equal(t, PositionEncodingKindUTF16, NewMapper("").Encoding())
+	assert.Equal(t, PositionEncodingKindUTF16, NewMapperWithEncoding("", "").Encoding())
+}
+
+func TestMapperOffset(t *testing.T) {
+	m := NewMapper(mapperDoc)
+
+	t.Run("clamps past end of line", func(t *testing.T) {
+		offset, err := m.Offset(Position{Line: 0, Character: 100})
+		require.NoError(t, err)
+		assert.Equal(t, len("package main"), offset)
+	})
+
+	t.Run("CRLF terminated line", func(t *testing.T) {
+		offset, err := m.Offset(Position{Line: 2, Character: 0})
+		require.NoError(t, err)
+		assert.Equal(t, "last", mapperDoc[offset:])
+	})
+
+	t.Run("line out of range", func(t *testing.T) {
+		_, err := m.Offset(Position{Line: 3, Character: 0})
+		assert.Error(t, err)
+	})
+
+	t.Run("inside surrogate pair", func(t *testing.T) {
+		// x := "é😀: the emoji starts at UTF-16 column 7.
+		_, err := m.Offset(Position{Line: 1, Character: 8})
+		assert.Error(t, err)
+	})
+}
+
+func TestMapperPositionAtErrors(t *testing.T) {
+	m := NewMapper(mapperDoc)
+
+	_, err := m.PositionAt(-1)
+	assert.Error(t, err)
+
+	_, err = m.PositionAt(len(mapperDoc) + 1)
+	assert.Error(t, err)
+
+	pos, err := m.PositionAt(len(mapperDoc))
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 2, Character: 4}, pos)
+}
+
+func TestMapperWordRangeAt(t *testing.T) {
+	m := NewMapper("func héllo_wörld() {\n\treturn a-b\n}")
+
+	rng := func(line, start, end uint32) Range {
+		return Range{
+			Start: Position{Line: line, Character: start},
+			End:   Position{Line: line, Character: end},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		pos         Position
+		isIdentChar func(rune) bool
+		want        Range
+	}{
+		{"mid identifier", Position{Line: 0, Character: 8}, nil, rng(0, 5, 16)},
+		{"start of word", Position{Line: 0, Character: 5}, nil, rng(0, 5, 16)},
+		{"end of word", Position{Line: 0, Character: 16}, nil, rng(0, 5, 16)},
+		{"between words", Position{Line: 1, Character: 9}, nil, rng(1, 8, 9)},
+		{"no word", Position{Line: 2, Character: 0}, nil, rng(2, 0, 0)},
+		{
+			"custom predicate",
+			Position{Line: 1, Character: 9},
+			func(r rune) bool { return r == '-' || isGoIdentChar(r) },
+			rng(1, 8, 11),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.WordRangeAt(tt.pos, tt.isIdentChar)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := m.WordRangeAt(Position{Line: 5, Character: 0}, nil)
+	assert.Error(t, err)
+}
+
+func TestMapperPrefixAt(t *testing.T) {
+	m := NewMapper("x := strings.TrimSp\n\tnéw-name\n")
+
+	tests := []struct {
+		name        string
+		pos         Position
+		isIdentChar func(rune) bool
+		want        string
+		start       uint32
+	}{
+		{"start of identifier", Position{Line: 0, Character: 13}, nil, "", 13},
+		{"mid identifier", Position{Line: 0, Character: 16}, nil, "Tri", 13},
+		{"end of identifier", Position{Line: 0, Character: 19}, nil, "TrimSp", 13},
+		{"before a dot", Position{Line: 0, Character: 12}, nil, "strings", 5},
+		{"no word", Position{Line: 0, Character: 4}, nil, "", 4},
+		{"non-ASCII", Position{Line: 1, Character: 3}, nil, "né", 1},
+		{"after a delimiter", Position{Line: 1, Character: 9}, nil, "name", 5},
+		{
+			"custom predicate",
+			Position{Line: 1, Character: 9},
+			func(r rune) bool { return r == '-' || isGoIdentChar(r) },
+			"néw-name",
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, rng, err := m.PrefixAt(tt.pos, tt.isIdentChar)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, prefix)
+			assert.Equal(t, Position{Line: tt.pos.Line, Character: tt.start}.To(tt.pos), rng)
+		})
+	}
+
+	prefix, rng, err := m.PrefixAt(Position{Line: 1, Character: 99}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "name", prefix)
+	assert.Equal(t, Position{Line: 1, Character: 5}.Range(4), rng,
+		"a position past the end of the line is clamped")
+
+	_, _, err = m.PrefixAt(Position{Line: 5, Character: 0}, nil)
+	assert.Error(t, err)
+}
+
+func TestMapperHoverAt(t *testing.T) {
+	m := NewMapper("x := strings.TrimSpace(s)\n")
+
+	hover, err := m.HoverAt(Position{Line: 0, Character: 16}, "func TrimSpace(s string) string", nil)
+	require.NoError(t, err)
+	assert.Equal(t, NewMarkupHoverContents(MarkupContent{
+		Kind:  MarkupKindMarkdown,
+		Value: "func TrimSpace(s string) string",
+	}), hover.Contents)
+	require.NotNil(t, hover.Range)
+	assert.Equal(t, Range{
+		Start: Position{Line: 0, Character: 13},
+		End:   Position{Line: 0, Character: 22},
+	}, *hover.Range)
+
+	hover, err = m.HoverAt(Position{Line: 0, Character: 2}, "assignment", nil)
+	require.NoError(t, err)
+	assert.Nil(t, hover.Range, "no word at the cursor")
+
+	_, err = m.HoverAt(Position{Line: 4, Character: 0}, "", nil)
+	assert.Error(t, err)
+}
+
+func TestMapperEncodingConversion(t *testing.T) {
+	// 😀 is four bytes in UTF-8 and a surrogate pair in UTF-16.
+	const doc = "s := \"😀\" + name\n"
+
+	nameOffset := strings.Index(doc, "name")
+	m16 := NewMapperWithEncoding(doc, PositionEncodingKindUTF16)
+	m8 := NewMapperWithEncoding(doc, PositionEncodingKindUTF8)
+
+	// The same byte offset is a different column in each encoding.
+	pos16, err := m16.PositionAt(nameOffset)
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 0, Character: 12}, pos16)
+
+	pos8, err := m8.PositionAt(nameOffset)
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 0, Character: 14}, pos8)
+
+	// A UTF-16 position from a client round-trips to a UTF-8 position via
+	// the byte offset.
+	offset, err := m16.Offset(pos16)
+	require.NoError(t, err)
+	assert.Equal(t, nameOffset, offset)
+
+	got, err := m8.PositionAt(offset)
+	require.NoError(t, err)
+	assert.Equal(t, pos8, got)
+
+	t.Run("ranges", func(t *testing.T) {
+		emoji := strings.Index(doc, "😀")
+
+		r, err := m16.RangeAt(emoji, emoji+len("😀"))
+		require.NoError(t, err)
+		assert.Equal(t, Range{Start: Position{Character: 6}, End: Position{Character: 8}}, r)
+
+		start, end, err := m16.Offsets(r)
+		require.NoError(t, err)
+		assert.Equal(t, "😀", doc[start:end])
+
+		r8, err := m8.RangeAt(start, end)
+		require.NoError(t, err)
+		assert.Equal(t, Range{Start: Position{Character: 6}, End: Position{Character: 10}}, r8)
+	})
+
+	t.Run("inside the emoji", func(t *testing.T) {
+		_, _, err := m16.Offsets(Range{Start: Position{Line: 0, Character: 7}})
+		require.Error(t, err)
+
+		_, _, err = m8.Offsets(Range{End: Position{Line: 0, Character: 8}})
+		require.Error(t, err)
+
+		_, err = m8.RangeAt(0, strings.Index(doc, "😀")+1)
+		require.Error(t, err)
+	})
+}
+
+func TestInitializeResultPositionEncoding(t *testing.T) {
+	var nilResult *InitializeResult
+	assert.Equal(t, PositionEncodingKindUTF16, nilResult.PositionEncoding())
+
+	result := &InitializeResult{Capabilities: ServerCapabilities{
+		PositionEncoding: new(PositionEncodingKindUTF8),
+	}}
+	assert.Equal(t, PositionEncodingKindUTF8, result.PositionEncoding())
+}
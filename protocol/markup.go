@@ -0,0 +1,54 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides constructors and accessors for `string | MarkupContent` unions, which are
+// generated as OrStringMarkupContent.
+
+// DocumentationText returns the item's documentation as text and its markup
+// kind. A plain string is reported as MarkupKindPlainText. The boolean is
+// false if the item has no documentation or it has an unexpected shape.
+func (item CompletionItem) DocumentationText() (string, MarkupKind, bool) {
+	return markupText(item.Documentation)
+}
+
+// SetMarkdownDocumentation sets the item's documentation to md rendered as
+// Markdown.
+func (item *CompletionItem) SetMarkdownDocumentation(md string) {
+	item.Documentation = newMarkdownContent(md)
+}
+
+// NewMarkdownHover returns a Hover showing md rendered as Markdown, without
+// a range.
+func NewMarkdownHover(md string) Hover {
+	return Hover{ //nolint:exhaustruct
+		Contents: NewMarkupHoverContents(MarkupContent{Kind: MarkupKindMarkdown, Value: md}),
+	}
+}
+
+// newMarkdownContent returns a `string | MarkupContent` value holding md
+// rendered as Markdown.
+func newMarkdownContent(md string) *OrStringMarkupContent {
+	var content OrStringMarkupContent
+	content.SetMarkupContent(MarkupContent{Kind: MarkupKindMarkdown, Value: md})
+
+	return &content
+}
+
+// markupText normalizes a `string | MarkupContent` value to its text and kind.
+func markupText(v *OrStringMarkupContent) (string, MarkupKind, bool) {
+	if v == nil {
+		return "", "", false
+	}
+
+	if s, ok := v.AsString(); ok {
+		return s, MarkupKindPlainText, true
+	}
+
+	if content, ok := v.AsMarkupContent(); ok {
+		return content.Value, content.Kind, true
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionItemDocumentationText(t *testing.T) {
+	markdown := CompletionItem{Label: "Println"}
+	markdown.SetMarkdownDocumentation("Println formats using the *default* formats.")
+
+	var plain OrStringMarkupContent
+	plain.SetString("Println formats.")
+
+	tests := []struct {
+		name      string
+		item      CompletionItem
+		wantJSON  string
+		wantText  string
+		wantKind  MarkupKind
+		wantFound bool
+	}{
+		{
+			name:      "plain string",
+			item:      CompletionItem{Label: "Println", Documentation: &plain},
+			wantJSON:  `{"label": "Println", "documentation": "Println formats."}`,
+			wantText:  "Println formats.",
+			wantKind:  MarkupKindPlainText,
+			wantFound: true,
+		},
+		{
+			name: "markup content",
+			item: markdown,
+			wantJSON: `{"label": "Println", "documentation": {
+				"kind": "markdown", "value": "Println formats using the *default* formats."
+			}}`,
+			wantText:  "Println formats using the *default* formats.",
+			wantKind:  MarkupKindMarkdown,
+			wantFound: true,
+		},
+		{
+			name:     "absent",
+			item:     CompletionItem{Label: "Println"},
+			wantJSON: `{"label": "Println"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, kind, ok := tt.item.DocumentationText()
+			assert.Equal(t, tt.wantFound, ok)
+			assert.Equal(t, tt.wantText, text)
+			assert.Equal(t, tt.wantKind, kind)
+
+			data, err := json.Marshal(tt.item)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantJSON, string(data))
+
+			var decoded CompletionItem
+			require.NoError(t, json.Unmarshal(data, &decoded))
+
+			text, kind, ok = decoded.DocumentationText()
+			assert.Equal(t, tt.wantFound, ok)
+			assert.Equal(t, tt.wantText, text)
+			assert.Equal(t, tt.wantKind, kind)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides Marshal, the JSON encoding used for protocol values.
+
+import "encoding/json"
+
+// Marshal returns the JSON encoding of v, a protocol value such as a params
+// or result struct or an LSPAny tree.
+//
+// The output is deterministic: encoding the same value twice yields the same
+// bytes, and the keys of every map, including maps nested in LSPAny values,
+// are written in sorted order. Golden tests may rely on this. Any codec that
+// replaces encoding/json here must keep the guarantee, sorting map keys
+// itself if it does not do so natively.
+func Marshal(v any) ([]byte, error) {
+	return json.Marshal(v) //nolint:wrapcheck
+}
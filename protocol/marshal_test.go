@@ -0,0 +1,34 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal_Deterministic(t *testing.T) {
+	settings := make(map[string]any)
+	for i := range 64 {
+		settings[fmt.Sprintf("key%02d", 63-i)] = map[string]any{"z": i, "a": []any{"x", map[string]any{"b": 1, "a": 2}}}
+	}
+
+	params := DidChangeConfigurationParams{Settings: settings}
+
+	first, err := Marshal(params)
+	require.NoError(t, err)
+
+	for range 10 {
+		again, err := Marshal(params)
+		require.NoError(t, err)
+		assert.Equal(t, first, again)
+	}
+
+	small, err := Marshal(map[string]any{"b": 1, "c": map[string]any{"y": true, "x": false}, "a": nil})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":null,"b":1,"c":{"x":false,"y":true}}`, string(small))
+}
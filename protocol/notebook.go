@@ -0,0 +1,278 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file implements NotebookStore, the notebook counterpart of
+// DocumentStore. A notebook document lists its cells, while the content of
+// each cell is a separate text document; the store keeps the two in step by
+// applying the notebookDocument/didOpen, didChange and didClose
+// notifications.
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+type (
+	// Notebook is a snapshot of an open notebook document.
+	Notebook struct {
+		// Document is the notebook with its cells in order.
+		Document NotebookDocument
+		// Cells holds the text document of each cell, in the order of
+		// Document.Cells.
+		Cells []TextDocumentItem
+	}
+
+	// NotebookStore holds the open notebook documents of a session, keyed by
+	// notebook URI. It is safe for concurrent use.
+	NotebookStore struct {
+		encoding PositionEncodingKind
+
+		mu        sync.RWMutex
+		notebooks map[URI]Notebook
+	}
+)
+
+// Cells returns the cell text documents of the opened notebook in the order
+// of the notebook's cells. Text documents of cells the notebook does not list
+// follow in the order the client sent them.
+func (p *DidOpenNotebookDocumentParams) Cells() []TextDocumentItem {
+	cells := slices.Clone(p.CellTextDocuments)
+
+	order := make(map[DocumentURI]int, len(p.NotebookDocument.Cells))
+	for idx, cell := range p.NotebookDocument.Cells {
+		order[cell.Document] = idx
+	}
+
+	rank := func(item TextDocumentItem) int {
+		if idx, ok := order[item.URI]; ok {
+			return idx
+		}
+
+		return len(order)
+	}
+
+	slices.SortStableFunc(cells, func(a, b TextDocumentItem) int {
+		return rank(a) - rank(b)
+	})
+
+	return cells
+}
+
+// NewNotebookStore returns an empty NotebookStore that interprets the ranges
+// of incremental cell changes in the given position encoding. An empty
+// encoding means UTF-16.
+func NewNotebookStore(encoding PositionEncodingKind) *NotebookStore {
+	return &NotebookStore{ //nolint:exhaustruct
+		encoding:  encoding,
+		notebooks: make(map[URI]Notebook),
+	}
+}
+
+// Get returns the notebook stored for uri.
+func (s *NotebookStore) Get(uri URI) (Notebook, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nb, ok := s.notebooks[uri]
+
+	return nb.clone(), ok
+}
+
+// Cell returns the text document of the cell uri together with the URI of
+// the notebook it belongs to.
+func (s *NotebookStore) Cell(uri DocumentURI) (TextDocumentItem, URI, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for notebookURI, nb := range s.notebooks {
+		if idx := cellIndex(nb.Cells, uri); idx >= 0 {
+			return nb.Cells[idx], notebookURI, true
+		}
+	}
+
+	return TextDocumentItem{}, "", false
+}
+
+// DidOpen stores the opened notebook, replacing any previous content.
+func (s *NotebookStore) DidOpen(params *DidOpenNotebookDocumentParams) {
+	nb := Notebook{Document: params.NotebookDocument, Cells: params.Cells()}
+	nb.Document.Cells = slices.Clone(nb.Document.Cells)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notebooks[nb.Document.URI] = nb
+}
+
+// DidChange applies the change to the notebook's metadata, cell structure,
+// cell properties and cell contents, and records the new version. It returns
+// an error, leaving the notebook unchanged, if the notebook is not open or
+// the change cannot be applied.
+func (s *NotebookStore) DidChange(params *DidChangeNotebookDocumentParams) error {
+	uri := params.NotebookDocument.URI
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nb, ok := s.notebooks[uri]
+	if !ok {
+		return fmt.Errorf("notebook %s is not open", uri) //nolint:err113
+	}
+
+	nb = nb.clone()
+	change := params.Change
+
+	if change.Metadata != nil {
+		nb.Document.Metadata = change.Metadata
+	}
+
+	if change.Cells != nil {
+		if structure := change.Cells.Structure; structure != nil {
+			cells, err := spliceCells(nb.Document.Cells, structure.Array, structure.Array.Cells)
+			if err != nil {
+				return fmt.Errorf("notebook %s: %w", uri, err)
+			}
+
+			nb.Document.Cells = cells
+		}
+
+		for _, data := range change.Cells.Data {
+			idx := slices.IndexFunc(nb.Document.Cells, func(c NotebookCell) bool {
+				return c.Document == data.Document
+			})
+			if idx < 0 {
+				return fmt.Errorf( //nolint:err113
+					"notebook %s: unknown cell %s", uri, data.Document)
+			}
+
+			nb.Document.Cells[idx] = data
+		}
+	}
+
+	cells, err := applyCellChanges(nb.Cells, change, s.encoding)
+	if err != nil {
+		return fmt.Errorf("notebook %s: %w", uri, err)
+	}
+
+	nb.Cells = cells
+	nb.Document.Version = params.NotebookDocument.Version
+	s.notebooks[uri] = nb
+
+	return nil
+}
+
+// DidClose forgets the closed notebook and its cells.
+func (s *NotebookStore) DidClose(params *DidCloseNotebookDocumentParams) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.notebooks, params.NotebookDocument.URI)
+}
+
+// clone returns a copy of nb whose cell slices can be modified without
+// affecting nb.
+func (nb Notebook) clone() Notebook {
+	nb.Document.Cells = slices.Clone(nb.Document.Cells)
+	nb.Cells = slices.Clone(nb.Cells)
+
+	return nb
+}
+
+// ApplyNotebookChanges returns cells, the text documents of a notebook's
+// cells in order, updated for change: the cell structure change is applied
+// first, inserting the cells it opens or moves and dropping the ones it
+// deletes, then the text content changes of each cell in turn. Ranges are
+// measured in UTF-16 code units. cells itself is not modified.
+//
+// Changes to the notebook metadata and to cell properties do not affect the
+// cell text documents and are ignored; NotebookStore tracks those too.
+func ApplyNotebookChanges(
+	cells []TextDocumentItem,
+	change NotebookDocumentChangeEvent,
+) ([]TextDocumentItem, error) {
+	return applyCellChanges(cells, change, "")
+}
+
+// applyCellChanges is ApplyNotebookChanges with ranges measured in encoding.
+func applyCellChanges(
+	cells []TextDocumentItem,
+	change NotebookDocumentChangeEvent,
+	encoding PositionEncodingKind,
+) ([]TextDocumentItem, error) {
+	cells = slices.Clone(cells)
+	if change.Cells == nil {
+		return cells, nil
+	}
+
+	if structure := change.Cells.Structure; structure != nil {
+		array := structure.Array
+		end := min(int(array.Start)+int(array.DeleteCount), len(cells))
+
+		// A moved cell is deleted and inserted again without being reopened,
+		// so inserted cells are looked up among the deleted ones first.
+		var available []TextDocumentItem
+		if int(array.Start) <= len(cells) {
+			available = slices.Clone(cells[array.Start:end])
+		}
+
+		available = append(available, structure.DidOpen...)
+
+		inserted := make([]TextDocumentItem, 0, len(array.Cells))
+
+		for _, cell := range array.Cells {
+			idx := cellIndex(available, cell.Document)
+			if idx < 0 {
+				return nil, fmt.Errorf( //nolint:err113
+					"inserted cell %s has no text document", cell.Document)
+			}
+
+			inserted = append(inserted, available[idx])
+		}
+
+		var err error
+
+		cells, err = spliceCells(cells, array, inserted)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, content := range change.Cells.TextContent {
+		uri := content.Document.URI
+
+		idx := cellIndex(cells, uri)
+		if idx < 0 {
+			return nil, fmt.Errorf("unknown cell %s", uri) //nolint:err113
+		}
+
+		text, err := ApplyContentChanges(cells[idx].Text, encoding, content.Changes...)
+		if err != nil {
+			return nil, fmt.Errorf("cell %s: %w", uri, err)
+		}
+
+		cells[idx].Text = text
+		cells[idx].Version = content.Document.Version
+	}
+
+	return cells, nil
+}
+
+// cellIndex returns the index of the text document uri in cells, or -1.
+func cellIndex(cells []TextDocumentItem, uri DocumentURI) int {
+	return slices.IndexFunc(cells, func(c TextDocumentItem) bool { return c.URI == uri })
+}
+
+// spliceCells returns a copy of cells with the elements described by array
+// replaced by inserted.
+func spliceCells[T any](cells []T, array NotebookCellArrayChange, inserted []T) ([]T, error) {
+	start, end := int(array.Start), int(array.Start)+int(array.DeleteCount)
+	if end > len(cells) {
+		return nil, fmt.Errorf( //nolint:err113
+			"cell change [%d, %d) is out of range for %d cells", start, end, len(cells))
+	}
+
+	return slices.Concat(cells[:start], inserted, cells[end:]), nil
+}
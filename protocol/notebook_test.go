@@ -0,0 +1,222 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	notebookURI URI         = "file:///analysis.ipynb"
+	cell1URI    DocumentURI = "vscode-notebook-cell:/analysis.ipynb#cell1"
+	cell2URI    DocumentURI = "vscode-notebook-cell:/analysis.ipynb#cell2"
+)
+
+// openNotebookParams returns the didOpen params of a notebook with a code
+// cell and a markdown cell, whose text documents are sent out of order.
+func openNotebookParams() *DidOpenNotebookDocumentParams {
+	return &DidOpenNotebookDocumentParams{
+		NotebookDocument: NotebookDocument{
+			URI:          notebookURI,
+			NotebookType: "jupyter-notebook",
+			Version:      1,
+			Cells: []NotebookCell{
+				{Kind: NotebookCellKindCode, Document: cell1URI},
+				{Kind: NotebookCellKindMarkup, Document: cell2URI},
+			},
+		},
+		CellTextDocuments: []TextDocumentItem{
+			{URI: cell2URI, LanguageId: "markdown", Version: 1, Text: "# Results\n"},
+			{URI: cell1URI, LanguageId: "python", Version: 1, Text: "import pandas\n"},
+		},
+	}
+}
+
+func TestDidOpenNotebookDocumentParamsCells(t *testing.T) {
+	params := openNotebookParams()
+
+	cells := params.Cells()
+	require.Len(t, cells, 2)
+	assert.Equal(t, cell1URI, cells[0].URI)
+	assert.Equal(t, cell2URI, cells[1].URI)
+	assert.Equal(t, cell2URI, params.CellTextDocuments[0].URI, "params must not be reordered")
+}
+
+func TestNotebookStore(t *testing.T) {
+	store := NewNotebookStore("")
+	store.DidOpen(openNotebookParams())
+
+	nb, ok := store.Get(notebookURI)
+	require.True(t, ok)
+	assert.Equal(t, int32(1), nb.Document.Version)
+	require.Len(t, nb.Document.Cells, 2)
+	require.Len(t, nb.Cells, 2)
+	assert.Equal(t, "import pandas\n", nb.Cells[0].Text)
+	assert.Equal(t, "# Results\n", nb.Cells[1].Text)
+
+	cell, owner, ok := store.Cell(cell2URI)
+	require.True(t, ok)
+	assert.Equal(t, notebookURI, owner)
+	assert.Equal(t, "# Results\n", cell.Text)
+
+	require.NoError(t, store.DidChange(&DidChangeNotebookDocumentParams{
+		NotebookDocument: VersionedNotebookDocumentIdentifier{URI: notebookURI, Version: 2},
+		Change: NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				TextContent: []NotebookDocumentCellContentChanges{{
+					Document: VersionedTextDocumentIdentifier{URI: cell1URI, Version: 2},
+					Changes: []TextDocumentContentChangeEvent{NewRangeChange(
+						Position{Line: 0, Character: 7}.To(Position{Line: 0, Character: 13}),
+						"numpy",
+					)},
+				}},
+			},
+		},
+	}))
+
+	nb, _ = store.Get(notebookURI)
+	assert.Equal(t, int32(2), nb.Document.Version)
+	assert.Equal(t, "import numpy\n", nb.Cells[0].Text)
+	assert.Equal(t, int32(2), nb.Cells[0].Version)
+
+	nb.Cells[0].Text = "mutated"
+	nb, _ = store.Get(notebookURI)
+	assert.Equal(t, "import numpy\n", nb.Cells[0].Text, "Get must return a copy")
+
+	err := store.DidChange(&DidChangeNotebookDocumentParams{
+		NotebookDocument: VersionedNotebookDocumentIdentifier{
+			URI:     "file:///other.ipynb",
+			Version: 2,
+		},
+	})
+	require.Error(t, err)
+
+	store.DidClose(&DidCloseNotebookDocumentParams{
+		NotebookDocument:  NotebookDocumentIdentifier{URI: notebookURI},
+		CellTextDocuments: []TextDocumentIdentifier{{URI: cell1URI}, {URI: cell2URI}},
+	})
+
+	_, ok = store.Get(notebookURI)
+	assert.False(t, ok)
+
+	_, _, ok = store.Cell(cell1URI)
+	assert.False(t, ok)
+}
+
+func TestApplyNotebookChanges(t *testing.T) {
+	cells := openNotebookParams().Cells()
+	cell3URI := DocumentURI("vscode-notebook-cell:/analysis.ipynb#cell3")
+
+	t.Run("insert", func(t *testing.T) {
+		got, err := ApplyNotebookChanges(cells, NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				Structure: &NotebookDocumentCellChangeStructure{
+					Array: NotebookCellArrayChange{
+						Start: 1,
+						Cells: []NotebookCell{{Kind: NotebookCellKindCode, Document: cell3URI}},
+					},
+					DidOpen: []TextDocumentItem{
+						{URI: cell3URI, LanguageId: "python", Version: 1, Text: "df.head()\n"},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, cell1URI, got[0].URI)
+		assert.Equal(t, cell3URI, got[1].URI)
+		assert.Equal(t, "df.head()\n", got[1].Text)
+		assert.Equal(t, cell2URI, got[2].URI)
+		assert.Len(t, cells, 2, "cells must not be modified")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		got, err := ApplyNotebookChanges(cells, NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				Structure: &NotebookDocumentCellChangeStructure{
+					Array:    NotebookCellArrayChange{Start: 0, DeleteCount: 1},
+					DidClose: []TextDocumentIdentifier{{URI: cell1URI}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, cell2URI, got[0].URI)
+	})
+
+	t.Run("move", func(t *testing.T) {
+		got, err := ApplyNotebookChanges(cells, NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				Structure: &NotebookDocumentCellChangeStructure{
+					Array: NotebookCellArrayChange{
+						Start:       0,
+						DeleteCount: 2,
+						Cells: []NotebookCell{
+							{Kind: NotebookCellKindMarkup, Document: cell2URI},
+							{Kind: NotebookCellKindCode, Document: cell1URI},
+						},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, cell2URI, got[0].URI)
+		assert.Equal(t, cell1URI, got[1].URI)
+		assert.Equal(t, "import pandas\n", got[1].Text)
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		got, err := ApplyNotebookChanges(cells, NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				TextContent: []NotebookDocumentCellContentChanges{{
+					Document: VersionedTextDocumentIdentifier{URI: cell2URI, Version: 2},
+					Changes: []TextDocumentContentChangeEvent{
+						NewRangeChange(Position{Line: 0, Character: 9}.Range(0), "!"),
+					},
+				}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "import pandas\n", got[0].Text)
+		assert.Equal(t, "# Results!\n", got[1].Text)
+		assert.Equal(t, int32(2), got[1].Version)
+		assert.Equal(t, "# Results\n", cells[1].Text, "cells must not be modified")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ApplyNotebookChanges(cells, NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				Structure: &NotebookDocumentCellChangeStructure{
+					Array: NotebookCellArrayChange{
+						Start: 2,
+						Cells: []NotebookCell{{Kind: NotebookCellKindCode, Document: cell3URI}},
+					},
+				},
+			},
+		})
+		require.ErrorContains(t, err, "has no text document")
+
+		_, err = ApplyNotebookChanges(cells, NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				Structure: &NotebookDocumentCellChangeStructure{
+					Array: NotebookCellArrayChange{Start: 1, DeleteCount: 2},
+				},
+			},
+		})
+		require.ErrorContains(t, err, "out of range")
+
+		_, err = ApplyNotebookChanges(cells, NotebookDocumentChangeEvent{
+			Cells: &NotebookDocumentCellChanges{
+				TextContent: []NotebookDocumentCellContentChanges{{
+					Document: VersionedTextDocumentIdentifier{URI: cell3URI, Version: 2},
+				}},
+			},
+		})
+		require.ErrorContains(t, err, "unknown cell")
+	})
+}
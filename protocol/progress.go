@@ -0,0 +1,111 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file implements server-side progress reporting over `$/progress`.
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#partialResults
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.lsp.dev/jsonrpc2"
+)
+
+// Values of the "kind" discriminator of work done progress notifications.
+const (
+	workDoneProgressKindBegin  = "begin"
+	workDoneProgressKindReport = "report"
+	workDoneProgressKindEnd    = "end"
+)
+
+// SendProgress sends a `$/progress` notification for token over conn. The
+// value is either a work done progress value (see NewWorkDoneProgressBegin,
+// NewWorkDoneProgressReport and NewWorkDoneProgressEnd) or a partial result.
+func SendProgress(ctx context.Context, conn jsonrpc2.Conn, token ProgressToken, value any) error {
+	return conn.Notify(ctx, MethodProgress, &ProgressParams{Token: token, Value: value}) //nolint:wrapcheck
+}
+
+// NewWorkDoneProgressBegin returns the value starting a work done progress
+// with the given title. Set Cancellable, Message or Percentage as needed.
+func NewWorkDoneProgressBegin(title string) WorkDoneProgressBegin {
+	return WorkDoneProgressBegin{Kind: workDoneProgressKindBegin, Title: title} //nolint:exhaustruct
+}
+
+// NewWorkDoneProgressReport returns a value reporting intermediate progress.
+// An empty message is omitted, keeping the client's previous message.
+func NewWorkDoneProgressReport(message string) WorkDoneProgressReport {
+	report := WorkDoneProgressReport{Kind: workDoneProgressKindReport} //nolint:exhaustruct
+	if message != "" {
+		report.Message = &message
+	}
+
+	return report
+}
+
+// NewWorkDoneProgressEnd returns the value ending a work done progress, with
+// an optional final message.
+func NewWorkDoneProgressEnd(message string) WorkDoneProgressEnd {
+	end := WorkDoneProgressEnd{Kind: workDoneProgressKindEnd} //nolint:exhaustruct
+	if message != "" {
+		end.Message = &message
+	}
+
+	return end
+}
+
+// PartialResultReporter streams partial results of a request to the client
+// as `$/progress` notifications keyed by the request's partialResultToken.
+//
+// Typical use inside a Server method:
+//
+//	rep := protocol.NewPartialResultReporter(client, params.PartialResultToken)
+//	for batch := range findReferences(ctx) {
+//		if rep.Enabled() {
+//			_ = rep.Report(ctx, batch)
+//			continue
+//		}
+//		all = append(all, batch...)
+//	}
+//	return all, nil
+//
+// Per the specification, once a partial result has been reported the final
+// response must not repeat it: return only what has not been streamed (an
+// empty result if everything was).
+type PartialResultReporter struct {
+	client   Client
+	token    *ProgressToken
+	reported atomic.Bool
+}
+
+// NewPartialResultReporter returns a reporter for the given partial result
+// token, usually params.PartialResultToken. A nil token means the client did
+// not ask for partial results; the reporter is then disabled and Report is a
+// no-op.
+func NewPartialResultReporter(client Client, token *ProgressToken) *PartialResultReporter {
+	return &PartialResultReporter{client: client, token: token} //nolint:exhaustruct
+}
+
+// Enabled reports whether the client supplied a partial result token.
+func (r *PartialResultReporter) Enabled() bool {
+	return r.token != nil
+}
+
+// Reported reports whether at least one partial result was sent.
+func (r *PartialResultReporter) Reported() bool {
+	return r.reported.Load()
+}
+
+// Report sends chunk to the client as a partial result. The chunk must have
+// the same shape as the request's result (e.g. []Location for references).
+// It does nothing if the reporter is disabled.
+func (r *PartialResultReporter) Report(ctx context.Context, chunk any) error {
+	if !r.Enabled() {
+		return nil
+	}
+
+	r.reported.Store(true)
+
+	return r.client.Progress(ctx, &ProgressParams{Token: *r.token, Value: chunk}) //nolint:wrapcheck
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/jsonrpc2"
+)
+
+// notifyConn is a jsonrpc2.Conn recording the notifications sent through it.
+// Other methods panic via the nil embedded interface.
+type notifyConn struct {
+	jsonrpc2.Conn
+
+	methods []string
+	params  []json.RawMessage
+}
+
+func (c *notifyConn) Notify(_ context.Context, method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	c.methods = append(c.methods, method)
+	c.params = append(c.params, data)
+
+	return nil
+}
+
+func TestSendProgress(t *testing.T) {
+	conn := &notifyConn{}
+	ctx := context.Background()
+
+	begin := NewWorkDoneProgressBegin("Indexing")
+	begin.Cancellable = new(true)
+
+	report := NewWorkDoneProgressReport("3/10 packages")
+	report.Percentage = new(uint32(30))
+
+	var token, intToken ProgressToken
+	token.SetString("idx-1")
+	intToken.SetInt32(7)
+
+	require.NoError(t, SendProgress(ctx, conn, token, begin))
+	require.NoError(t, SendProgress(ctx, conn, token, report))
+	require.NoError(t, SendProgress(ctx, conn, intToken, NewWorkDoneProgressEnd("")))
+
+	assert.Equal(t, []string{MethodProgress, MethodProgress, MethodProgress}, conn.methods)
+	require.Len(t, conn.params, 3)
+	assert.JSONEq(t, `{"token": "idx-1", "value": {"kind": "begin", "title": "Indexing", "cancellable": true}}`,
+		string(conn.params[0]))
+	assert.JSONEq(t, `{"token": "idx-1", "value": {"kind": "report", "message": "3/10 packages", "percentage": 30}}`,
+		string(conn.params[1]))
+	assert.JSONEq(t, `{"token": 7, "value": {"kind": "end"}}`, string(conn.params[2]))
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+// Package protocoltest provides utilities for testing LSP servers and clients
+// built with the protocol package.
+package protocoltest
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/modern-dev/go-lsp/protocol"
+	"go.lsp.dev/jsonrpc2"
+)
+
+type (
+	// RecordedCall is a request or notification seen by a RecordingMiddleware.
+	RecordedCall struct {
+		Method string
+		Params json.RawMessage
+	}
+
+	// RecordingMiddleware records every request and notification passing
+	// through it, so tests can assert which Server methods were invoked
+	// without instrumenting the server. It is safe for concurrent use.
+	RecordingMiddleware struct {
+		mu    sync.Mutex
+		calls []RecordedCall
+	}
+)
+
+// NewRecordingMiddleware returns an empty RecordingMiddleware. Install it
+// with protocol.WithMiddleware:
+//
+//	rec := protocoltest.NewRecordingMiddleware()
+//	handler := protocol.WithMiddleware(protocol.ServerHandler(s, nil), rec.Middleware())
+func NewRecordingMiddleware() *RecordingMiddleware {
+	return &RecordingMiddleware{} //nolint:exhaustruct
+}
+
+// Middleware returns the protocol.Middleware that feeds the recorder.
+func (r *RecordingMiddleware) Middleware() protocol.Middleware {
+	return func(next jsonrpc2.Handler) jsonrpc2.Handler {
+		return func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+			r.mu.Lock()
+			r.calls = append(r.calls, RecordedCall{
+				Method: req.Method(),
+				Params: slices.Clone(req.Params()),
+			})
+			r.mu.Unlock()
+
+			return next(ctx, reply, req)
+		}
+	}
+}
+
+// Calls returns the recorded calls in the order they were received.
+func (r *RecordingMiddleware) Calls() []RecordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.calls)
+}
+
+// Methods returns the method names of the recorded calls, in order.
+func (r *RecordingMiddleware) Methods() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	methods := make([]string, 0, len(r.calls))
+	for _, call := range r.calls {
+		methods = append(methods, call.Method)
+	}
+
+	return methods
+}
+
+// Reset discards the recorded calls.
+func (r *RecordingMiddleware) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocoltest_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modern-dev/go-lsp/protocol"
+	"github.com/modern-dev/go-lsp/protocol/protocoltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/jsonrpc2"
+)
+
+// hoverServer implements the methods the test drives; calling any other
+// Server method panics on the nil embedded interface.
+type hoverServer struct {
+	protocol.Server
+}
+
+func (hoverServer) DidOpen(context.Context, *protocol.DidOpenTextDocumentParams) error {
+	return nil
+}
+
+func (hoverServer) Hover(context.Context, *protocol.HoverParams) (*protocol.Hover, error) {
+	hover := protocol.NewMarkdownHover("hover")
+	return &hover, nil
+}
+
+func TestRecordingMiddleware(t *testing.T) {
+	rec := protocoltest.NewRecordingMiddleware()
+	h := protocol.WithMiddleware(protocol.ServerHandler(hoverServer{}, nil), rec.Middleware())
+
+	nopReply := func(context.Context, any, error) error { return nil }
+
+	didOpen, _ := jsonrpc2.NewNotification(protocol.MethodTextDocumentDidOpen, protocol.NewDidOpenTextDocumentParams(
+		"file:///a.go", protocol.LanguageKindGo, 1, "package a",
+	))
+	require.NoError(t, h(context.Background(), nopReply, didOpen))
+
+	for idx, line := range []uint32{3, 7} {
+		hover, _ := jsonrpc2.NewCall(jsonrpc2.NewNumberID(int32(idx)), protocol.MethodTextDocumentHover, protocol.HoverParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: "file:///a.go"},
+			Position:     protocol.Position{Line: line, Character: 1},
+		})
+		require.NoError(t, h(context.Background(), nopReply, hover))
+	}
+
+	assert.Equal(t, []string{
+		protocol.MethodTextDocumentDidOpen,
+		protocol.MethodTextDocumentHover,
+		protocol.MethodTextDocumentHover,
+	}, rec.Methods())
+
+	calls := rec.Calls()
+	require.Len(t, calls, 3)
+
+	var opened protocol.DidOpenTextDocumentParams
+	require.NoError(t, json.Unmarshal(calls[0].Params, &opened))
+	assert.Equal(t, "package a", opened.TextDocument.Text)
+
+	var hovered protocol.HoverParams
+	require.NoError(t, json.Unmarshal(calls[2].Params, &hovered))
+	assert.Equal(t, protocol.Position{Line: 7, Character: 1}, hovered.Position)
+
+	rec.Reset()
+	assert.Empty(t, rec.Calls())
+}
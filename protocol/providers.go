@@ -0,0 +1,218 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides BoolOrOptions, the type of the `boolean | XOptions`
+// fields of ServerCapabilities and TextDocumentSyncOptions.Save, and typed
+// accessors for the provider fields.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// BoolOrOptions is a setting sent either as a bare boolean or as an options
+// object of type T, such as the hover provider capability, which is `true`
+// or a HoverOptions. Options, if not nil, takes precedence over Bool.
+//
+// Fields of this type are pointers; a nil field is omitted from the wire.
+type BoolOrOptions[T any] struct {
+	// Bool is the boolean form, sent if Options is nil.
+	Bool bool
+	// Options is the options form.
+	Options *T
+}
+
+// NewBoolOrOptions returns a BoolOrOptions holding opts.
+func NewBoolOrOptions[T any](opts T) *BoolOrOptions[T] {
+	return &BoolOrOptions[T]{Bool: false, Options: &opts}
+}
+
+// Enabled reports whether v enables its feature: it holds true or options.
+// A nil v is disabled.
+func (v *BoolOrOptions[T]) Enabled() bool {
+	return v != nil && (v.Bool || v.Options != nil)
+}
+
+// MarshalJSON encodes the options object if set, and the boolean otherwise.
+func (v BoolOrOptions[T]) MarshalJSON() ([]byte, error) {
+	if v.Options != nil {
+		return json.Marshal(v.Options) //nolint:wrapcheck
+	}
+
+	return json.Marshal(v.Bool) //nolint:wrapcheck
+}
+
+// UnmarshalJSON decodes a boolean or an options object. null decodes as
+// false.
+func (v *BoolOrOptions[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*v = BoolOrOptions[T]{} //nolint:exhaustruct
+	case bytes.HasPrefix(data, []byte("{")):
+		var opts T
+		if err := json.Unmarshal(data, &opts); err != nil { //nolint:noinlineerr
+			return fmt.Errorf("boolean or options: %w", err)
+		}
+
+		*v = BoolOrOptions[T]{Bool: false, Options: &opts}
+	default:
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil { //nolint:noinlineerr
+			return fmt.Errorf("boolean or options: %w", err)
+		}
+
+		*v = BoolOrOptions[T]{Bool: b, Options: nil}
+	}
+
+	return nil
+}
+
+// boolProvider returns the value stored for a bool provider setting: true,
+// or nil so that a disabled provider is omitted from the wire.
+func boolProvider[T any](enabled bool) *BoolOrOptions[T] {
+	if !enabled {
+		return nil
+	}
+
+	return &BoolOrOptions[T]{Bool: true, Options: nil}
+}
+
+// SetHoverProvider advertises (or, with false, stops advertising) textDocument/hover.
+func (c *ServerCapabilities) SetHoverProvider(enabled bool) {
+	c.HoverProvider = boolProvider[HoverOptions](enabled)
+}
+
+// SetHoverProviderOptions advertises textDocument/hover with opts.
+func (c *ServerCapabilities) SetHoverProviderOptions(opts HoverOptions) {
+	c.HoverProvider = NewBoolOrOptions(opts)
+}
+
+// HoverEnabled reports whether textDocument/hover is advertised, in either form.
+func (c *ServerCapabilities) HoverEnabled() bool {
+	return c.HoverProvider.Enabled()
+}
+
+// SetDefinitionProvider advertises (or, with false, stops advertising) textDocument/definition.
+func (c *ServerCapabilities) SetDefinitionProvider(enabled bool) {
+	c.DefinitionProvider = boolProvider[DefinitionOptions](enabled)
+}
+
+// SetDefinitionProviderOptions advertises textDocument/definition with opts.
+func (c *ServerCapabilities) SetDefinitionProviderOptions(opts DefinitionOptions) {
+	c.DefinitionProvider = NewBoolOrOptions(opts)
+}
+
+// DefinitionEnabled reports whether textDocument/definition is advertised, in either form.
+func (c *ServerCapabilities) DefinitionEnabled() bool {
+	return c.DefinitionProvider.Enabled()
+}
+
+// SetReferencesProvider advertises (or, with false, stops advertising) textDocument/references.
+func (c *ServerCapabilities) SetReferencesProvider(enabled bool) {
+	c.ReferencesProvider = boolProvider[ReferenceOptions](enabled)
+}
+
+// SetReferencesProviderOptions advertises textDocument/references with opts.
+func (c *ServerCapabilities) SetReferencesProviderOptions(opts ReferenceOptions) {
+	c.ReferencesProvider = NewBoolOrOptions(opts)
+}
+
+// ReferencesEnabled reports whether textDocument/references is advertised, in either form.
+func (c *ServerCapabilities) ReferencesEnabled() bool {
+	return c.ReferencesProvider.Enabled()
+}
+
+// SetDocumentHighlightProvider advertises (or, with false, stops advertising) textDocument/documentHighlight.
+func (c *ServerCapabilities) SetDocumentHighlightProvider(enabled bool) {
+	c.DocumentHighlightProvider = boolProvider[DocumentHighlightOptions](enabled)
+}
+
+// SetDocumentHighlightProviderOptions advertises textDocument/documentHighlight with opts.
+func (c *ServerCapabilities) SetDocumentHighlightProviderOptions(opts DocumentHighlightOptions) {
+	c.DocumentHighlightProvider = NewBoolOrOptions(opts)
+}
+
+// DocumentHighlightEnabled reports whether textDocument/documentHighlight is advertised, in either form.
+func (c *ServerCapabilities) DocumentHighlightEnabled() bool {
+	return c.DocumentHighlightProvider.Enabled()
+}
+
+// SetDocumentSymbolProvider advertises (or, with false, stops advertising) textDocument/documentSymbol.
+func (c *ServerCapabilities) SetDocumentSymbolProvider(enabled bool) {
+	c.DocumentSymbolProvider = boolProvider[DocumentSymbolOptions](enabled)
+}
+
+// SetDocumentSymbolProviderOptions advertises textDocument/documentSymbol with opts.
+func (c *ServerCapabilities) SetDocumentSymbolProviderOptions(opts DocumentSymbolOptions) {
+	c.DocumentSymbolProvider = NewBoolOrOptions(opts)
+}
+
+// DocumentSymbolEnabled reports whether textDocument/documentSymbol is advertised, in either form.
+func (c *ServerCapabilities) DocumentSymbolEnabled() bool {
+	return c.DocumentSymbolProvider.Enabled()
+}
+
+// SetCodeActionProvider advertises (or, with false, stops advertising) textDocument/codeAction.
+func (c *ServerCapabilities) SetCodeActionProvider(enabled bool) {
+	c.CodeActionProvider = boolProvider[CodeActionOptions](enabled)
+}
+
+// SetCodeActionProviderOptions advertises textDocument/codeAction with opts.
+func (c *ServerCapabilities) SetCodeActionProviderOptions(opts CodeActionOptions) {
+	c.CodeActionProvider = NewBoolOrOptions(opts)
+}
+
+// CodeActionEnabled reports whether textDocument/codeAction is advertised, in either form.
+func (c *ServerCapabilities) CodeActionEnabled() bool {
+	return c.CodeActionProvider.Enabled()
+}
+
+// SetWorkspaceSymbolProvider advertises (or, with false, stops advertising) workspace/symbol.
+func (c *ServerCapabilities) SetWorkspaceSymbolProvider(enabled bool) {
+	c.WorkspaceSymbolProvider = boolProvider[WorkspaceSymbolOptions](enabled)
+}
+
+// SetWorkspaceSymbolProviderOptions advertises workspace/symbol with opts.
+func (c *ServerCapabilities) SetWorkspaceSymbolProviderOptions(opts WorkspaceSymbolOptions) {
+	c.WorkspaceSymbolProvider = NewBoolOrOptions(opts)
+}
+
+// WorkspaceSymbolEnabled reports whether workspace/symbol is advertised, in either form.
+func (c *ServerCapabilities) WorkspaceSymbolEnabled() bool {
+	return c.WorkspaceSymbolProvider.Enabled()
+}
+
+// SetDocumentFormattingProvider advertises (or, with false, stops advertising) textDocument/formatting.
+func (c *ServerCapabilities) SetDocumentFormattingProvider(enabled bool) {
+	c.DocumentFormattingProvider = boolProvider[DocumentFormattingOptions](enabled)
+}
+
+// SetDocumentFormattingProviderOptions advertises textDocument/formatting with opts.
+func (c *ServerCapabilities) SetDocumentFormattingProviderOptions(opts DocumentFormattingOptions) {
+	c.DocumentFormattingProvider = NewBoolOrOptions(opts)
+}
+
+// DocumentFormattingEnabled reports whether textDocument/formatting is advertised, in either form.
+func (c *ServerCapabilities) DocumentFormattingEnabled() bool {
+	return c.DocumentFormattingProvider.Enabled()
+}
+
+// SetRenameProvider advertises (or, with false, stops advertising) textDocument/rename.
+func (c *ServerCapabilities) SetRenameProvider(enabled bool) {
+	c.RenameProvider = boolProvider[RenameOptions](enabled)
+}
+
+// SetRenameProviderOptions advertises textDocument/rename with opts.
+func (c *ServerCapabilities) SetRenameProviderOptions(opts RenameOptions) {
+	c.RenameProvider = NewBoolOrOptions(opts)
+}
+
+// RenameEnabled reports whether textDocument/rename is advertised, in either form.
+func (c *ServerCapabilities) RenameEnabled() bool {
+	return c.RenameProvider.Enabled()
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerCapabilitiesProviders(t *testing.T) {
+	roundTrip := func(t *testing.T, sc ServerCapabilities) (string, ServerCapabilities) {
+		t.Helper()
+
+		data, err := json.Marshal(sc)
+		require.NoError(t, err)
+
+		var decoded ServerCapabilities
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		return string(data), decoded
+	}
+
+	t.Run("bool form", func(t *testing.T) {
+		var sc ServerCapabilities
+		sc.SetHoverProvider(true)
+		sc.SetReferencesProvider(true)
+		assert.True(t, sc.HoverEnabled())
+
+		data, decoded := roundTrip(t, sc)
+		assert.JSONEq(t, `{"hoverProvider": true, "referencesProvider": true}`, data)
+		assert.True(t, decoded.HoverEnabled())
+		assert.True(t, decoded.ReferencesEnabled())
+		assert.False(t, decoded.RenameEnabled())
+	})
+
+	t.Run("options form", func(t *testing.T) {
+		var sc ServerCapabilities
+		sc.SetHoverProviderOptions(HoverOptions{WorkDoneProgress: new(true)})
+		sc.SetRenameProviderOptions(RenameOptions{PrepareProvider: new(true)})
+		assert.True(t, sc.HoverEnabled())
+		assert.True(t, sc.RenameEnabled())
+
+		data, decoded := roundTrip(t, sc)
+		assert.JSONEq(t, `{
+			"hoverProvider": {"workDoneProgress": true},
+			"renameProvider": {"prepareProvider": true}
+		}`, data)
+		assert.True(t, decoded.HoverEnabled())
+		assert.True(t, decoded.RenameEnabled())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		sc := ServerCapabilities{
+			DefinitionProvider: &BoolOrOptions[DefinitionOptions]{Bool: false},
+			CodeActionProvider: nil,
+		}
+		assert.False(t, sc.DefinitionEnabled())
+		assert.False(t, sc.CodeActionEnabled())
+
+		sc.SetHoverProvider(true)
+		sc.SetHoverProvider(false)
+		assert.False(t, sc.HoverEnabled())
+
+		data, _ := roundTrip(t, ServerCapabilities{HoverProvider: sc.HoverProvider})
+		assert.JSONEq(t, `{}`, data)
+
+		data, decoded := roundTrip(t, ServerCapabilities{
+			DocumentFormattingProvider: &BoolOrOptions[DocumentFormattingOptions]{Bool: false},
+		})
+		assert.JSONEq(t, `{"documentFormattingProvider": false}`, data)
+		assert.False(t, decoded.DocumentFormattingEnabled())
+	})
+}
+
+func TestBoolOrOptions(t *testing.T) {
+	t.Run("decode", func(t *testing.T) {
+		var got struct {
+			A *BoolOrOptions[CodeActionOptions] `json:"a"`
+			B *BoolOrOptions[CodeActionOptions] `json:"b"`
+			C *BoolOrOptions[CodeActionOptions] `json:"c"`
+			D *BoolOrOptions[CodeActionOptions] `json:"d"`
+		}
+		require.NoError(t, json.Unmarshal(
+			[]byte(`{"a": true, "b": false, "c": {"resolveProvider": true}, "d": null}`), &got))
+
+		require.NotNil(t, got.A)
+		assert.True(t, got.A.Enabled())
+		assert.Nil(t, got.A.Options)
+
+		require.NotNil(t, got.B)
+		assert.False(t, got.B.Enabled())
+
+		require.NotNil(t, got.C)
+		assert.True(t, got.C.Enabled())
+		require.NotNil(t, got.C.Options)
+		assert.Equal(t, new(true), got.C.Options.ResolveProvider)
+
+		assert.Nil(t, got.D)
+		assert.False(t, got.D.Enabled())
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		data, err := json.Marshal(NewBoolOrOptions(RenameOptions{PrepareProvider: new(true)}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"prepareProvider": true}`, string(data))
+
+		data, err = json.Marshal(BoolOrOptions[RenameOptions]{Bool: true})
+		require.NoError(t, err)
+		assert.JSONEq(t, `true`, string(data))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var v BoolOrOptions[RenameOptions]
+		require.ErrorContains(t, json.Unmarshal([]byte(`"yes"`), &v), "boolean or options")
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"prepareProvider": 1}`), &v),
+			"boolean or options")
+	})
+}
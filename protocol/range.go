@@ -0,0 +1,115 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides computations on Position and Range values. Ranges are
+// half-open: End is the position just after the last character covered.
+
+import "cmp"
+
+// To returns the range from p to end. end is an absolute position, so for a
+// multi-line range end.Character counts from the start of end's line, not
+// from p. The positions are not reordered; end must not come before p.
+func (p Position) To(end Position) Range {
+	return Range{Start: p, End: end}
+}
+
+// Range returns the range covering length characters on p's line, starting
+// at p. A zero length yields the empty range at p.
+func (p Position) Range(length uint32) Range {
+	return Range{Start: p, End: Position{Line: p.Line, Character: p.Character + length}}
+}
+
+// Compare returns -1 if p comes before other in a document, 1 if it comes
+// after, and 0 if they are equal. Positions are ordered by line, then by
+// character.
+func (p Position) Compare(other Position) int {
+	if c := cmp.Compare(p.Line, other.Line); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(p.Character, other.Character)
+}
+
+// Before reports whether p comes before other.
+func (p Position) Before(other Position) bool {
+	return p.Compare(other) < 0
+}
+
+// After reports whether p comes after other.
+func (p Position) After(other Position) bool {
+	return p.Compare(other) > 0
+}
+
+// FromOneBased returns the position of a one-based line and column, as
+// reported by compilers and linters, in the zero-based form LSP uses: line 1,
+// column 1 is Position{Line: 0, Character: 0}. A line or column of 0, which
+// tools use for "unknown", is clamped to the first line or column rather than
+// wrapping around. The column is taken as is; it must already be counted in
+// the session's position encoding.
+func FromOneBased(line, col uint32) Position {
+	return Position{Line: max(line, 1) - 1, Character: max(col, 1) - 1}
+}
+
+// ToOneBased returns p as the one-based line and column that compilers and
+// linters use; it is the inverse of FromOneBased.
+func (p Position) ToOneBased() (line, col uint32) {
+	return p.Line + 1, p.Character + 1
+}
+
+// SingleChar returns the range covering the single character at p.
+func SingleChar(p Position) Range {
+	return p.Range(1)
+}
+
+// Intersect returns the region covered by both r and other. Ranges that
+// merely touch, where one ends exactly where the other starts, intersect in
+// the zero-width range at that point. The boolean is false if the ranges are
+// disjoint.
+func (r Range) Intersect(other Range) (Range, bool) {
+	start := r.Start
+	if other.Start.After(start) {
+		start = other.Start
+	}
+
+	end := r.End
+	if other.End.Before(end) {
+		end = other.End
+	}
+
+	if start.After(end) {
+		return Range{}, false
+	}
+
+	return Range{Start: start, End: end}, true
+}
+
+// Empty reports whether r covers no characters, i.e. Start equals End.
+func (r Range) Empty() bool {
+	return r.Start == r.End
+}
+
+// Contains reports whether p lies within r. As ranges are half-open, a
+// position at r.End is not contained, and an empty range contains no
+// positions.
+func (r Range) Contains(p Position) bool {
+	return !p.Before(r.Start) && p.Before(r.End)
+}
+
+// Overlaps reports whether r and other share a position. An empty range is
+// treated as the point it sits at: it overlaps a range containing that point
+// and an empty range at the same point. Unlike Intersect, ranges that merely
+// touch do not overlap.
+func (r Range) Overlaps(other Range) bool {
+	switch {
+	case r.Empty() && other.Empty():
+		return r.Start == other.Start
+	case r.Empty():
+		return other.Contains(r.Start)
+	case other.Empty():
+		return r.Contains(other.Start)
+	default:
+		return r.Start.Before(other.End) && other.Start.Before(r.End)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_Range(t *testing.T) {
+	p := Position{Line: 3, Character: 7}
+
+	assert.Equal(t, Range{Start: p, End: Position{Line: 3, Character: 12}}, p.Range(5))
+	assert.Equal(t, Range{Start: p, End: p}, p.Range(0))
+	assert.Equal(t, Range{Start: p, End: Position{Line: 3, Character: 8}}, SingleChar(p))
+}
+
+func TestPosition_To(t *testing.T) {
+	start := Position{Line: 1, Character: 10}
+	end := Position{Line: 4, Character: 2}
+
+	// end.Character is absolute on its own line, not an offset from start.
+	assert.Equal(t, Range{Start: start, End: end}, start.To(end))
+	assert.Equal(t, start.Range(3), start.To(Position{Line: 1, Character: 13}))
+}
+
+func TestPosition_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Position
+		want int
+	}{
+		{"equal", Position{Line: 2, Character: 4}, Position{Line: 2, Character: 4}, 0},
+		{"earlier character", Position{Line: 2, Character: 3}, Position{Line: 2, Character: 4}, -1},
+		{"later character", Position{Line: 2, Character: 5}, Position{Line: 2, Character: 4}, 1},
+		{"line wins", Position{Line: 1, Character: 90}, Position{Line: 2, Character: 0}, -1},
+		{"later line", Position{Line: 3, Character: 0}, Position{Line: 2, Character: 9}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.a.Compare(tt.b))
+			assert.Equal(t, -tt.want, tt.b.Compare(tt.a))
+			assert.Equal(t, tt.want < 0, tt.a.Before(tt.b))
+			assert.Equal(t, tt.want > 0, tt.a.After(tt.b))
+		})
+	}
+}
+
+func TestFromOneBased(t *testing.T) {
+	assert.Equal(t, Position{Line: 0, Character: 0}, FromOneBased(1, 1))
+	assert.Equal(t, Position{Line: 11, Character: 4}, FromOneBased(12, 5))
+
+	// 0 means "unknown" to many tools and must not wrap around.
+	assert.Equal(t, Position{Line: 0, Character: 0}, FromOneBased(0, 0))
+	assert.Equal(t, Position{Line: 6, Character: 0}, FromOneBased(7, 0))
+	assert.Equal(t, Position{Line: 0, Character: 2}, FromOneBased(0, 3))
+}
+
+func TestPosition_ToOneBased(t *testing.T) {
+	line, col := Position{Line: 0, Character: 0}.ToOneBased()
+	assert.Equal(t, uint32(1), line)
+	assert.Equal(t, uint32(1), col)
+
+	p := Position{Line: 11, Character: 4}
+	line, col = p.ToOneBased()
+	assert.Equal(t, uint32(12), line)
+	assert.Equal(t, uint32(5), col)
+	assert.Equal(t, p, FromOneBased(line, col))
+}
+
+// rng returns the range from line sl, character sc to line el, character ec.
+func rng(sl, sc, el, ec uint32) Range {
+	return Range{
+		Start: Position{Line: sl, Character: sc},
+		End:   Position{Line: el, Character: ec},
+	}
+}
+
+func TestRange_Intersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Range
+		want   Range
+		wantOK bool
+	}{
+		{"partial overlap", rng(1, 4, 3, 2), rng(2, 0, 5, 0), rng(2, 0, 3, 2), true},
+		{"same line overlap", rng(0, 2, 0, 8), rng(0, 5, 0, 12), rng(0, 5, 0, 8), true},
+		{"containment", rng(0, 0, 10, 0), rng(3, 1, 4, 7), rng(3, 1, 4, 7), true},
+		{"identical", rng(2, 3, 2, 9), rng(2, 3, 2, 9), rng(2, 3, 2, 9), true},
+		{"touching edges", rng(0, 0, 1, 4), rng(1, 4, 2, 0), rng(1, 4, 1, 4), true},
+		{"zero-width inside", rng(0, 0, 0, 10), rng(0, 3, 0, 3), rng(0, 3, 0, 3), true},
+		{"disjoint lines", rng(0, 0, 1, 0), rng(2, 0, 3, 0), Range{}, false},
+		{"disjoint same line", rng(4, 0, 4, 3), rng(4, 5, 4, 9), Range{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.a.Intersect(tt.b)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+
+			got, ok = tt.b.Intersect(tt.a)
+			assert.Equal(t, tt.wantOK, ok, "Intersect must be symmetric")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRange_Empty(t *testing.T) {
+	assert.True(t, rng(2, 3, 2, 3).Empty())
+	assert.False(t, rng(2, 3, 2, 4).Empty())
+	assert.False(t, rng(2, 3, 3, 3).Empty())
+}
+
+func TestRange_Contains(t *testing.T) {
+	r := rng(1, 4, 3, 2)
+
+	tests := []struct {
+		name string
+		pos  Position
+		want bool
+	}{
+		{"start", Position{Line: 1, Character: 4}, true},
+		{"middle line", Position{Line: 2, Character: 99}, true},
+		{"last character", Position{Line: 3, Character: 1}, true},
+		{"end", Position{Line: 3, Character: 2}, false},
+		{"before start", Position{Line: 1, Character: 3}, false},
+		{"after end", Position{Line: 4, Character: 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.Contains(tt.pos))
+		})
+	}
+
+	assert.False(t, rng(1, 4, 1, 4).Contains(Position{Line: 1, Character: 4}),
+		"an empty range contains no positions")
+}
+
+func TestRange_Overlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Range
+		want bool
+	}{
+		{"partial overlap", rng(1, 4, 3, 2), rng(2, 0, 5, 0), true},
+		{"containment", rng(0, 0, 10, 0), rng(3, 1, 4, 7), true},
+		{"identical", rng(2, 3, 2, 9), rng(2, 3, 2, 9), true},
+		{"touching edges", rng(0, 0, 1, 4), rng(1, 4, 2, 0), false},
+		{"disjoint", rng(4, 0, 4, 3), rng(4, 5, 4, 9), false},
+		{"empty at same point", rng(1, 4, 1, 4), rng(1, 4, 1, 4), true},
+		{"empty at different points", rng(1, 4, 1, 4), rng(1, 5, 1, 5), false},
+		{"empty inside", rng(0, 0, 0, 10), rng(0, 3, 0, 3), true},
+		{"empty at start", rng(0, 3, 0, 10), rng(0, 3, 0, 3), true},
+		{"empty at end", rng(0, 0, 0, 10), rng(0, 10, 0, 10), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.a.Overlaps(tt.b))
+			assert.Equal(t, tt.want, tt.b.Overlaps(tt.a), "Overlaps must be symmetric")
+		})
+	}
+}
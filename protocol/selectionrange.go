@@ -0,0 +1,18 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for textDocument/selectionRange, which takes
+// several positions at once and answers with one SelectionRange per position.
+
+// NewSelectionRangeParams returns SelectionRangeParams asking for the
+// selection ranges at positions in the document uri. The positions are copied
+// in order, and the server's []SelectionRange result aligns with them by
+// index: result[i] is the selection hierarchy around positions[i].
+func NewSelectionRangeParams(uri DocumentURI, positions ...Position) SelectionRangeParams {
+	return SelectionRangeParams{ //nolint:exhaustruct
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Positions:    append([]Position{}, positions...),
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSelectionRangeParams(t *testing.T) {
+	positions := []Position{{Line: 3, Character: 7}, {Line: 0, Character: 1}, {Line: 9}}
+
+	params := NewSelectionRangeParams("file:///main.go", positions...)
+	assert.Equal(t, DocumentURI("file:///main.go"), params.TextDocument.URI)
+	assert.Equal(t, positions, params.Positions)
+
+	positions[0].Line = 42
+	assert.Equal(t, uint32(3), params.Positions[0].Line, "positions must be copied")
+
+	data, err := json.Marshal(NewSelectionRangeParams("file:///main.go"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"textDocument": {"uri": "file:///main.go"}, "positions": []}`, string(data))
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for producing semantic tokens: building the
+// legend announced in SemanticTokensOptions and encoding tokens against it.
+// It also helps clients consume the `SemanticTokens | SemanticTokensDelta`
+// result of textDocument/semanticTokens/full/delta.
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_semanticTokens
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+const (
+	// maxSemanticTokenModifiers is the number of modifiers that fit in the
+	// bit set of an encoded token.
+	maxSemanticTokenModifiers = 32
+
+	// semanticTokenFields is the number of integers encoding a token.
+	semanticTokenFields = 5
+)
+
+// NewSemanticTokensLegend returns the legend mapping token type and modifier
+// indices to names. The same legend must be announced in the server
+// capabilities and passed to NewSemanticTokensBuilder.
+func NewSemanticTokensLegend(types []SemanticTokenTypes, mods []SemanticTokenModifiers) SemanticTokensLegend {
+	legend := SemanticTokensLegend{
+		TokenTypes:     make([]string, 0, len(types)),
+		TokenModifiers: make([]string, 0, len(mods)),
+	}
+
+	for _, typ := range types {
+		legend.TokenTypes = append(legend.TokenTypes, string(typ))
+	}
+
+	for _, mod := range mods {
+		legend.TokenModifiers = append(legend.TokenModifiers, string(mod))
+	}
+
+	return legend
+}
+
+type (
+	// SemanticTokensBuilder collects the semantic tokens of a document and
+	// encodes them relative to a legend.
+	SemanticTokensBuilder struct {
+		types  map[string]uint32
+		mods   map[string]uint32
+		tokens []semanticToken
+	}
+
+	// semanticToken is a token in absolute coordinates.
+	semanticToken struct {
+		line, char, length, typ, mods uint32
+	}
+)
+
+// NewSemanticTokensBuilder returns a builder encoding tokens against legend.
+func NewSemanticTokensBuilder(legend SemanticTokensLegend) *SemanticTokensBuilder {
+	b := &SemanticTokensBuilder{ //nolint:exhaustruct
+		types: make(map[string]uint32, len(legend.TokenTypes)),
+		mods:  make(map[string]uint32, len(legend.TokenModifiers)),
+	}
+
+	for idx, typ := range legend.TokenTypes {
+		if _, dup := b.types[typ]; !dup {
+			b.types[typ] = uint32(idx) //nolint:gosec
+		}
+	}
+
+	for idx, mod := range legend.TokenModifiers {
+		if _, dup := b.mods[mod]; !dup {
+			b.mods[mod] = uint32(idx) //nolint:gosec
+		}
+	}
+
+	return b
+}
+
+// Add records a token of the given length starting at line:char. Tokens may
+// be added in any order but must not span lines or overlap. It returns an
+// error if typ or one of mods is not part of the legend, or a modifier's
+// index does not fit in the 32-bit modifier set.
+func (b *SemanticTokensBuilder) Add(
+	line, char, length uint32,
+	typ SemanticTokenTypes,
+	mods ...SemanticTokenModifiers,
+) error {
+	typIdx, ok := b.types[string(typ)]
+	if !ok {
+		return fmt.Errorf("semantic token type %q is not in the legend", typ) //nolint:err113
+	}
+
+	var modSet uint32
+
+	for _, mod := range mods {
+		modIdx, ok := b.mods[string(mod)]
+		if !ok {
+			return fmt.Errorf("semantic token modifier %q is not in the legend", mod) //nolint:err113
+		}
+
+		if modIdx >= maxSemanticTokenModifiers {
+			return fmt.Errorf( //nolint:err113
+				"semantic token modifier %q has index %d, only %d modifiers can be encoded",
+				mod,
+				modIdx,
+				maxSemanticTokenModifiers,
+			)
+		}
+
+		modSet |= 1 << modIdx
+	}
+
+	b.tokens = append(b.tokens, semanticToken{
+		line:   line,
+		char:   char,
+		length: length,
+		typ:    typIdx,
+		mods:   modSet,
+	})
+
+	return nil
+}
+
+// Build returns the recorded tokens in the relative encoding of
+// SemanticTokens.Data: five integers per token holding the line delta, the
+// start character (relative to the previous token on the same line), the
+// length, the type index and the modifier bit set.
+func (b *SemanticTokensBuilder) Build() SemanticTokens {
+	tokens := slices.Clone(b.tokens)
+	slices.SortStableFunc(tokens, func(x, y semanticToken) int {
+		if c := cmp.Compare(x.line, y.line); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(x.char, y.char)
+	})
+
+	return SemanticTokens{Data: encodeSemanticTokens(tokens)} //nolint:exhaustruct
+}
+
+// encodeSemanticTokens returns tokens, which must be sorted by position, in
+// the relative encoding of SemanticTokens.Data.
+func encodeSemanticTokens(tokens []semanticToken) []uint32 {
+	data := make([]uint32, 0, len(tokens)*semanticTokenFields)
+
+	var prevLine, prevChar uint32
+
+	for _, tok := range tokens {
+		deltaChar := tok.char
+		if tok.line == prevLine {
+			deltaChar -= prevChar
+		}
+
+		data = append(data, tok.line-prevLine, deltaChar, tok.length, tok.typ, tok.mods)
+		prevLine, prevChar = tok.line, tok.char
+	}
+
+	return data
+}
+
+// decodeSemanticTokens returns the tokens of data, in the relative encoding
+// of SemanticTokens.Data, in absolute coordinates.
+func decodeSemanticTokens(data []uint32) ([]semanticToken, error) {
+	if len(data)%semanticTokenFields != 0 {
+		return nil, fmt.Errorf( //nolint:err113
+			"semantic tokens data has %d integers, not a multiple of %d",
+			len(data),
+			semanticTokenFields,
+		)
+	}
+
+	tokens := make([]semanticToken, 0, len(data)/semanticTokenFields)
+
+	var line, char uint32
+
+	for chunk := range slices.Chunk(data, semanticTokenFields) {
+		if chunk[0] > 0 {
+			char = 0
+		}
+
+		line += chunk[0]
+		char += chunk[1]
+		tokens = append(tokens, semanticToken{
+			line:   line,
+			char:   char,
+			length: chunk[2],
+			typ:    chunk[3],
+			mods:   chunk[4],
+		})
+	}
+
+	return tokens, nil
+}
+
+// FilterSemanticTokensByRange returns the tokens of full, the Data of a
+// textDocument/semanticTokens/full result, that intersect r, re-encoded
+// relative to each other. A server can use it to answer
+// textDocument/semanticTokens/range from the tokens of the whole document.
+// A token touching r only at one of its ends is dropped. It returns an
+// error if full is not a whole number of tokens.
+func FilterSemanticTokensByRange(full []uint32, r Range) ([]uint32, error) {
+	tokens, err := decodeSemanticTokens(full)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens = slices.DeleteFunc(tokens, func(tok semanticToken) bool {
+		return !Position{Line: tok.line, Character: tok.char}.Range(tok.length).Overlaps(r)
+	})
+
+	return encodeSemanticTokens(tokens), nil
+}
+
+// DecodeSemanticTokensDelta discriminates the result of
+// textDocument/semanticTokens/full/delta. Exactly one of full and delta is
+// non-nil on success. v may hold either type or the
+// OrSemanticTokensSemanticTokensDelta union of both (by value or pointer), a
+// json.RawMessage, or the map[string]any produced by decoding into any; a
+// value with an "edits" property is a delta.
+func DecodeSemanticTokensDelta(v any) (*SemanticTokens, *SemanticTokensDelta, error) {
+	if u, ok := v.(*OrSemanticTokensSemanticTokensDelta); ok && u != nil {
+		v = *u
+	}
+
+	if u, ok := v.(OrSemanticTokensSemanticTokensDelta); ok {
+		if full, ok := u.AsSemanticTokens(); ok {
+			return &full, nil, nil
+		}
+
+		if delta, ok := u.AsSemanticTokensDelta(); ok {
+			return nil, &delta, nil
+		}
+	}
+
+	switch v := v.(type) {
+	case SemanticTokens:
+		return &v, nil, nil
+	case *SemanticTokens:
+		if v != nil {
+			return v, nil, nil
+		}
+	case SemanticTokensDelta:
+		return nil, &v, nil
+	case *SemanticTokensDelta:
+		if v != nil {
+			return nil, v, nil
+		}
+	case json.RawMessage:
+		return decodeSemanticTokensDelta(v)
+	case map[string]any:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("semantic tokens result: %w", err)
+		}
+
+		return decodeSemanticTokensDelta(raw)
+	}
+
+	return nil, nil, fmt.Errorf("semantic tokens result: unexpected %T", v) //nolint:err113
+}
+
+func decodeSemanticTokensDelta(raw json.RawMessage) (*SemanticTokens, *SemanticTokensDelta, error) {
+	var probe struct {
+		Edits json.RawMessage `json:"edits"`
+	}
+
+	if err := json.Unmarshal(raw, &probe); err != nil { //nolint:noinlineerr
+		return nil, nil, fmt.Errorf("semantic tokens result: %w", err)
+	}
+
+	if probe.Edits != nil {
+		var delta SemanticTokensDelta
+		if err := json.Unmarshal(raw, &delta); err != nil { //nolint:noinlineerr
+			return nil, nil, fmt.Errorf("semantic tokens delta: %w", err)
+		}
+
+		return nil, &delta, nil
+	}
+
+	var full SemanticTokens
+	if err := json.Unmarshal(raw, &full); err != nil { //nolint:noinlineerr
+		return nil, nil, fmt.Errorf("semantic tokens: %w", err)
+	}
+
+	return &full, nil, nil
+}
+
+// ApplySemanticTokensDelta applies the edits of delta to prev, the Data of
+// the previous result, and returns the new token array. prev is not
+// modified. Edit offsets refer to prev, so edits may arrive in any order but
+// must not overlap or reach past its end.
+func ApplySemanticTokensDelta(prev []uint32, delta SemanticTokensDelta) ([]uint32, error) {
+	edits := slices.Clone(delta.Edits)
+	slices.SortStableFunc(edits, func(x, y SemanticTokensEdit) int {
+		return cmp.Compare(x.Start, y.Start)
+	})
+
+	out := make([]uint32, 0, len(prev))
+
+	var pos uint32
+
+	for _, edit := range edits {
+		end := uint64(edit.Start) + uint64(edit.DeleteCount)
+		if edit.Start < pos || end > uint64(len(prev)) {
+			return nil, fmt.Errorf( //nolint:err113
+				"semantic tokens edit [%d, %d) overlaps another edit or exceeds %d tokens",
+				edit.Start,
+				end,
+				len(prev),
+			)
+		}
+
+		out = append(out, prev[pos:edit.Start]...)
+		out = append(out, edit.Data...)
+		pos = uint32(end) //nolint:gosec
+	}
+
+	return append(out, prev[pos:]...), nil
+}
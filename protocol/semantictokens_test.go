@@ -0,0 +1,236 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSemanticTokensLegend(t *testing.T) {
+	legend := NewSemanticTokensLegend(
+		[]SemanticTokenTypes{SemanticTokenTypesKeyword, SemanticTokenTypesFunction},
+		nil,
+	)
+
+	data, err := json.Marshal(legend)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tokenTypes": ["keyword", "function"], "tokenModifiers": []}`, string(data))
+}
+
+func TestSemanticTokensBuilder(t *testing.T) {
+	legend := NewSemanticTokensLegend(
+		[]SemanticTokenTypes{SemanticTokenTypesKeyword, SemanticTokenTypesFunction},
+		[]SemanticTokenModifiers{SemanticTokenModifiersDeclaration, SemanticTokenModifiersReadonly},
+	)
+
+	t.Run("encodes relative to the legend", func(t *testing.T) {
+		b := NewSemanticTokensBuilder(legend)
+
+		// Added out of order; Build sorts by position.
+		require.NoError(t, b.Add(2, 5, 4, SemanticTokenTypesFunction, SemanticTokenModifiersDeclaration))
+		require.NoError(t, b.Add(2, 0, 4, SemanticTokenTypesKeyword))
+		require.NoError(t, b.Add(0, 0, 7, SemanticTokenTypesKeyword))
+		require.NoError(t, b.Add(4, 1, 3, SemanticTokenTypesFunction,
+			SemanticTokenModifiersDeclaration, SemanticTokenModifiersReadonly))
+
+		assert.Equal(t, []uint32{
+			0, 0, 7, 0, 0,
+			2, 0, 4, 0, 0,
+			0, 5, 4, 1, 1,
+			2, 1, 3, 1, 3,
+		}, b.Build().Data)
+	})
+
+	t.Run("type absent from legend", func(t *testing.T) {
+		b := NewSemanticTokensBuilder(legend)
+
+		err := b.Add(0, 0, 5, SemanticTokenTypesMacro)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"macro"`)
+		assert.Empty(t, b.Build().Data)
+	})
+
+	t.Run("modifier absent from legend", func(t *testing.T) {
+		err := NewSemanticTokensBuilder(legend).Add(0, 0, 5, SemanticTokenTypesKeyword, SemanticTokenModifiersStatic)
+		assert.Error(t, err)
+	})
+
+	t.Run("modifier beyond bit set", func(t *testing.T) {
+		mods := make([]SemanticTokenModifiers, 33)
+		for idx := range mods {
+			mods[idx] = SemanticTokenModifiers(fmt.Sprintf("mod%d", idx))
+		}
+
+		b := NewSemanticTokensBuilder(NewSemanticTokensLegend([]SemanticTokenTypes{SemanticTokenTypesKeyword}, mods))
+		require.NoError(t, b.Add(0, 0, 1, SemanticTokenTypesKeyword, mods[31]))
+		assert.Error(t, b.Add(0, 2, 1, SemanticTokenTypesKeyword, mods[32]))
+	})
+}
+
+func TestDecodeSemanticTokensDelta(t *testing.T) {
+	decoded := func(t *testing.T, src string) any {
+		t.Helper()
+
+		var v any
+		require.NoError(t, json.Unmarshal([]byte(src), &v))
+
+		return v
+	}
+
+	union := func(t *testing.T, src string) *OrSemanticTokensSemanticTokensDelta {
+		t.Helper()
+
+		var u OrSemanticTokensSemanticTokensDelta
+		require.NoError(t, json.Unmarshal([]byte(src), &u))
+
+		return &u
+	}
+
+	t.Run("full", func(t *testing.T) {
+		for _, v := range []any{
+			decoded(t, `{"resultId": "2", "data": [0, 0, 5, 1, 0]}`),
+			json.RawMessage(`{"resultId": "2", "data": [0, 0, 5, 1, 0]}`),
+			SemanticTokens{ResultId: new("2"), Data: []uint32{0, 0, 5, 1, 0}},
+			union(t, `{"resultId": "2", "data": [0, 0, 5, 1, 0]}`),
+		} {
+			full, delta, err := DecodeSemanticTokensDelta(v)
+			require.NoError(t, err)
+			assert.Nil(t, delta)
+			require.NotNil(t, full)
+			assert.Equal(t, "2", *full.ResultId)
+			assert.Equal(t, []uint32{0, 0, 5, 1, 0}, full.Data)
+		}
+	})
+
+	t.Run("delta", func(t *testing.T) {
+		for _, v := range []any{
+			decoded(t, `{"resultId": "3", "edits": [{"start": 5, "deleteCount": 5}]}`),
+			json.RawMessage(`{"resultId": "3", "edits": [{"start": 5, "deleteCount": 5}]}`),
+			&SemanticTokensDelta{ResultId: new("3"), Edits: []SemanticTokensEdit{{Start: 5, DeleteCount: 5}}},
+			*union(t, `{"resultId": "3", "edits": [{"start": 5, "deleteCount": 5}]}`),
+		} {
+			full, delta, err := DecodeSemanticTokensDelta(v)
+			require.NoError(t, err)
+			assert.Nil(t, full)
+			require.NotNil(t, delta)
+			assert.Equal(t, "3", *delta.ResultId)
+			assert.Equal(t, []SemanticTokensEdit{{Start: 5, DeleteCount: 5}}, delta.Edits)
+		}
+	})
+
+	t.Run("unexpected", func(t *testing.T) {
+		_, _, err := DecodeSemanticTokensDelta("tokens")
+		require.Error(t, err)
+
+		_, _, err = DecodeSemanticTokensDelta((*SemanticTokens)(nil))
+		require.Error(t, err)
+
+		_, _, err = DecodeSemanticTokensDelta(OrSemanticTokensSemanticTokensDelta{})
+		require.Error(t, err)
+	})
+}
+
+func TestApplySemanticTokensDelta(t *testing.T) {
+	prev := []uint32{
+		0, 0, 7, 0, 0,
+		2, 0, 4, 0, 0,
+		0, 5, 4, 1, 1,
+	}
+
+	t.Run("applies edits against the previous array", func(t *testing.T) {
+		got, err := ApplySemanticTokensDelta(prev, SemanticTokensDelta{Edits: []SemanticTokensEdit{
+			{Start: 15, Data: []uint32{2, 1, 3, 1, 3}},
+			{Start: 5, DeleteCount: 5},
+			{Start: 2, DeleteCount: 1, Data: []uint32{8}},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []uint32{
+			0, 0, 8, 0, 0,
+			0, 5, 4, 1, 1,
+			2, 1, 3, 1, 3,
+		}, got)
+		assert.Equal(t, uint32(7), prev[2], "prev must not be modified")
+	})
+
+	t.Run("no edits", func(t *testing.T) {
+		got, err := ApplySemanticTokensDelta(prev, SemanticTokensDelta{})
+		require.NoError(t, err)
+		assert.Equal(t, prev, got)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := ApplySemanticTokensDelta(prev, SemanticTokensDelta{Edits: []SemanticTokensEdit{
+			{Start: 10, DeleteCount: 10},
+		}})
+		require.Error(t, err)
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+		_, err := ApplySemanticTokensDelta(prev, SemanticTokensDelta{Edits: []SemanticTokensEdit{
+			{Start: 0, DeleteCount: 5},
+			{Start: 3, DeleteCount: 1},
+		}})
+		require.Error(t, err)
+	})
+}
+
+func TestFilterSemanticTokensByRange(t *testing.T) {
+	full := []uint32{
+		0, 0, 7, 0, 0, // 0:0-0:7
+		2, 0, 4, 0, 0, // 2:0-2:4
+		0, 5, 4, 1, 1, // 2:5-2:9
+		2, 1, 3, 1, 3, // 4:1-4:4
+	}
+
+	t.Run("keeps intersecting tokens", func(t *testing.T) {
+		r := rng(2, 2, 4, 0)
+
+		got, err := FilterSemanticTokensByRange(full, r)
+		require.NoError(t, err)
+		assert.Equal(t, []uint32{
+			2, 0, 4, 0, 0,
+			0, 5, 4, 1, 1,
+		}, got)
+
+		tokens, err := decodeSemanticTokens(got)
+		require.NoError(t, err)
+		require.Len(t, tokens, 2)
+
+		for _, tok := range tokens {
+			tokRange := Position{Line: tok.line, Character: tok.char}.Range(tok.length)
+			assert.True(t, tokRange.Overlaps(r), "token %v is outside %v", tokRange, r)
+		}
+	})
+
+	t.Run("re-encodes from a later line", func(t *testing.T) {
+		got, err := FilterSemanticTokensByRange(full, rng(2, 6, 9, 0))
+		require.NoError(t, err)
+		assert.Equal(t, []uint32{
+			2, 5, 4, 1, 1,
+			2, 1, 3, 1, 3,
+		}, got)
+	})
+
+	t.Run("touching tokens dropped", func(t *testing.T) {
+		got, err := FilterSemanticTokensByRange(full, rng(0, 7, 2, 0))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("whole document", func(t *testing.T) {
+		got, err := FilterSemanticTokensByRange(full, rng(0, 0, 100, 0))
+		require.NoError(t, err)
+		assert.Equal(t, full, got)
+	})
+
+	t.Run("truncated data", func(t *testing.T) {
+		_, err := FilterSemanticTokensByRange(full[:7], rng(0, 0, 100, 0))
+		require.ErrorContains(t, err, "not a multiple of 5")
+	})
+}
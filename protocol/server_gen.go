@@ -106,6 +106,133 @@ const (
 	MethodWorkspaceWorkspaceFolders = "workspace/workspaceFolders"
 )
 
+// MethodsWithPartialResult holds the requests whose results may be
+// streamed in chunks via $/progress when the client sends a
+// partialResultToken.
+var MethodsWithPartialResult = map[string]bool{
+	MethodCallHierarchyIncomingCalls: true,
+	MethodCallHierarchyOutgoingCalls: true,
+	MethodTextDocumentCodeAction: true,
+	MethodTextDocumentCodeLens: true,
+	MethodTextDocumentColorPresentation: true,
+	MethodTextDocumentCompletion: true,
+	MethodTextDocumentDeclaration: true,
+	MethodTextDocumentDefinition: true,
+	MethodTextDocumentDiagnostic: true,
+	MethodTextDocumentDocumentColor: true,
+	MethodTextDocumentDocumentHighlight: true,
+	MethodTextDocumentDocumentLink: true,
+	MethodTextDocumentDocumentSymbol: true,
+	MethodTextDocumentFoldingRange: true,
+	MethodTextDocumentImplementation: true,
+	MethodTextDocumentMoniker: true,
+	MethodTextDocumentReferences: true,
+	MethodTextDocumentSelectionRange: true,
+	MethodTextDocumentSemanticTokensFull: true,
+	MethodTextDocumentSemanticTokensFullDelta: true,
+	MethodTextDocumentSemanticTokensRange: true,
+	MethodTextDocumentTypeDefinition: true,
+	MethodTypeHierarchySubtypes: true,
+	MethodTypeHierarchySupertypes: true,
+	MethodWorkspaceDiagnostic: true,
+	MethodWorkspaceSymbol: true,
+}
+
+// MethodsWithWorkDoneProgress holds the requests whose params accept a
+// workDoneToken for reporting work done progress.
+var MethodsWithWorkDoneProgress = map[string]bool{
+	MethodCallHierarchyIncomingCalls: true,
+	MethodCallHierarchyOutgoingCalls: true,
+	MethodInitialize: true,
+	MethodTextDocumentCodeAction: true,
+	MethodTextDocumentCodeLens: true,
+	MethodTextDocumentColorPresentation: true,
+	MethodTextDocumentCompletion: true,
+	MethodTextDocumentDeclaration: true,
+	MethodTextDocumentDefinition: true,
+	MethodTextDocumentDiagnostic: true,
+	MethodTextDocumentDocumentColor: true,
+	MethodTextDocumentDocumentHighlight: true,
+	MethodTextDocumentDocumentLink: true,
+	MethodTextDocumentDocumentSymbol: true,
+	MethodTextDocumentFoldingRange: true,
+	MethodTextDocumentFormatting: true,
+	MethodTextDocumentHover: true,
+	MethodTextDocumentImplementation: true,
+	MethodTextDocumentInlayHint: true,
+	MethodTextDocumentInlineValue: true,
+	MethodTextDocumentLinkedEditingRange: true,
+	MethodTextDocumentMoniker: true,
+	MethodTextDocumentPrepareCallHierarchy: true,
+	MethodTextDocumentPrepareRename: true,
+	MethodTextDocumentPrepareTypeHierarchy: true,
+	MethodTextDocumentRangeFormatting: true,
+	MethodTextDocumentReferences: true,
+	MethodTextDocumentRename: true,
+	MethodTextDocumentSelectionRange: true,
+	MethodTextDocumentSemanticTokensFull: true,
+	MethodTextDocumentSemanticTokensFullDelta: true,
+	MethodTextDocumentSemanticTokensRange: true,
+	MethodTextDocumentSignatureHelp: true,
+	MethodTextDocumentTypeDefinition: true,
+	MethodTypeHierarchySubtypes: true,
+	MethodTypeHierarchySupertypes: true,
+	MethodWorkspaceDiagnostic: true,
+	MethodWorkspaceExecuteCommand: true,
+	MethodWorkspaceSymbol: true,
+}
+
+// methodDynamicRegistration maps the methods a server may register via
+// client/registerCapability to the dotted path of the client capability
+// whose dynamicRegistration property allows it.
+var methodDynamicRegistration = map[string]string{
+	"notebookDocument/sync": "notebookDocument.synchronization",
+	MethodTextDocumentCodeAction: "textDocument.codeAction",
+	MethodTextDocumentCodeLens: "textDocument.codeLens",
+	MethodTextDocumentCompletion: "textDocument.completion",
+	MethodTextDocumentDeclaration: "textDocument.declaration",
+	MethodTextDocumentDefinition: "textDocument.definition",
+	MethodTextDocumentDiagnostic: "textDocument.diagnostic",
+	MethodTextDocumentDidChange: "textDocument.synchronization",
+	MethodTextDocumentDidClose: "textDocument.synchronization",
+	MethodTextDocumentDidOpen: "textDocument.synchronization",
+	MethodTextDocumentDidSave: "textDocument.synchronization",
+	MethodTextDocumentDocumentColor: "textDocument.colorProvider",
+	MethodTextDocumentDocumentHighlight: "textDocument.documentHighlight",
+	MethodTextDocumentDocumentLink: "textDocument.documentLink",
+	MethodTextDocumentDocumentSymbol: "textDocument.documentSymbol",
+	MethodTextDocumentFoldingRange: "textDocument.foldingRange",
+	MethodTextDocumentFormatting: "textDocument.formatting",
+	MethodTextDocumentHover: "textDocument.hover",
+	MethodTextDocumentImplementation: "textDocument.implementation",
+	MethodTextDocumentInlayHint: "textDocument.inlayHint",
+	MethodTextDocumentInlineValue: "textDocument.inlineValue",
+	MethodTextDocumentLinkedEditingRange: "textDocument.linkedEditingRange",
+	MethodTextDocumentMoniker: "textDocument.moniker",
+	MethodTextDocumentOnTypeFormatting: "textDocument.onTypeFormatting",
+	MethodTextDocumentPrepareCallHierarchy: "textDocument.callHierarchy",
+	MethodTextDocumentPrepareTypeHierarchy: "textDocument.typeHierarchy",
+	MethodTextDocumentRangeFormatting: "textDocument.rangeFormatting",
+	MethodTextDocumentReferences: "textDocument.references",
+	MethodTextDocumentRename: "textDocument.rename",
+	MethodTextDocumentSelectionRange: "textDocument.selectionRange",
+	"textDocument/semanticTokens": "textDocument.semanticTokens",
+	MethodTextDocumentSignatureHelp: "textDocument.signatureHelp",
+	MethodTextDocumentTypeDefinition: "textDocument.typeDefinition",
+	MethodTextDocumentWillSave: "textDocument.synchronization",
+	MethodTextDocumentWillSaveWaitUntil: "textDocument.synchronization",
+	MethodWorkspaceDidChangeConfiguration: "workspace.didChangeConfiguration",
+	MethodWorkspaceDidChangeWatchedFiles: "workspace.didChangeWatchedFiles",
+	MethodWorkspaceDidCreateFiles: "workspace.fileOperations",
+	MethodWorkspaceDidDeleteFiles: "workspace.fileOperations",
+	MethodWorkspaceDidRenameFiles: "workspace.fileOperations",
+	MethodWorkspaceExecuteCommand: "workspace.executeCommand",
+	MethodWorkspaceSymbol: "workspace.symbol",
+	MethodWorkspaceWillCreateFiles: "workspace.fileOperations",
+	MethodWorkspaceWillDeleteFiles: "workspace.fileOperations",
+	MethodWorkspaceWillRenameFiles: "workspace.fileOperations",
+}
+
 // Server defines the interface for an LSP server.
 // All methods correspond to LSP requests and notifications
 // directed from client to server.
@@ -177,7 +304,7 @@ type Server interface {
 	// is the exit event.
 	Shutdown(ctx context.Context) (any, error)
 	// A request to provide commands for the given text document and range.
-	CodeAction(ctx context.Context, params *CodeActionParams) ([]any, error)
+	CodeAction(ctx context.Context, params *CodeActionParams) ([]OrCommandCodeAction, error)
 	// A request to provide code lens for the given text document.
 	CodeLens(ctx context.Context, params *CodeLensParams) ([]CodeLens, error)
 	// A request to list all presentation for a color. The request's
@@ -194,17 +321,17 @@ type Server interface {
 	// and {@link CompletionItem.documentation `documentation`} properties to the `completionItem/resolve`
 	// request. However, properties that are needed for the initial sorting and filtering, like `sortText`,
 	// `filterText`, `insertText`, and `textEdit`, must not be changed during resolve.
-	Completion(ctx context.Context, params *CompletionParams) (any, error)
+	Completion(ctx context.Context, params *CompletionParams) (*OrCompletionItemArrayCompletionList, error)
 	// A request to resolve the type definition locations of a symbol at a given text
 	// document position. The request's parameter is of type {@link TextDocumentPositionParams}
 	// the response is of type {@link Declaration} or a typed array of {@link DeclarationLink}
 	// or a Thenable that resolves to such.
-	Declaration(ctx context.Context, params *DeclarationParams) (any, error)
+	Declaration(ctx context.Context, params *DeclarationParams) (*OrDeclarationDeclarationLinkArray, error)
 	// A request to resolve the definition location of a symbol at a given text
 	// document position. The request's parameter is of type {@link TextDocumentPosition}
 	// the response is of either type {@link Definition} or a typed array of
 	// {@link DefinitionLink} or a Thenable that resolves to such.
-	Definition(ctx context.Context, params *DefinitionParams) (any, error)
+	Definition(ctx context.Context, params *DefinitionParams) (*OrDefinitionDefinitionLinkArray, error)
 	// The document diagnostic request definition.
 	// 
 	// @since 3.17.0
@@ -248,7 +375,7 @@ type Server interface {
 	// parameter is of type {@link TextDocumentIdentifier} the
 	// response is of type {@link SymbolInformation SymbolInformation[]} or a Thenable
 	// that resolves to such.
-	DocumentSymbol(ctx context.Context, params *DocumentSymbolParams) (any, error)
+	DocumentSymbol(ctx context.Context, params *DocumentSymbolParams) (*OrSymbolInformationArrayDocumentSymbolArray, error)
 	// A request to provide folding ranges in a document. The request's
 	// parameter is of type {@link FoldingRangeParams}, the
 	// response is of type {@link FoldingRangeList} or a Thenable
@@ -263,7 +390,7 @@ type Server interface {
 	// A request to resolve the implementation locations of a symbol at a given text
 	// document position. The request's parameter is of type {@link TextDocumentPositionParams}
 	// the response is of type {@link Definition} or a Thenable that resolves to such.
-	Implementation(ctx context.Context, params *ImplementationParams) (any, error)
+	Implementation(ctx context.Context, params *ImplementationParams) (*OrDefinitionDefinitionLinkArray, error)
 	// A request to provide inlay hints in a document. The request's parameter is of
 	// type {@link InlayHintsParams}, the response is of type
 	// {@link InlayHint InlayHint[]} or a Thenable that resolves to such.
@@ -317,7 +444,7 @@ type Server interface {
 	// @since 3.16.0
 	SemanticTokensFull(ctx context.Context, params *SemanticTokensParams) (*SemanticTokens, error)
 	// @since 3.16.0
-	SemanticTokensFullDelta(ctx context.Context, params *SemanticTokensDeltaParams) (any, error)
+	SemanticTokensFullDelta(ctx context.Context, params *SemanticTokensDeltaParams) (*OrSemanticTokensSemanticTokensDelta, error)
 	// @since 3.16.0
 	SemanticTokensRange(ctx context.Context, params *SemanticTokensRangeParams) (*SemanticTokens, error)
 	// SignatureHelp handles the "textDocument/signatureHelp" method.
@@ -325,7 +452,7 @@ type Server interface {
 	// A request to resolve the type definition locations of a symbol at a given text
 	// document position. The request's parameter is of type {@link TextDocumentPositionParams}
 	// the response is of type {@link Definition} or a Thenable that resolves to such.
-	TypeDefinition(ctx context.Context, params *TypeDefinitionParams) (any, error)
+	TypeDefinition(ctx context.Context, params *TypeDefinitionParams) (*OrDefinitionDefinitionLinkArray, error)
 	// A document will save notification is sent from the client to the server before
 	// the document is actually saved.
 	WillSave(ctx context.Context, params *WillSaveTextDocumentParams) error
@@ -387,7 +514,7 @@ type Server interface {
 	// @since 3.17.0 - support for WorkspaceSymbol in the returned data. Clients
 	// need to advertise support for WorkspaceSymbols via the client capability
 	// `workspace.symbol.resolveSupport`.
-	Symbols(ctx context.Context, params *WorkspaceSymbolParams) (any, error)
+	Symbols(ctx context.Context, params *WorkspaceSymbolParams) (*OrSymbolInformationArrayWorkspaceSymbolArray, error)
 	// The will create files request is sent from the client to the server before files are actually
 	// created as long as the creation is triggered from within the client.
 	// 
@@ -415,7 +542,8 @@ type Server interface {
 
 	// Request is a catch-all handler for any LSP method not covered by the
 	// interface above.  The method string is the raw LSP method name and
-	// params is the already-decoded parameter value.
+	// params is the already-decoded parameter value: a pointer to the type
+	// registered via RegisterCustomMethod, or a plain decoded any otherwise.
 	Request(ctx context.Context, method string, params any) (any, error)
 }
 
@@ -898,11 +1026,9 @@ func serverDispatch(ctx context.Context, server Server, reply jsonrpc2.Replier,
 		result, err := server.WorkspaceSymbolResolve(ctx, &params)
 		return reply(ctx, result, err)
 	default:
-		var params any
-		if req.Params() != nil {
-			if err := json.Unmarshal(req.Params(), &params); err != nil {
-				return replyParseError(ctx, reply, err)
-			}
+		params, err := decodeCustomParams(req.Method(), req.Params())
+		if err != nil {
+			return replyParseError(ctx, reply, err)
 		}
 		resp, err := server.Request(ctx, req.Method(), params)
 		return reply(ctx, resp, err)
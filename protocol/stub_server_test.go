@@ -17,6 +17,12 @@ type stubServer struct {
 	shutdownCalled   bool
 	requestCalled    bool
 	requestMethod    string
+	requestParams    any
+
+	// hoverErr, if set, is returned by Hover.
+	hoverErr error
+	// hoverHook, if set, is called by Hover with its context.
+	hoverHook func(ctx context.Context)
 }
 
 func (s *stubServer) CancelRequest(_ context.Context, _ *CancelParams) error { return nil }
@@ -107,7 +113,7 @@ func (s *stubServer) Shutdown(_ context.Context) (any, error) {
 	return nil, nil
 }
 
-func (s *stubServer) CodeAction(_ context.Context, _ *CodeActionParams) ([]any, error) {
+func (s *stubServer) CodeAction(_ context.Context, _ *CodeActionParams) ([]OrCommandCodeAction, error) {
 	return nil, nil
 }
 
@@ -122,15 +128,15 @@ func (s *stubServer) ColorPresentation(
 	return nil, nil
 }
 
-func (s *stubServer) Completion(_ context.Context, _ *CompletionParams) (any, error) {
+func (s *stubServer) Completion(_ context.Context, _ *CompletionParams) (*OrCompletionItemArrayCompletionList, error) {
 	return nil, nil
 }
 
-func (s *stubServer) Declaration(_ context.Context, _ *DeclarationParams) (any, error) {
+func (s *stubServer) Declaration(_ context.Context, _ *DeclarationParams) (*OrDeclarationDeclarationLinkArray, error) {
 	return nil, nil
 }
 
-func (s *stubServer) Definition(_ context.Context, _ *DefinitionParams) (any, error) {
+func (s *stubServer) Definition(_ context.Context, _ *DefinitionParams) (*OrDefinitionDefinitionLinkArray, error) {
 	return nil, nil
 }
 
@@ -138,7 +144,7 @@ func (s *stubServer) Diagnostic(
 	_ context.Context,
 	_ *DocumentDiagnosticParams,
 ) (DocumentDiagnosticReport, error) {
-	return nil, fmt.Errorf("not implemented")
+	return DocumentDiagnosticReport{}, fmt.Errorf("not implemented")
 }
 
 func (s *stubServer) DidChange(_ context.Context, _ *DidChangeTextDocumentParams) error {
@@ -179,7 +185,7 @@ func (s *stubServer) DocumentLink(
 	return nil, nil
 }
 
-func (s *stubServer) DocumentSymbol(_ context.Context, _ *DocumentSymbolParams) (any, error) {
+func (s *stubServer) DocumentSymbol(_ context.Context, _ *DocumentSymbolParams) (*OrSymbolInformationArrayDocumentSymbolArray, error) {
 	return nil, nil
 }
 
@@ -197,10 +203,16 @@ func (s *stubServer) Formatting(
 	return nil, nil
 }
 
-func (s *stubServer) Hover(_ context.Context, params *HoverParams) (*Hover, error) {
+func (s *stubServer) Hover(ctx context.Context, params *HoverParams) (*Hover, error) {
 	s.hoverCalled = true
+	if s.hoverHook != nil {
+		s.hoverHook(ctx)
+	}
+	if s.hoverErr != nil {
+		return nil, s.hoverErr
+	}
 	return &Hover{
-		Contents: "hello",
+		Contents: NewMarkedStringHoverContents(markedText("hello")),
 		Range: &Range{
 			Start: params.Position,
 			End:   params.Position,
@@ -208,7 +220,7 @@ func (s *stubServer) Hover(_ context.Context, params *HoverParams) (*Hover, erro
 	}, nil
 }
 
-func (s *stubServer) Implementation(_ context.Context, _ *ImplementationParams) (any, error) {
+func (s *stubServer) Implementation(_ context.Context, _ *ImplementationParams) (*OrDefinitionDefinitionLinkArray, error) {
 	return nil, nil
 }
 
@@ -291,7 +303,7 @@ func (s *stubServer) SemanticTokensFull(
 func (s *stubServer) SemanticTokensFullDelta(
 	_ context.Context,
 	_ *SemanticTokensDeltaParams,
-) (any, error) {
+) (*OrSemanticTokensSemanticTokensDelta, error) {
 	return nil, nil
 }
 
@@ -309,7 +321,7 @@ func (s *stubServer) SignatureHelp(
 	return nil, nil
 }
 
-func (s *stubServer) TypeDefinition(_ context.Context, _ *TypeDefinitionParams) (any, error) {
+func (s *stubServer) TypeDefinition(_ context.Context, _ *TypeDefinitionParams) (*OrDefinitionDefinitionLinkArray, error) {
 	return nil, nil
 }
 
@@ -389,7 +401,7 @@ func (s *stubServer) ExecuteCommand(_ context.Context, _ *ExecuteCommandParams)
 	return nil, nil
 }
 
-func (s *stubServer) Symbols(_ context.Context, _ *WorkspaceSymbolParams) (any, error) {
+func (s *stubServer) Symbols(_ context.Context, _ *WorkspaceSymbolParams) (*OrSymbolInformationArrayWorkspaceSymbolArray, error) {
 	return nil, nil
 }
 
@@ -421,9 +433,10 @@ func (s *stubServer) WorkspaceSymbolResolve(
 	return params, nil
 }
 
-func (s *stubServer) Request(_ context.Context, method string, _ any) (any, error) {
+func (s *stubServer) Request(_ context.Context, method string, params any) (any, error) {
 	s.requestCalled = true
 	s.requestMethod = method
+	s.requestParams = params
 	return map[string]string{"echo": method}, nil
 }
 
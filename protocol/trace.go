@@ -0,0 +1,143 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file implements message tracing: a jsonrpc2.Stream wrapper that reports
+// every message read from or written to the connection to a TraceHook, and a
+// TraceHook that records an NDJSON transcript of the session.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.lsp.dev/jsonrpc2"
+)
+
+// TraceDirection tells whether a traced message was received or sent.
+type TraceDirection string
+
+const (
+	// TraceInbound marks a message read from the peer.
+	TraceInbound TraceDirection = "in"
+	// TraceOutbound marks a message written to the peer.
+	TraceOutbound TraceDirection = "out"
+)
+
+type (
+	// TraceEvent describes a single message crossing the connection.
+	TraceEvent struct {
+		Direction TraceDirection
+		Time      time.Time
+		Message   jsonrpc2.Message
+	}
+
+	// TraceHook is called for every message passing through a stream wrapped
+	// by TraceStream. Hooks are called from the connection's read loop and
+	// from writers concurrently, so they must be safe for concurrent use and
+	// should not block.
+	TraceHook func(ctx context.Context, ev TraceEvent)
+
+	// traceStream is the jsonrpc2.Stream returned by TraceStream.
+	traceStream struct {
+		jsonrpc2.Stream
+
+		hook TraceHook
+	}
+)
+
+// TraceStream wraps stream so that hook observes every message read from or
+// written to it:
+//
+//	stream := protocol.TraceStream(jsonrpc2.NewStream(rwc), hook)
+//	conn := jsonrpc2.NewConn(stream)
+//
+// Outbound messages are reported before they are written.
+func TraceStream(stream jsonrpc2.Stream, hook TraceHook) jsonrpc2.Stream { //nolint:ireturn
+	return &traceStream{Stream: stream, hook: hook}
+}
+
+// Read implements jsonrpc2.Stream.
+func (s *traceStream) Read(ctx context.Context) (jsonrpc2.Message, int64, error) {
+	msg, n, err := s.Stream.Read(ctx)
+	if err == nil {
+		s.hook(ctx, TraceEvent{Direction: TraceInbound, Time: time.Now(), Message: msg})
+	}
+
+	return msg, n, err //nolint:wrapcheck
+}
+
+// Write implements jsonrpc2.Stream.
+func (s *traceStream) Write(ctx context.Context, msg jsonrpc2.Message) (int64, error) {
+	s.hook(ctx, TraceEvent{Direction: TraceOutbound, Time: time.Now(), Message: msg})
+
+	return s.Stream.Write(ctx, msg) //nolint:wrapcheck
+}
+
+// transcriptRecord is one line of the transcript written by NewTranscriptHook.
+type transcriptRecord struct {
+	Direction TraceDirection  `json:"direction"`
+	Time      time.Time       `json:"time"`
+	Method    string          `json:"method,omitempty"`
+	ID        *jsonrpc2.ID    `json:"id,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// NewTranscriptHook returns a TraceHook that writes one JSON object per
+// message to w (NDJSON), with the fields direction, time, method, id and
+// payload, the latter holding the complete JSON-RPC message. Responses carry
+// the method of the request they answer. Writes are serialized, so w need
+// not be safe for concurrent use; write errors are ignored.
+//
+// The transcript is meant for machine analysis of interop issues; use a
+// Logger for human-readable events.
+func NewTranscriptHook(w io.Writer) TraceHook {
+	var (
+		mu sync.Mutex
+		// pending maps "<direction of the call> <id>" to the call's method
+		// until its response is seen.
+		pending = make(map[string]string)
+	)
+
+	return func(_ context.Context, ev TraceEvent) {
+		payload, err := json.Marshal(ev.Message)
+		if err != nil {
+			return
+		}
+
+		rec := transcriptRecord{Direction: ev.Direction, Time: ev.Time, Payload: payload} //nolint:exhaustruct
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch msg := ev.Message.(type) {
+		case *jsonrpc2.Call:
+			id := msg.ID()
+			rec.Method, rec.ID = msg.Method(), &id
+			pending[fmt.Sprintf("%s %q", ev.Direction, id)] = msg.Method()
+		case *jsonrpc2.Notification:
+			rec.Method = msg.Method()
+		case *jsonrpc2.Response:
+			callDir := TraceInbound
+			if ev.Direction == TraceInbound {
+				callDir = TraceOutbound
+			}
+
+			id := msg.ID()
+			key := fmt.Sprintf("%s %q", callDir, id)
+			rec.Method, rec.ID = pending[key], &id
+			delete(pending, key)
+		}
+
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return
+		}
+
+		_, _ = w.Write(append(line, '\n'))
+	}
+}
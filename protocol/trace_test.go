@@ -0,0 +1,81 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/jsonrpc2"
+)
+
+func TestTranscriptHook(t *testing.T) {
+	var transcript bytes.Buffer
+
+	clientPipe, serverPipe := net.Pipe()
+
+	serverConn := jsonrpc2.NewConn(TraceStream(jsonrpc2.NewStream(serverPipe), NewTranscriptHook(&transcript)))
+	serverConn.Go(context.Background(), ServerHandler(&stubServer{}, nil))
+
+	clientConn := jsonrpc2.NewConn(jsonrpc2.NewStream(clientPipe))
+	clientConn.Go(context.Background(), jsonrpc2.MethodNotFoundHandler)
+
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+		<-clientConn.Done()
+		<-serverConn.Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var hover Hover
+	_, err := clientConn.Call(ctx, MethodTextDocumentHover, HoverParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///test.go"},
+		Position:     Position{Line: 1, Character: 5},
+	}, &hover)
+	require.NoError(t, err)
+
+	type record struct {
+		Direction TraceDirection  `json:"direction"`
+		Time      time.Time       `json:"time"`
+		Method    string          `json:"method"`
+		ID        json.RawMessage `json:"id"`
+		Payload   map[string]any  `json:"payload"`
+	}
+
+	var records []record
+
+	scanner := bufio.NewScanner(&transcript)
+	for scanner.Scan() {
+		var rec record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec), "line %q", scanner.Text())
+		records = append(records, rec)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, 2)
+
+	req, resp := records[0], records[1]
+
+	assert.Equal(t, TraceInbound, req.Direction)
+	assert.Equal(t, MethodTextDocumentHover, req.Method)
+	assert.NotEmpty(t, req.ID)
+	assert.Equal(t, MethodTextDocumentHover, req.Payload["method"])
+	assert.NotNil(t, req.Payload["params"])
+	assert.False(t, req.Time.IsZero())
+
+	assert.Equal(t, TraceOutbound, resp.Direction)
+	assert.Equal(t, MethodTextDocumentHover, resp.Method, "responses carry the request's method")
+	assert.JSONEq(t, string(req.ID), string(resp.ID))
+	assert.Contains(t, resp.Payload, "result")
+	assert.False(t, resp.Time.Before(req.Time))
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for type hierarchy items. A server answers
+// textDocument/prepareTypeHierarchy with items carrying opaque Data, and the
+// client sends them back unchanged in typeHierarchy/supertypes and
+// typeHierarchy/subtypes so the server can resolve the chain lazily.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// NewTypeHierarchyItem returns a TypeHierarchyItem for the symbol name of
+// the given kind declared in uri. r encloses the whole declaration and selR,
+// which must be contained in r, covers the part revealed when the item is
+// picked, typically the name.
+func NewTypeHierarchyItem(name string, kind SymbolKind, uri DocumentURI, r, selR Range) TypeHierarchyItem {
+	return TypeHierarchyItem{ //nolint:exhaustruct
+		Name:           name,
+		Kind:           kind,
+		URI:            uri,
+		Range:          r,
+		SelectionRange: selR,
+	}
+}
+
+// SetData stashes v in the item's Data so it is returned to the server in
+// the supertypes and subtypes requests. v must be JSON-serializable.
+func (item *TypeHierarchyItem) SetData(v any) {
+	item.Data = &v
+}
+
+// DecodeData decodes the item's Data into v, which must be a pointer. It
+// works both on items built in Go and on items decoded from JSON, where Data
+// holds a map[string]any.
+func (item TypeHierarchyItem) DecodeData(v any) error {
+	return decodeData(item.Data, v)
+}
+
+// decodeData converts an LSPAny data entry into v through its JSON form.
+func decodeData(data *LSPAny, v any) error {
+	if data == nil || *data == nil {
+		return errors.New("no data") //nolint:err113
+	}
+
+	raw, err := json.Marshal(*data)
+	if err != nil {
+		return fmt.Errorf("encode data: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil { //nolint:noinlineerr
+		return fmt.Errorf("decode data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTypeHierarchyItem(t *testing.T) {
+	r := Range{Start: Position{Line: 4, Character: 0}, End: Position{Line: 9, Character: 1}}
+	sel := Range{Start: Position{Line: 4, Character: 5}, End: Position{Line: 4, Character: 11}}
+
+	item := NewTypeHierarchyItem("Reader", SymbolKindInterface, "file:///io.go", r, sel)
+
+	assert.Equal(t, "Reader", item.Name)
+	assert.Equal(t, SymbolKindInterface, item.Kind)
+	assert.Equal(t, DocumentURI("file:///io.go"), item.URI)
+	assert.Equal(t, r, item.Range)
+	assert.Equal(t, sel, item.SelectionRange)
+	assert.Nil(t, item.Data)
+}
+
+func TestTypeHierarchyItem_Data(t *testing.T) {
+	type symbolRef struct {
+		Package string `json:"package"`
+		Offset  int    `json:"offset"`
+	}
+
+	item := NewTypeHierarchyItem("Reader", SymbolKindInterface, "file:///io.go", Range{}, Range{})
+	item.SetData(symbolRef{Package: "io", Offset: 312})
+
+	var direct symbolRef
+	require.NoError(t, item.DecodeData(&direct))
+	assert.Equal(t, symbolRef{Package: "io", Offset: 312}, direct)
+
+	raw, err := json.Marshal(item)
+	require.NoError(t, err)
+
+	var echoed TypeHierarchyItem
+	require.NoError(t, json.Unmarshal(raw, &echoed))
+	require.NotNil(t, echoed.Data)
+	assert.IsType(t, map[string]any{}, *echoed.Data)
+
+	var ref symbolRef
+	require.NoError(t, echoed.DecodeData(&ref))
+	assert.Equal(t, symbolRef{Package: "io", Offset: 312}, ref)
+
+	require.Error(t, NewTypeHierarchyItem("T", SymbolKindClass, "", Range{}, Range{}).DecodeData(&ref))
+}
@@ -7,7 +7,9 @@
 package protocol
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 )
 
 // ImplementationParams is an LSP type.
@@ -406,9 +408,9 @@ type SemanticTokensRegistrationOptions struct {
 	Legend SemanticTokensLegend `json:"legend"`
 	// Server supports providing semantic tokens for a specific range
 	// of a document.
-	Range any `json:"range,omitempty"`
+	Range *BoolOrOptions[SemanticTokensOptionsRange] `json:"range,omitempty"`
 	// Server supports providing semantic tokens for a full document.
-	Full any `json:"full,omitempty"`
+	Full *BoolOrOptions[SemanticTokensFullDelta] `json:"full,omitempty"`
 	WorkDoneProgress *bool `json:"workDoneProgress,omitempty"`
 	// The id used to register the request. The id can be used to deregister
 	// the request again. See also Registration#id.
@@ -552,7 +554,7 @@ type WorkspaceEdit struct {
 	// 
 	// If a client neither supports `documentChanges` nor `workspace.workspaceEdit.resourceOperations` then
 	// only plain `TextEdit`s using the `changes` property are supported.
-	DocumentChanges []any `json:"documentChanges,omitempty"`
+	DocumentChanges []OrTextDocumentEditCreateFileRenameFileDeleteFile `json:"documentChanges,omitempty"`
 	// A map of change annotations that can be referenced in `AnnotatedTextEdit`s or create, rename and
 	// delete file / folder operations.
 	// 
@@ -753,7 +755,7 @@ type InlayHint struct {
 	// InlayHintLabelPart label parts.
 	// 
 	// *Note* that neither the string nor the label part can be empty.
-	Label any `json:"label"`
+	Label OrStringInlayHintLabelPartArray `json:"label"`
 	// The kind of this hint. Can be omitted in which case the client
 	// should fall back to a reasonable default.
 	Kind *InlayHintKind `json:"kind,omitempty"`
@@ -764,7 +766,7 @@ type InlayHint struct {
 	// hint itself is now obsolete.
 	TextEdits []TextEdit `json:"textEdits,omitempty"`
 	// The tooltip text when you hover over this item.
-	Tooltip any `json:"tooltip,omitempty"`
+	Tooltip *OrStringMarkupContent `json:"tooltip,omitempty"`
 	// Render padding before the hint.
 	// 
 	// Note: Padding should use the editor's background color, not the
@@ -819,7 +821,7 @@ type DocumentDiagnosticParams struct {
 // 
 // @since 3.17.0
 type DocumentDiagnosticReportPartialResult struct {
-	RelatedDocuments map[DocumentURI]any `json:"relatedDocuments"`
+	RelatedDocuments map[DocumentURI]OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport `json:"relatedDocuments"`
 }
 
 // Cancellation data returned from a diagnostic request.
@@ -898,7 +900,7 @@ type DidOpenNotebookDocumentParams struct {
 // @since 3.17.0
 type NotebookDocumentSyncRegistrationOptions struct {
 	// The notebooks to be synced
-	NotebookSelector []any `json:"notebookSelector"`
+	NotebookSelector []OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells `json:"notebookSelector"`
 	// Whether save notification should be forwarded to
 	// the server. Will only be honored if mode === `notebook`.
 	Save *bool `json:"save,omitempty"`
@@ -1043,7 +1045,7 @@ type DidChangeConfigurationParams struct {
 
 // DidChangeConfigurationRegistrationOptions is an LSP type.
 type DidChangeConfigurationRegistrationOptions struct {
-	Section any `json:"section,omitempty"`
+	Section *OrStringStringArray `json:"section,omitempty"`
 }
 
 // The parameters of a notification message.
@@ -1221,7 +1223,7 @@ type CompletionItem struct {
 	// about this item, like type or symbol information.
 	Detail *string `json:"detail,omitempty"`
 	// A human-readable string that represents a doc-comment.
-	Documentation any `json:"documentation,omitempty"`
+	Documentation *OrStringMarkupContent `json:"documentation,omitempty"`
 	// Indicates if this item is deprecated.
 	// @deprecated Use `tags` instead.
 	Deprecated *bool `json:"deprecated,omitempty"`
@@ -1284,7 +1286,7 @@ type CompletionItem struct {
 	// contained and starting at the same position.
 	// 
 	// @since 3.16.0 additional type `InsertReplaceEdit`
-	TextEdit any `json:"textEdit,omitempty"`
+	TextEdit *OrTextEditInsertReplaceEdit `json:"textEdit,omitempty"`
 	// The edit text used if the completion item is part of a CompletionList and
 	// CompletionList defines an item default for the text edit range.
 	// 
@@ -1410,7 +1412,7 @@ type HoverParams struct {
 // The result of a hover request.
 type Hover struct {
 	// The hover's content
-	Contents any `json:"contents"`
+	Contents HoverContents `json:"contents"`
 	// An optional range inside the text document that is used to
 	// visualize the hover, e.g. by changing the background color.
 	Range *Range `json:"range,omitempty"`
@@ -1782,7 +1784,7 @@ type WorkspaceSymbol struct {
 	// capability `workspace.symbol.resolveSupport`.
 	// 
 	// See SymbolInformation#location for more details.
-	Location any `json:"location"`
+	Location OrLocationLocationUriOnly `json:"location"`
 	// A data entry field that is preserved on a workspace symbol between a
 	// workspace symbol request and a workspace symbol resolve request.
 	Data *LSPAny `json:"data,omitempty"`
@@ -2105,7 +2107,7 @@ type LogTraceParams struct {
 // CancelParams is an LSP type.
 type CancelParams struct {
 	// The request id to cancel.
-	ID any `json:"id"`
+	ID OrInt32String `json:"id"`
 }
 
 // ProgressParams is an LSP type.
@@ -2297,9 +2299,9 @@ type SemanticTokensOptions struct {
 	Legend SemanticTokensLegend `json:"legend"`
 	// Server supports providing semantic tokens for a specific range
 	// of a document.
-	Range any `json:"range,omitempty"`
+	Range *BoolOrOptions[SemanticTokensOptionsRange] `json:"range,omitempty"`
 	// Server supports providing semantic tokens for a full document.
-	Full any `json:"full,omitempty"`
+	Full *BoolOrOptions[SemanticTokensFullDelta] `json:"full,omitempty"`
 	WorkDoneProgress *bool `json:"workDoneProgress,omitempty"`
 }
 
@@ -2340,7 +2342,7 @@ type TextDocumentEdit struct {
 	// 
 	// @since 3.18.0 - support for SnippetTextEdit. This is guarded using a
 	// client capability.
-	Edits []any `json:"edits"`
+	Edits []OrTextEditAnnotatedTextEdit `json:"edits"`
 }
 
 // Create file operation.
@@ -2507,7 +2509,7 @@ type InlayHintLabelPart struct {
 	// The tooltip text when you hover over this label part. Depending on
 	// the client capability `inlayHint.resolveSupport` clients might resolve
 	// this property late using the resolve request.
-	Tooltip any `json:"tooltip,omitempty"`
+	Tooltip *OrStringMarkupContent `json:"tooltip,omitempty"`
 	// An optional source code location that represents this
 	// label part.
 	// 
@@ -2577,7 +2579,7 @@ type RelatedFullDocumentDiagnosticReport struct {
 	// a.cpp and result in errors in a header file b.hpp.
 	// 
 	// @since 3.17.0
-	RelatedDocuments map[DocumentURI]any `json:"relatedDocuments,omitempty"`
+	RelatedDocuments map[DocumentURI]OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport `json:"relatedDocuments,omitempty"`
 	// A full document diagnostic report.
 	Kind string `json:"kind"`
 	// An optional result id. If provided it will
@@ -2599,7 +2601,7 @@ type RelatedUnchangedDocumentDiagnosticReport struct {
 	// a.cpp and result in errors in a header file b.hpp.
 	// 
 	// @since 3.17.0
-	RelatedDocuments map[DocumentURI]any `json:"relatedDocuments,omitempty"`
+	RelatedDocuments map[DocumentURI]OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport `json:"relatedDocuments,omitempty"`
 	// A document diagnostic report indicating
 	// no changes to the last result. A server can
 	// only return `unchanged` if result ids are
@@ -2716,7 +2718,7 @@ type TextDocumentItem struct {
 // @since 3.17.0
 type NotebookDocumentSyncOptions struct {
 	// The notebooks to be synced
-	NotebookSelector []any `json:"notebookSelector"`
+	NotebookSelector []OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells `json:"notebookSelector"`
 	// Whether save notification should be forwarded to
 	// the server. Will only be honored if mode === `notebook`.
 	Save *bool `json:"save,omitempty"`
@@ -2842,91 +2844,91 @@ type ServerCapabilities struct {
 	// Defines how text documents are synced. Is either a detailed structure
 	// defining each notification or for backwards compatibility the
 	// TextDocumentSyncKind number.
-	TextDocumentSync any `json:"textDocumentSync,omitempty"`
+	TextDocumentSync *OrTextDocumentSyncOptionsTextDocumentSyncKind `json:"textDocumentSync,omitempty"`
 	// Defines how notebook documents are synced.
 	// 
 	// @since 3.17.0
-	NotebookDocumentSync any `json:"notebookDocumentSync,omitempty"`
+	NotebookDocumentSync *OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions `json:"notebookDocumentSync,omitempty"`
 	// The server provides completion support.
 	CompletionProvider *CompletionOptions `json:"completionProvider,omitempty"`
 	// The server provides hover support.
-	HoverProvider any `json:"hoverProvider,omitempty"`
+	HoverProvider *BoolOrOptions[HoverOptions] `json:"hoverProvider,omitempty"`
 	// The server provides signature help support.
 	SignatureHelpProvider *SignatureHelpOptions `json:"signatureHelpProvider,omitempty"`
 	// The server provides Goto Declaration support.
-	DeclarationProvider any `json:"declarationProvider,omitempty"`
+	DeclarationProvider *BoolOrOptions[DeclarationRegistrationOptions] `json:"declarationProvider,omitempty"`
 	// The server provides goto definition support.
-	DefinitionProvider any `json:"definitionProvider,omitempty"`
+	DefinitionProvider *BoolOrOptions[DefinitionOptions] `json:"definitionProvider,omitempty"`
 	// The server provides Goto Type Definition support.
-	TypeDefinitionProvider any `json:"typeDefinitionProvider,omitempty"`
+	TypeDefinitionProvider *BoolOrOptions[TypeDefinitionRegistrationOptions] `json:"typeDefinitionProvider,omitempty"`
 	// The server provides Goto Implementation support.
-	ImplementationProvider any `json:"implementationProvider,omitempty"`
+	ImplementationProvider *BoolOrOptions[ImplementationRegistrationOptions] `json:"implementationProvider,omitempty"`
 	// The server provides find references support.
-	ReferencesProvider any `json:"referencesProvider,omitempty"`
+	ReferencesProvider *BoolOrOptions[ReferenceOptions] `json:"referencesProvider,omitempty"`
 	// The server provides document highlight support.
-	DocumentHighlightProvider any `json:"documentHighlightProvider,omitempty"`
+	DocumentHighlightProvider *BoolOrOptions[DocumentHighlightOptions] `json:"documentHighlightProvider,omitempty"`
 	// The server provides document symbol support.
-	DocumentSymbolProvider any `json:"documentSymbolProvider,omitempty"`
+	DocumentSymbolProvider *BoolOrOptions[DocumentSymbolOptions] `json:"documentSymbolProvider,omitempty"`
 	// The server provides code actions. CodeActionOptions may only be
 	// specified if the client states that it supports
 	// `codeActionLiteralSupport` in its initial `initialize` request.
-	CodeActionProvider any `json:"codeActionProvider,omitempty"`
+	CodeActionProvider *BoolOrOptions[CodeActionOptions] `json:"codeActionProvider,omitempty"`
 	// The server provides code lens.
 	CodeLensProvider *CodeLensOptions `json:"codeLensProvider,omitempty"`
 	// The server provides document link support.
 	DocumentLinkProvider *DocumentLinkOptions `json:"documentLinkProvider,omitempty"`
 	// The server provides color provider support.
-	ColorProvider any `json:"colorProvider,omitempty"`
+	ColorProvider *BoolOrOptions[DocumentColorRegistrationOptions] `json:"colorProvider,omitempty"`
 	// The server provides workspace symbol support.
-	WorkspaceSymbolProvider any `json:"workspaceSymbolProvider,omitempty"`
+	WorkspaceSymbolProvider *BoolOrOptions[WorkspaceSymbolOptions] `json:"workspaceSymbolProvider,omitempty"`
 	// The server provides document formatting.
-	DocumentFormattingProvider any `json:"documentFormattingProvider,omitempty"`
+	DocumentFormattingProvider *BoolOrOptions[DocumentFormattingOptions] `json:"documentFormattingProvider,omitempty"`
 	// The server provides document range formatting.
-	DocumentRangeFormattingProvider any `json:"documentRangeFormattingProvider,omitempty"`
+	DocumentRangeFormattingProvider *BoolOrOptions[DocumentRangeFormattingOptions] `json:"documentRangeFormattingProvider,omitempty"`
 	// The server provides document formatting on typing.
 	DocumentOnTypeFormattingProvider *DocumentOnTypeFormattingOptions `json:"documentOnTypeFormattingProvider,omitempty"`
 	// The server provides rename support. RenameOptions may only be
 	// specified if the client states that it supports
 	// `prepareSupport` in its initial `initialize` request.
-	RenameProvider any `json:"renameProvider,omitempty"`
+	RenameProvider *BoolOrOptions[RenameOptions] `json:"renameProvider,omitempty"`
 	// The server provides folding provider support.
-	FoldingRangeProvider any `json:"foldingRangeProvider,omitempty"`
+	FoldingRangeProvider *BoolOrOptions[FoldingRangeRegistrationOptions] `json:"foldingRangeProvider,omitempty"`
 	// The server provides selection range support.
-	SelectionRangeProvider any `json:"selectionRangeProvider,omitempty"`
+	SelectionRangeProvider *BoolOrOptions[SelectionRangeRegistrationOptions] `json:"selectionRangeProvider,omitempty"`
 	// The server provides execute command support.
 	ExecuteCommandProvider *ExecuteCommandOptions `json:"executeCommandProvider,omitempty"`
 	// The server provides call hierarchy support.
 	// 
 	// @since 3.16.0
-	CallHierarchyProvider any `json:"callHierarchyProvider,omitempty"`
+	CallHierarchyProvider *BoolOrOptions[CallHierarchyRegistrationOptions] `json:"callHierarchyProvider,omitempty"`
 	// The server provides linked editing range support.
 	// 
 	// @since 3.16.0
-	LinkedEditingRangeProvider any `json:"linkedEditingRangeProvider,omitempty"`
+	LinkedEditingRangeProvider *BoolOrOptions[LinkedEditingRangeRegistrationOptions] `json:"linkedEditingRangeProvider,omitempty"`
 	// The server provides semantic tokens support.
 	// 
 	// @since 3.16.0
-	SemanticTokensProvider any `json:"semanticTokensProvider,omitempty"`
+	SemanticTokensProvider *OrSemanticTokensOptionsSemanticTokensRegistrationOptions `json:"semanticTokensProvider,omitempty"`
 	// The server provides moniker support.
 	// 
 	// @since 3.16.0
-	MonikerProvider any `json:"monikerProvider,omitempty"`
+	MonikerProvider *BoolOrOptions[MonikerRegistrationOptions] `json:"monikerProvider,omitempty"`
 	// The server provides type hierarchy support.
 	// 
 	// @since 3.17.0
-	TypeHierarchyProvider any `json:"typeHierarchyProvider,omitempty"`
+	TypeHierarchyProvider *BoolOrOptions[TypeHierarchyRegistrationOptions] `json:"typeHierarchyProvider,omitempty"`
 	// The server provides inline values.
 	// 
 	// @since 3.17.0
-	InlineValueProvider any `json:"inlineValueProvider,omitempty"`
+	InlineValueProvider *BoolOrOptions[InlineValueRegistrationOptions] `json:"inlineValueProvider,omitempty"`
 	// The server provides inlay hints.
 	// 
 	// @since 3.17.0
-	InlayHintProvider any `json:"inlayHintProvider,omitempty"`
+	InlayHintProvider *BoolOrOptions[InlayHintRegistrationOptions] `json:"inlayHintProvider,omitempty"`
 	// The server has support for pull model diagnostics.
 	// 
 	// @since 3.17.0
-	DiagnosticProvider any `json:"diagnosticProvider,omitempty"`
+	DiagnosticProvider *OrDiagnosticOptionsDiagnosticRegistrationOptions `json:"diagnosticProvider,omitempty"`
 	// Workspace specific server capabilities.
 	Workspace *WorkspaceOptions `json:"workspace,omitempty"`
 	// Experimental server capabilities.
@@ -2988,7 +2990,7 @@ type Diagnostic struct {
 	// always provide a severity value.
 	Severity *DiagnosticSeverity `json:"severity,omitempty"`
 	// The diagnostic's code, which usually appear in the user interface.
-	Code any `json:"code,omitempty"`
+	Code *OrInt32String `json:"code,omitempty"`
 	// An optional property to describe the error code.
 	// Requires the code field (above) to be present/not null.
 	// 
@@ -3070,7 +3072,7 @@ type CompletionItemDefaults struct {
 	// A default edit range.
 	// 
 	// @since 3.17.0
-	EditRange any `json:"editRange,omitempty"`
+	EditRange *OrRangeEditRangeWithInsertReplace `json:"editRange,omitempty"`
 	// A default insert text format.
 	// 
 	// @since 3.17.0
@@ -3211,7 +3213,7 @@ type SignatureInformation struct {
 	Label string `json:"label"`
 	// The human-readable doc-comment of this signature. Will be shown
 	// in the UI but can be omitted.
-	Documentation any `json:"documentation,omitempty"`
+	Documentation *OrStringMarkupContent `json:"documentation,omitempty"`
 	// The parameters of this signature.
 	Parameters []ParameterInformation `json:"parameters,omitempty"`
 	// The index of the active parameter.
@@ -3594,7 +3596,7 @@ type NotebookDocumentFilterWithNotebook struct {
 	// The notebook to be synced If a string
 	// value is provided it matches against the
 	// notebook type. '*' matches every notebook.
-	Notebook any `json:"notebook"`
+	Notebook OrStringNotebookDocumentFilter `json:"notebook"`
 	// The cells of the matching notebook to be synced.
 	Cells []NotebookCellLanguage `json:"cells,omitempty"`
 }
@@ -3604,7 +3606,7 @@ type NotebookDocumentFilterWithCells struct {
 	// The notebook to be synced If a string
 	// value is provided it matches against the
 	// notebook type. '*' matches every notebook.
-	Notebook any `json:"notebook,omitempty"`
+	Notebook *OrStringNotebookDocumentFilter `json:"notebook,omitempty"`
 	// The cells of the matching notebook to be synced.
 	Cells []NotebookCellLanguage `json:"cells"`
 }
@@ -3670,7 +3672,7 @@ type TextDocumentSyncOptions struct {
 	WillSaveWaitUntil *bool `json:"willSaveWaitUntil,omitempty"`
 	// If present save notifications are sent to the server. If omitted the notification should not be
 	// sent.
-	Save any `json:"save,omitempty"`
+	Save *BoolOrOptions[SaveOptions] `json:"save,omitempty"`
 }
 
 // Defines workspace specific capabilities of the server.
@@ -3763,10 +3765,10 @@ type ParameterInformation struct {
 	// 
 	// *Note*: a label of type string should be a substring of its containing signature label.
 	// Its intended use case is to highlight the parameter label part in the `SignatureInformation.label`.
-	Label any `json:"label"`
+	Label OrStringAny `json:"label"`
 	// The human-readable doc-comment of this parameter. Will be shown
 	// in the UI but can be omitted.
-	Documentation any `json:"documentation,omitempty"`
+	Documentation *OrStringMarkupContent `json:"documentation,omitempty"`
 }
 
 // A notebook cell text document filter denotes a cell text
@@ -3778,7 +3780,7 @@ type NotebookCellTextDocumentFilter struct {
 	// containing the notebook cell. If a string
 	// value is provided it matches against the
 	// notebook type. '*' matches every notebook.
-	Notebook any `json:"notebook"`
+	Notebook OrStringNotebookDocumentFilter `json:"notebook"`
 	// A language id like `python`.
 	// 
 	// Will be matched against the language id of the
@@ -4065,7 +4067,7 @@ type WorkspaceFoldersServerCapabilities struct {
 	// under which the notification is registered on the client
 	// side. The ID can be used to unregister for these events
 	// using the `client/unregisterCapability` request.
-	ChangeNotifications any `json:"changeNotifications,omitempty"`
+	ChangeNotifications *OrStringBool `json:"changeNotifications,omitempty"`
 }
 
 // Options for notifications/requests for user operations on files.
@@ -4094,7 +4096,7 @@ type FileOperationOptions struct {
 type RelativePattern struct {
 	// A workspace folder or a base URI to which this pattern will be matched
 	// against relatively.
-	BaseURI any `json:"baseUri"`
+	BaseURI OrWorkspaceFolderURI `json:"baseUri"`
 	// The actual glob pattern;
 	Pattern Pattern `json:"pattern"`
 }
@@ -5081,10 +5083,10 @@ type DiagnosticsCapabilities struct {
 type ClientSemanticTokensRequestOptions struct {
 	// The client will send the `textDocument/semanticTokens/range` request if
 	// the server provides a corresponding handler.
-	Range any `json:"range,omitempty"`
+	Range *BoolOrOptions[SemanticTokensOptionsRange] `json:"range,omitempty"`
 	// The client will send the `textDocument/semanticTokens/full` request if
 	// the server provides a corresponding handler.
-	Full any `json:"full,omitempty"`
+	Full *BoolOrOptions[ClientSemanticTokensRequestFullDelta] `json:"full,omitempty"`
 }
 
 // @since 3.18.0
@@ -5879,7 +5881,7 @@ const (
 // 
 // Servers should prefer returning `DefinitionLink` over `Definition` if supported
 // by the client.
-type Definition = any
+type Definition = OrLocationLocationArray
 
 // Information about where a symbol is defined.
 // 
@@ -5900,7 +5902,7 @@ type LSPArray = []LSPAny
 type LSPAny = any
 
 // The declaration of a symbol representation as one or many {@link Location locations}.
-type Declaration = any
+type Declaration = OrLocationLocationArray
 
 // Information about where a symbol is declared.
 // 
@@ -5918,7 +5920,7 @@ type DeclarationLink = LocationLink
 // The InlineValue types combines all inline value types into one type.
 // 
 // @since 3.17.0
-type InlineValue = any
+type InlineValue = OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression
 
 // The result of a document diagnostic pull request. A report can
 // either be a full report containing all diagnostics for the
@@ -5927,10 +5929,10 @@ type InlineValue = any
 // pull request.
 // 
 // @since 3.17.0
-type DocumentDiagnosticReport = any
+type DocumentDiagnosticReport = OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport
 
 // PrepareRenameResult is an LSP type.
-type PrepareRenameResult = any
+type PrepareRenameResult = OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior
 
 // A document selector is the combination of one or many document filters.
 // 
@@ -5940,7 +5942,7 @@ type PrepareRenameResult = any
 type DocumentSelector = []DocumentFilter
 
 // ProgressToken is an LSP type.
-type ProgressToken = any
+type ProgressToken = OrInt32String
 
 // An identifier to refer to a change annotation stored with a workspace edit.
 type ChangeAnnotationIdentifier = string
@@ -5948,11 +5950,11 @@ type ChangeAnnotationIdentifier = string
 // A workspace diagnostic document report.
 // 
 // @since 3.17.0
-type WorkspaceDocumentDiagnosticReport = any
+type WorkspaceDocumentDiagnosticReport = OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport
 
 // An event describing a change to a text document. If only a text is provided
 // it is considered to be the full content of the document.
-type TextDocumentContentChangeEvent = any
+type TextDocumentContentChangeEvent = OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument
 
 // MarkedString can be used to render human readable text. It is either a markdown string
 // or a code-block that provides a language and a code snippet. The language identifier
@@ -5966,13 +5968,13 @@ type TextDocumentContentChangeEvent = any
 // 
 // Note that markdown strings will be sanitized - that means html will be escaped.
 // @deprecated use MarkupContent instead.
-type MarkedString = any
+type MarkedString = OrStringMarkedStringWithLanguage
 
 // A document filter describes a top level text document or
 // a notebook cell document.
 // 
 // @since 3.17.0 - support for NotebookCellTextDocumentFilter.
-type DocumentFilter = any
+type DocumentFilter = OrTextDocumentFilterNotebookCellTextDocumentFilter
 
 // LSP object definition.
 // @since 3.17.0
@@ -5981,7 +5983,7 @@ type LSPObject = map[string]LSPAny
 // The glob pattern. Either a string pattern or a relative pattern.
 // 
 // @since 3.17.0
-type GlobPattern = any
+type GlobPattern = OrPatternRelativePattern
 
 // A document filter denotes a document by different properties like
 // the {@link TextDocument.languageId language}, the {@link Uri.scheme scheme} of
@@ -5999,14 +6001,14 @@ type GlobPattern = any
 // @sample A language filter that applies to all package.json paths: `{ language: 'json', pattern: '**package.json' }`
 // 
 // @since 3.17.0
-type TextDocumentFilter = any
+type TextDocumentFilter = OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern
 
 // A notebook document filter denotes a notebook document by
 // different properties. The properties will be match
 // against the notebook's URI (same as with documents)
 // 
 // @since 3.17.0
-type NotebookDocumentFilter = any
+type NotebookDocumentFilter = OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern
 
 // The glob pattern to watch relative to the base path. Glob patterns can have the following syntax:
 // - `*` to match one or more characters in a path segment
@@ -6022,5 +6024,2667 @@ type Pattern = string
 // RegularExpressionEngineKind is an LSP type.
 type RegularExpressionEngineKind = string
 
+type SemanticTokensOptionsRange struct {
+}
+
+// unionKey is a property an object must have to decode as a union
+// variant; value, unless empty, is the JSON value it must hold.
+type unionKey struct {
+	name  string
+	value string
+}
+
+// decodeUnionVariant decodes data into v. If data is an object, or an
+// array of objects, each object must have the properties in required and
+// none of those in excluded, which only other variants have. Other
+// properties are ignored, as is everything below the top level, so that
+// objects sent by a newer version of the protocol still decode.
+func decodeUnionVariant(data []byte, v any, required []unionKey, excluded ...string) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if len(required) == 0 && len(excluded) == 0 {
+		return nil
+	}
+	var objects []map[string]json.RawMessage
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &objects); err != nil {
+			return err
+		}
+	} else {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+	for _, object := range objects {
+		for _, key := range required {
+			value, ok := object[key.name]
+			if !ok {
+				return fmt.Errorf("missing property %q", key.name)
+			}
+			if key.value != "" && string(bytes.TrimSpace(value)) != key.value {
+				return fmt.Errorf("property %q is %s, not %s", key.name, value, key.value)
+			}
+		}
+		for _, name := range excluded {
+			if _, ok := object[name]; ok {
+				return fmt.Errorf("unexpected property %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// unionMismatch reports that no variant of the union name accepts data.
+func unionMismatch(name string, data []byte) error {
+	return fmt.Errorf("%s: no variant accepts %s", name, data)
+}
+
+// OrCommandCodeAction holds one of Command, CodeAction. The zero value holds none and
+// is encoded as null.
+type OrCommandCodeAction struct {
+	command *Command
+	codeAction *CodeAction
+}
+
+// AsCommand returns the Command held by u, if any.
+func (u OrCommandCodeAction) AsCommand() (Command, bool) {
+	if u.command == nil {
+		var zero Command
+		return zero, false
+	}
+	return *u.command, true
+}
+
+// SetCommand makes u hold v.
+func (u *OrCommandCodeAction) SetCommand(v Command) {
+	*u = OrCommandCodeAction{command: &v}
+}
+
+// AsCodeAction returns the CodeAction held by u, if any.
+func (u OrCommandCodeAction) AsCodeAction() (CodeAction, bool) {
+	if u.codeAction == nil {
+		var zero CodeAction
+		return zero, false
+	}
+	return *u.codeAction, true
+}
+
+// SetCodeAction makes u hold v.
+func (u *OrCommandCodeAction) SetCodeAction(v CodeAction) {
+	*u = OrCommandCodeAction{codeAction: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrCommandCodeAction) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.command != nil:
+		return json.Marshal(*u.command)
+	case u.codeAction != nil:
+		return json.Marshal(*u.codeAction)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrCommandCodeAction) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrCommandCodeAction{}
+		return nil
+	}
+	var v0 Command
+	if decodeUnionVariant(data, &v0, []unionKey{{"title", ""}, {"command", ""}}, "kind", "diagnostics", "isPreferred", "disabled", "edit", "data", "tags") == nil {
+		u.SetCommand(v0)
+		return nil
+	}
+	var v1 CodeAction
+	if decodeUnionVariant(data, &v1, []unionKey{{"title", ""}}, "arguments") == nil {
+		u.SetCodeAction(v1)
+		return nil
+	}
+	return unionMismatch("OrCommandCodeAction", data)
+}
+
+// OrCompletionItemArrayCompletionList holds one of []CompletionItem, CompletionList. The zero value holds none and
+// is encoded as null.
+type OrCompletionItemArrayCompletionList struct {
+	completionItemArray *[]CompletionItem
+	completionList *CompletionList
+}
+
+// AsCompletionItemArray returns the []CompletionItem held by u, if any.
+func (u OrCompletionItemArrayCompletionList) AsCompletionItemArray() ([]CompletionItem, bool) {
+	if u.completionItemArray == nil {
+		var zero []CompletionItem
+		return zero, false
+	}
+	return *u.completionItemArray, true
+}
+
+// SetCompletionItemArray makes u hold v.
+func (u *OrCompletionItemArrayCompletionList) SetCompletionItemArray(v []CompletionItem) {
+	*u = OrCompletionItemArrayCompletionList{completionItemArray: &v}
+}
+
+// AsCompletionList returns the CompletionList held by u, if any.
+func (u OrCompletionItemArrayCompletionList) AsCompletionList() (CompletionList, bool) {
+	if u.completionList == nil {
+		var zero CompletionList
+		return zero, false
+	}
+	return *u.completionList, true
+}
+
+// SetCompletionList makes u hold v.
+func (u *OrCompletionItemArrayCompletionList) SetCompletionList(v CompletionList) {
+	*u = OrCompletionItemArrayCompletionList{completionList: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrCompletionItemArrayCompletionList) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.completionItemArray != nil:
+		return json.Marshal(*u.completionItemArray)
+	case u.completionList != nil:
+		return json.Marshal(*u.completionList)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrCompletionItemArrayCompletionList) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrCompletionItemArrayCompletionList{}
+		return nil
+	}
+	var v0 []CompletionItem
+	if decodeUnionVariant(data, &v0, []unionKey{{"label", ""}}, "isIncomplete", "itemDefaults", "applyKind", "items") == nil {
+		u.SetCompletionItemArray(v0)
+		return nil
+	}
+	var v1 CompletionList
+	if decodeUnionVariant(data, &v1, []unionKey{{"isIncomplete", ""}, {"items", ""}}, "label", "labelDetails", "kind", "tags", "detail", "documentation", "deprecated", "preselect", "sortText", "filterText", "insertText", "insertTextFormat", "insertTextMode", "textEdit", "textEditText", "additionalTextEdits", "commitCharacters", "command", "data") == nil {
+		u.SetCompletionList(v1)
+		return nil
+	}
+	return unionMismatch("OrCompletionItemArrayCompletionList", data)
+}
+
+// OrDeclarationDeclarationLinkArray holds one of Declaration, []DeclarationLink. The zero value holds none and
+// is encoded as null.
+type OrDeclarationDeclarationLinkArray struct {
+	declaration *Declaration
+	declarationLinkArray *[]DeclarationLink
+}
+
+// AsDeclaration returns the Declaration held by u, if any.
+func (u OrDeclarationDeclarationLinkArray) AsDeclaration() (Declaration, bool) {
+	if u.declaration == nil {
+		var zero Declaration
+		return zero, false
+	}
+	return *u.declaration, true
+}
+
+// SetDeclaration makes u hold v.
+func (u *OrDeclarationDeclarationLinkArray) SetDeclaration(v Declaration) {
+	*u = OrDeclarationDeclarationLinkArray{declaration: &v}
+}
+
+// AsDeclarationLinkArray returns the []DeclarationLink held by u, if any.
+func (u OrDeclarationDeclarationLinkArray) AsDeclarationLinkArray() ([]DeclarationLink, bool) {
+	if u.declarationLinkArray == nil {
+		var zero []DeclarationLink
+		return zero, false
+	}
+	return *u.declarationLinkArray, true
+}
+
+// SetDeclarationLinkArray makes u hold v.
+func (u *OrDeclarationDeclarationLinkArray) SetDeclarationLinkArray(v []DeclarationLink) {
+	*u = OrDeclarationDeclarationLinkArray{declarationLinkArray: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrDeclarationDeclarationLinkArray) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.declaration != nil:
+		return json.Marshal(*u.declaration)
+	case u.declarationLinkArray != nil:
+		return json.Marshal(*u.declarationLinkArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrDeclarationDeclarationLinkArray) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrDeclarationDeclarationLinkArray{}
+		return nil
+	}
+	var v0 Declaration
+	if decodeUnionVariant(data, &v0, nil, "originSelectionRange", "targetUri", "targetRange", "targetSelectionRange") == nil {
+		u.SetDeclaration(v0)
+		return nil
+	}
+	var v1 []DeclarationLink
+	if decodeUnionVariant(data, &v1, []unionKey{{"targetUri", ""}, {"targetRange", ""}, {"targetSelectionRange", ""}}, "uri", "range") == nil {
+		u.SetDeclarationLinkArray(v1)
+		return nil
+	}
+	return unionMismatch("OrDeclarationDeclarationLinkArray", data)
+}
+
+// OrDefinitionDefinitionLinkArray holds one of Definition, []DefinitionLink. The zero value holds none and
+// is encoded as null.
+type OrDefinitionDefinitionLinkArray struct {
+	definition *Definition
+	definitionLinkArray *[]DefinitionLink
+}
+
+// AsDefinition returns the Definition held by u, if any.
+func (u OrDefinitionDefinitionLinkArray) AsDefinition() (Definition, bool) {
+	if u.definition == nil {
+		var zero Definition
+		return zero, false
+	}
+	return *u.definition, true
+}
+
+// SetDefinition makes u hold v.
+func (u *OrDefinitionDefinitionLinkArray) SetDefinition(v Definition) {
+	*u = OrDefinitionDefinitionLinkArray{definition: &v}
+}
+
+// AsDefinitionLinkArray returns the []DefinitionLink held by u, if any.
+func (u OrDefinitionDefinitionLinkArray) AsDefinitionLinkArray() ([]DefinitionLink, bool) {
+	if u.definitionLinkArray == nil {
+		var zero []DefinitionLink
+		return zero, false
+	}
+	return *u.definitionLinkArray, true
+}
+
+// SetDefinitionLinkArray makes u hold v.
+func (u *OrDefinitionDefinitionLinkArray) SetDefinitionLinkArray(v []DefinitionLink) {
+	*u = OrDefinitionDefinitionLinkArray{definitionLinkArray: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrDefinitionDefinitionLinkArray) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.definition != nil:
+		return json.Marshal(*u.definition)
+	case u.definitionLinkArray != nil:
+		return json.Marshal(*u.definitionLinkArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrDefinitionDefinitionLinkArray) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrDefinitionDefinitionLinkArray{}
+		return nil
+	}
+	var v0 Definition
+	if decodeUnionVariant(data, &v0, nil, "originSelectionRange", "targetUri", "targetRange", "targetSelectionRange") == nil {
+		u.SetDefinition(v0)
+		return nil
+	}
+	var v1 []DefinitionLink
+	if decodeUnionVariant(data, &v1, []unionKey{{"targetUri", ""}, {"targetRange", ""}, {"targetSelectionRange", ""}}, "uri", "range") == nil {
+		u.SetDefinitionLinkArray(v1)
+		return nil
+	}
+	return unionMismatch("OrDefinitionDefinitionLinkArray", data)
+}
+
+// OrDiagnosticOptionsDiagnosticRegistrationOptions holds one of DiagnosticOptions, DiagnosticRegistrationOptions. The zero value holds none and
+// is encoded as null.
+type OrDiagnosticOptionsDiagnosticRegistrationOptions struct {
+	diagnosticOptions *DiagnosticOptions
+	diagnosticRegistrationOptions *DiagnosticRegistrationOptions
+}
+
+// AsDiagnosticOptions returns the DiagnosticOptions held by u, if any.
+func (u OrDiagnosticOptionsDiagnosticRegistrationOptions) AsDiagnosticOptions() (DiagnosticOptions, bool) {
+	if u.diagnosticOptions == nil {
+		var zero DiagnosticOptions
+		return zero, false
+	}
+	return *u.diagnosticOptions, true
+}
+
+// SetDiagnosticOptions makes u hold v.
+func (u *OrDiagnosticOptionsDiagnosticRegistrationOptions) SetDiagnosticOptions(v DiagnosticOptions) {
+	*u = OrDiagnosticOptionsDiagnosticRegistrationOptions{diagnosticOptions: &v}
+}
+
+// AsDiagnosticRegistrationOptions returns the DiagnosticRegistrationOptions held by u, if any.
+func (u OrDiagnosticOptionsDiagnosticRegistrationOptions) AsDiagnosticRegistrationOptions() (DiagnosticRegistrationOptions, bool) {
+	if u.diagnosticRegistrationOptions == nil {
+		var zero DiagnosticRegistrationOptions
+		return zero, false
+	}
+	return *u.diagnosticRegistrationOptions, true
+}
+
+// SetDiagnosticRegistrationOptions makes u hold v.
+func (u *OrDiagnosticOptionsDiagnosticRegistrationOptions) SetDiagnosticRegistrationOptions(v DiagnosticRegistrationOptions) {
+	*u = OrDiagnosticOptionsDiagnosticRegistrationOptions{diagnosticRegistrationOptions: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrDiagnosticOptionsDiagnosticRegistrationOptions) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.diagnosticOptions != nil:
+		return json.Marshal(*u.diagnosticOptions)
+	case u.diagnosticRegistrationOptions != nil:
+		return json.Marshal(*u.diagnosticRegistrationOptions)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrDiagnosticOptionsDiagnosticRegistrationOptions) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrDiagnosticOptionsDiagnosticRegistrationOptions{}
+		return nil
+	}
+	var v0 DiagnosticOptions
+	if decodeUnionVariant(data, &v0, []unionKey{{"interFileDependencies", ""}, {"workspaceDiagnostics", ""}}, "documentSelector", "id") == nil {
+		u.SetDiagnosticOptions(v0)
+		return nil
+	}
+	var v1 DiagnosticRegistrationOptions
+	if decodeUnionVariant(data, &v1, []unionKey{{"documentSelector", ""}, {"interFileDependencies", ""}, {"workspaceDiagnostics", ""}}) == nil {
+		u.SetDiagnosticRegistrationOptions(v1)
+		return nil
+	}
+	return unionMismatch("OrDiagnosticOptionsDiagnosticRegistrationOptions", data)
+}
+
+// OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport holds one of FullDocumentDiagnosticReport, UnchangedDocumentDiagnosticReport. The zero value holds none and
+// is encoded as null.
+type OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport struct {
+	fullDocumentDiagnosticReport *FullDocumentDiagnosticReport
+	unchangedDocumentDiagnosticReport *UnchangedDocumentDiagnosticReport
+}
+
+// AsFullDocumentDiagnosticReport returns the FullDocumentDiagnosticReport held by u, if any.
+func (u OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport) AsFullDocumentDiagnosticReport() (FullDocumentDiagnosticReport, bool) {
+	if u.fullDocumentDiagnosticReport == nil {
+		var zero FullDocumentDiagnosticReport
+		return zero, false
+	}
+	return *u.fullDocumentDiagnosticReport, true
+}
+
+// SetFullDocumentDiagnosticReport makes u hold v.
+func (u *OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport) SetFullDocumentDiagnosticReport(v FullDocumentDiagnosticReport) {
+	*u = OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport{fullDocumentDiagnosticReport: &v}
+}
+
+// AsUnchangedDocumentDiagnosticReport returns the UnchangedDocumentDiagnosticReport held by u, if any.
+func (u OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport) AsUnchangedDocumentDiagnosticReport() (UnchangedDocumentDiagnosticReport, bool) {
+	if u.unchangedDocumentDiagnosticReport == nil {
+		var zero UnchangedDocumentDiagnosticReport
+		return zero, false
+	}
+	return *u.unchangedDocumentDiagnosticReport, true
+}
+
+// SetUnchangedDocumentDiagnosticReport makes u hold v.
+func (u *OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport) SetUnchangedDocumentDiagnosticReport(v UnchangedDocumentDiagnosticReport) {
+	*u = OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport{unchangedDocumentDiagnosticReport: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.fullDocumentDiagnosticReport != nil:
+		return json.Marshal(*u.fullDocumentDiagnosticReport)
+	case u.unchangedDocumentDiagnosticReport != nil:
+		return json.Marshal(*u.unchangedDocumentDiagnosticReport)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport{}
+		return nil
+	}
+	var v0 FullDocumentDiagnosticReport
+	if decodeUnionVariant(data, &v0, []unionKey{{"kind", "\"full\""}, {"items", ""}}) == nil {
+		u.SetFullDocumentDiagnosticReport(v0)
+		return nil
+	}
+	var v1 UnchangedDocumentDiagnosticReport
+	if decodeUnionVariant(data, &v1, []unionKey{{"kind", "\"unchanged\""}, {"resultId", ""}}, "items") == nil {
+		u.SetUnchangedDocumentDiagnosticReport(v1)
+		return nil
+	}
+	return unionMismatch("OrFullDocumentDiagnosticReportUnchangedDocumentDiagnosticReport", data)
+}
+
+// OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression holds one of InlineValueText, InlineValueVariableLookup, InlineValueEvaluatableExpression. The zero value holds none and
+// is encoded as null.
+type OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression struct {
+	inlineValueText *InlineValueText
+	inlineValueVariableLookup *InlineValueVariableLookup
+	inlineValueEvaluatableExpression *InlineValueEvaluatableExpression
+}
+
+// AsInlineValueText returns the InlineValueText held by u, if any.
+func (u OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) AsInlineValueText() (InlineValueText, bool) {
+	if u.inlineValueText == nil {
+		var zero InlineValueText
+		return zero, false
+	}
+	return *u.inlineValueText, true
+}
+
+// SetInlineValueText makes u hold v.
+func (u *OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) SetInlineValueText(v InlineValueText) {
+	*u = OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression{inlineValueText: &v}
+}
+
+// AsInlineValueVariableLookup returns the InlineValueVariableLookup held by u, if any.
+func (u OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) AsInlineValueVariableLookup() (InlineValueVariableLookup, bool) {
+	if u.inlineValueVariableLookup == nil {
+		var zero InlineValueVariableLookup
+		return zero, false
+	}
+	return *u.inlineValueVariableLookup, true
+}
+
+// SetInlineValueVariableLookup makes u hold v.
+func (u *OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) SetInlineValueVariableLookup(v InlineValueVariableLookup) {
+	*u = OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression{inlineValueVariableLookup: &v}
+}
+
+// AsInlineValueEvaluatableExpression returns the InlineValueEvaluatableExpression held by u, if any.
+func (u OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) AsInlineValueEvaluatableExpression() (InlineValueEvaluatableExpression, bool) {
+	if u.inlineValueEvaluatableExpression == nil {
+		var zero InlineValueEvaluatableExpression
+		return zero, false
+	}
+	return *u.inlineValueEvaluatableExpression, true
+}
+
+// SetInlineValueEvaluatableExpression makes u hold v.
+func (u *OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) SetInlineValueEvaluatableExpression(v InlineValueEvaluatableExpression) {
+	*u = OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression{inlineValueEvaluatableExpression: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.inlineValueText != nil:
+		return json.Marshal(*u.inlineValueText)
+	case u.inlineValueVariableLookup != nil:
+		return json.Marshal(*u.inlineValueVariableLookup)
+	case u.inlineValueEvaluatableExpression != nil:
+		return json.Marshal(*u.inlineValueEvaluatableExpression)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression{}
+		return nil
+	}
+	var v0 InlineValueText
+	if decodeUnionVariant(data, &v0, []unionKey{{"range", ""}, {"text", ""}}, "variableName", "caseSensitiveLookup", "expression") == nil {
+		u.SetInlineValueText(v0)
+		return nil
+	}
+	var v1 InlineValueVariableLookup
+	if decodeUnionVariant(data, &v1, []unionKey{{"range", ""}, {"caseSensitiveLookup", ""}}, "text", "expression") == nil {
+		u.SetInlineValueVariableLookup(v1)
+		return nil
+	}
+	var v2 InlineValueEvaluatableExpression
+	if decodeUnionVariant(data, &v2, []unionKey{{"range", ""}}, "text", "variableName", "caseSensitiveLookup") == nil {
+		u.SetInlineValueEvaluatableExpression(v2)
+		return nil
+	}
+	return unionMismatch("OrInlineValueTextInlineValueVariableLookupInlineValueEvaluatableExpression", data)
+}
+
+// OrInt32String holds one of int32, string. The zero value holds none and
+// is encoded as null.
+type OrInt32String struct {
+	int32 *int32
+	string *string
+}
+
+// AsInt32 returns the int32 held by u, if any.
+func (u OrInt32String) AsInt32() (int32, bool) {
+	if u.int32 == nil {
+		var zero int32
+		return zero, false
+	}
+	return *u.int32, true
+}
+
+// SetInt32 makes u hold v.
+func (u *OrInt32String) SetInt32(v int32) {
+	*u = OrInt32String{int32: &v}
+}
+
+// AsString returns the string held by u, if any.
+func (u OrInt32String) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrInt32String) SetString(v string) {
+	*u = OrInt32String{string: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrInt32String) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.int32 != nil:
+		return json.Marshal(*u.int32)
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrInt32String) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrInt32String{}
+		return nil
+	}
+	var v0 int32
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetInt32(v0)
+		return nil
+	}
+	var v1 string
+	if decodeUnionVariant(data, &v1, nil) == nil {
+		u.SetString(v1)
+		return nil
+	}
+	return unionMismatch("OrInt32String", data)
+}
+
+// OrLocationLocationArray holds one of Location, []Location. The zero value holds none and
+// is encoded as null.
+type OrLocationLocationArray struct {
+	location *Location
+	locationArray *[]Location
+}
+
+// AsLocation returns the Location held by u, if any.
+func (u OrLocationLocationArray) AsLocation() (Location, bool) {
+	if u.location == nil {
+		var zero Location
+		return zero, false
+	}
+	return *u.location, true
+}
+
+// SetLocation makes u hold v.
+func (u *OrLocationLocationArray) SetLocation(v Location) {
+	*u = OrLocationLocationArray{location: &v}
+}
+
+// AsLocationArray returns the []Location held by u, if any.
+func (u OrLocationLocationArray) AsLocationArray() ([]Location, bool) {
+	if u.locationArray == nil {
+		var zero []Location
+		return zero, false
+	}
+	return *u.locationArray, true
+}
+
+// SetLocationArray makes u hold v.
+func (u *OrLocationLocationArray) SetLocationArray(v []Location) {
+	*u = OrLocationLocationArray{locationArray: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrLocationLocationArray) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.location != nil:
+		return json.Marshal(*u.location)
+	case u.locationArray != nil:
+		return json.Marshal(*u.locationArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrLocationLocationArray) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrLocationLocationArray{}
+		return nil
+	}
+	var v0 Location
+	if decodeUnionVariant(data, &v0, []unionKey{{"uri", ""}, {"range", ""}}) == nil {
+		u.SetLocation(v0)
+		return nil
+	}
+	var v1 []Location
+	if decodeUnionVariant(data, &v1, []unionKey{{"uri", ""}, {"range", ""}}) == nil {
+		u.SetLocationArray(v1)
+		return nil
+	}
+	return unionMismatch("OrLocationLocationArray", data)
+}
+
+// OrLocationLocationUriOnly holds one of Location, LocationUriOnly. The zero value holds none and
+// is encoded as null.
+type OrLocationLocationUriOnly struct {
+	location *Location
+	locationUriOnly *LocationUriOnly
+}
+
+// AsLocation returns the Location held by u, if any.
+func (u OrLocationLocationUriOnly) AsLocation() (Location, bool) {
+	if u.location == nil {
+		var zero Location
+		return zero, false
+	}
+	return *u.location, true
+}
+
+// SetLocation makes u hold v.
+func (u *OrLocationLocationUriOnly) SetLocation(v Location) {
+	*u = OrLocationLocationUriOnly{location: &v}
+}
+
+// AsLocationUriOnly returns the LocationUriOnly held by u, if any.
+func (u OrLocationLocationUriOnly) AsLocationUriOnly() (LocationUriOnly, bool) {
+	if u.locationUriOnly == nil {
+		var zero LocationUriOnly
+		return zero, false
+	}
+	return *u.locationUriOnly, true
+}
+
+// SetLocationUriOnly makes u hold v.
+func (u *OrLocationLocationUriOnly) SetLocationUriOnly(v LocationUriOnly) {
+	*u = OrLocationLocationUriOnly{locationUriOnly: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrLocationLocationUriOnly) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.location != nil:
+		return json.Marshal(*u.location)
+	case u.locationUriOnly != nil:
+		return json.Marshal(*u.locationUriOnly)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrLocationLocationUriOnly) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrLocationLocationUriOnly{}
+		return nil
+	}
+	var v0 Location
+	if decodeUnionVariant(data, &v0, []unionKey{{"uri", ""}, {"range", ""}}) == nil {
+		u.SetLocation(v0)
+		return nil
+	}
+	var v1 LocationUriOnly
+	if decodeUnionVariant(data, &v1, []unionKey{{"uri", ""}}, "range") == nil {
+		u.SetLocationUriOnly(v1)
+		return nil
+	}
+	return unionMismatch("OrLocationLocationUriOnly", data)
+}
+
+// OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern holds one of NotebookDocumentFilterNotebookType, NotebookDocumentFilterScheme, NotebookDocumentFilterPattern. The zero value holds none and
+// is encoded as null.
+type OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern struct {
+	notebookDocumentFilterNotebookType *NotebookDocumentFilterNotebookType
+	notebookDocumentFilterScheme *NotebookDocumentFilterScheme
+	notebookDocumentFilterPattern *NotebookDocumentFilterPattern
+}
+
+// AsNotebookDocumentFilterNotebookType returns the NotebookDocumentFilterNotebookType held by u, if any.
+func (u OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) AsNotebookDocumentFilterNotebookType() (NotebookDocumentFilterNotebookType, bool) {
+	if u.notebookDocumentFilterNotebookType == nil {
+		var zero NotebookDocumentFilterNotebookType
+		return zero, false
+	}
+	return *u.notebookDocumentFilterNotebookType, true
+}
+
+// SetNotebookDocumentFilterNotebookType makes u hold v.
+func (u *OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) SetNotebookDocumentFilterNotebookType(v NotebookDocumentFilterNotebookType) {
+	*u = OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern{notebookDocumentFilterNotebookType: &v}
+}
+
+// AsNotebookDocumentFilterScheme returns the NotebookDocumentFilterScheme held by u, if any.
+func (u OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) AsNotebookDocumentFilterScheme() (NotebookDocumentFilterScheme, bool) {
+	if u.notebookDocumentFilterScheme == nil {
+		var zero NotebookDocumentFilterScheme
+		return zero, false
+	}
+	return *u.notebookDocumentFilterScheme, true
+}
+
+// SetNotebookDocumentFilterScheme makes u hold v.
+func (u *OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) SetNotebookDocumentFilterScheme(v NotebookDocumentFilterScheme) {
+	*u = OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern{notebookDocumentFilterScheme: &v}
+}
+
+// AsNotebookDocumentFilterPattern returns the NotebookDocumentFilterPattern held by u, if any.
+func (u OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) AsNotebookDocumentFilterPattern() (NotebookDocumentFilterPattern, bool) {
+	if u.notebookDocumentFilterPattern == nil {
+		var zero NotebookDocumentFilterPattern
+		return zero, false
+	}
+	return *u.notebookDocumentFilterPattern, true
+}
+
+// SetNotebookDocumentFilterPattern makes u hold v.
+func (u *OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) SetNotebookDocumentFilterPattern(v NotebookDocumentFilterPattern) {
+	*u = OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern{notebookDocumentFilterPattern: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.notebookDocumentFilterNotebookType != nil:
+		return json.Marshal(*u.notebookDocumentFilterNotebookType)
+	case u.notebookDocumentFilterScheme != nil:
+		return json.Marshal(*u.notebookDocumentFilterScheme)
+	case u.notebookDocumentFilterPattern != nil:
+		return json.Marshal(*u.notebookDocumentFilterPattern)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern{}
+		return nil
+	}
+	var v0 NotebookDocumentFilterNotebookType
+	if decodeUnionVariant(data, &v0, []unionKey{{"notebookType", ""}}) == nil {
+		u.SetNotebookDocumentFilterNotebookType(v0)
+		return nil
+	}
+	var v1 NotebookDocumentFilterScheme
+	if decodeUnionVariant(data, &v1, []unionKey{{"scheme", ""}}) == nil {
+		u.SetNotebookDocumentFilterScheme(v1)
+		return nil
+	}
+	var v2 NotebookDocumentFilterPattern
+	if decodeUnionVariant(data, &v2, []unionKey{{"pattern", ""}}) == nil {
+		u.SetNotebookDocumentFilterPattern(v2)
+		return nil
+	}
+	return unionMismatch("OrNotebookDocumentFilterNotebookTypeNotebookDocumentFilterSchemeNotebookDocumentFilterPattern", data)
+}
+
+// OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells holds one of NotebookDocumentFilterWithNotebook, NotebookDocumentFilterWithCells. The zero value holds none and
+// is encoded as null.
+type OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells struct {
+	notebookDocumentFilterWithNotebook *NotebookDocumentFilterWithNotebook
+	notebookDocumentFilterWithCells *NotebookDocumentFilterWithCells
+}
+
+// AsNotebookDocumentFilterWithNotebook returns the NotebookDocumentFilterWithNotebook held by u, if any.
+func (u OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells) AsNotebookDocumentFilterWithNotebook() (NotebookDocumentFilterWithNotebook, bool) {
+	if u.notebookDocumentFilterWithNotebook == nil {
+		var zero NotebookDocumentFilterWithNotebook
+		return zero, false
+	}
+	return *u.notebookDocumentFilterWithNotebook, true
+}
+
+// SetNotebookDocumentFilterWithNotebook makes u hold v.
+func (u *OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells) SetNotebookDocumentFilterWithNotebook(v NotebookDocumentFilterWithNotebook) {
+	*u = OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells{notebookDocumentFilterWithNotebook: &v}
+}
+
+// AsNotebookDocumentFilterWithCells returns the NotebookDocumentFilterWithCells held by u, if any.
+func (u OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells) AsNotebookDocumentFilterWithCells() (NotebookDocumentFilterWithCells, bool) {
+	if u.notebookDocumentFilterWithCells == nil {
+		var zero NotebookDocumentFilterWithCells
+		return zero, false
+	}
+	return *u.notebookDocumentFilterWithCells, true
+}
+
+// SetNotebookDocumentFilterWithCells makes u hold v.
+func (u *OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells) SetNotebookDocumentFilterWithCells(v NotebookDocumentFilterWithCells) {
+	*u = OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells{notebookDocumentFilterWithCells: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.notebookDocumentFilterWithNotebook != nil:
+		return json.Marshal(*u.notebookDocumentFilterWithNotebook)
+	case u.notebookDocumentFilterWithCells != nil:
+		return json.Marshal(*u.notebookDocumentFilterWithCells)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells{}
+		return nil
+	}
+	var v0 NotebookDocumentFilterWithNotebook
+	if decodeUnionVariant(data, &v0, []unionKey{{"notebook", ""}}) == nil {
+		u.SetNotebookDocumentFilterWithNotebook(v0)
+		return nil
+	}
+	var v1 NotebookDocumentFilterWithCells
+	if decodeUnionVariant(data, &v1, []unionKey{{"cells", ""}}) == nil {
+		u.SetNotebookDocumentFilterWithCells(v1)
+		return nil
+	}
+	return unionMismatch("OrNotebookDocumentFilterWithNotebookNotebookDocumentFilterWithCells", data)
+}
+
+// OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions holds one of NotebookDocumentSyncOptions, NotebookDocumentSyncRegistrationOptions. The zero value holds none and
+// is encoded as null.
+type OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions struct {
+	notebookDocumentSyncOptions *NotebookDocumentSyncOptions
+	notebookDocumentSyncRegistrationOptions *NotebookDocumentSyncRegistrationOptions
+}
+
+// AsNotebookDocumentSyncOptions returns the NotebookDocumentSyncOptions held by u, if any.
+func (u OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions) AsNotebookDocumentSyncOptions() (NotebookDocumentSyncOptions, bool) {
+	if u.notebookDocumentSyncOptions == nil {
+		var zero NotebookDocumentSyncOptions
+		return zero, false
+	}
+	return *u.notebookDocumentSyncOptions, true
+}
+
+// SetNotebookDocumentSyncOptions makes u hold v.
+func (u *OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions) SetNotebookDocumentSyncOptions(v NotebookDocumentSyncOptions) {
+	*u = OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions{notebookDocumentSyncOptions: &v}
+}
+
+// AsNotebookDocumentSyncRegistrationOptions returns the NotebookDocumentSyncRegistrationOptions held by u, if any.
+func (u OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions) AsNotebookDocumentSyncRegistrationOptions() (NotebookDocumentSyncRegistrationOptions, bool) {
+	if u.notebookDocumentSyncRegistrationOptions == nil {
+		var zero NotebookDocumentSyncRegistrationOptions
+		return zero, false
+	}
+	return *u.notebookDocumentSyncRegistrationOptions, true
+}
+
+// SetNotebookDocumentSyncRegistrationOptions makes u hold v.
+func (u *OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions) SetNotebookDocumentSyncRegistrationOptions(v NotebookDocumentSyncRegistrationOptions) {
+	*u = OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions{notebookDocumentSyncRegistrationOptions: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.notebookDocumentSyncOptions != nil:
+		return json.Marshal(*u.notebookDocumentSyncOptions)
+	case u.notebookDocumentSyncRegistrationOptions != nil:
+		return json.Marshal(*u.notebookDocumentSyncRegistrationOptions)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions{}
+		return nil
+	}
+	var v0 NotebookDocumentSyncOptions
+	if decodeUnionVariant(data, &v0, []unionKey{{"notebookSelector", ""}}, "id") == nil {
+		u.SetNotebookDocumentSyncOptions(v0)
+		return nil
+	}
+	var v1 NotebookDocumentSyncRegistrationOptions
+	if decodeUnionVariant(data, &v1, []unionKey{{"notebookSelector", ""}}) == nil {
+		u.SetNotebookDocumentSyncRegistrationOptions(v1)
+		return nil
+	}
+	return unionMismatch("OrNotebookDocumentSyncOptionsNotebookDocumentSyncRegistrationOptions", data)
+}
+
+// OrPatternRelativePattern holds one of Pattern, RelativePattern. The zero value holds none and
+// is encoded as null.
+type OrPatternRelativePattern struct {
+	pattern *Pattern
+	relativePattern *RelativePattern
+}
+
+// AsPattern returns the Pattern held by u, if any.
+func (u OrPatternRelativePattern) AsPattern() (Pattern, bool) {
+	if u.pattern == nil {
+		var zero Pattern
+		return zero, false
+	}
+	return *u.pattern, true
+}
+
+// SetPattern makes u hold v.
+func (u *OrPatternRelativePattern) SetPattern(v Pattern) {
+	*u = OrPatternRelativePattern{pattern: &v}
+}
+
+// AsRelativePattern returns the RelativePattern held by u, if any.
+func (u OrPatternRelativePattern) AsRelativePattern() (RelativePattern, bool) {
+	if u.relativePattern == nil {
+		var zero RelativePattern
+		return zero, false
+	}
+	return *u.relativePattern, true
+}
+
+// SetRelativePattern makes u hold v.
+func (u *OrPatternRelativePattern) SetRelativePattern(v RelativePattern) {
+	*u = OrPatternRelativePattern{relativePattern: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrPatternRelativePattern) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.pattern != nil:
+		return json.Marshal(*u.pattern)
+	case u.relativePattern != nil:
+		return json.Marshal(*u.relativePattern)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrPatternRelativePattern) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrPatternRelativePattern{}
+		return nil
+	}
+	var v0 Pattern
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetPattern(v0)
+		return nil
+	}
+	var v1 RelativePattern
+	if decodeUnionVariant(data, &v1, []unionKey{{"baseUri", ""}, {"pattern", ""}}) == nil {
+		u.SetRelativePattern(v1)
+		return nil
+	}
+	return unionMismatch("OrPatternRelativePattern", data)
+}
+
+// OrRangeEditRangeWithInsertReplace holds one of Range, EditRangeWithInsertReplace. The zero value holds none and
+// is encoded as null.
+type OrRangeEditRangeWithInsertReplace struct {
+	rangeValue *Range
+	editRangeWithInsertReplace *EditRangeWithInsertReplace
+}
+
+// AsRange returns the Range held by u, if any.
+func (u OrRangeEditRangeWithInsertReplace) AsRange() (Range, bool) {
+	if u.rangeValue == nil {
+		var zero Range
+		return zero, false
+	}
+	return *u.rangeValue, true
+}
+
+// SetRange makes u hold v.
+func (u *OrRangeEditRangeWithInsertReplace) SetRange(v Range) {
+	*u = OrRangeEditRangeWithInsertReplace{rangeValue: &v}
+}
+
+// AsEditRangeWithInsertReplace returns the EditRangeWithInsertReplace held by u, if any.
+func (u OrRangeEditRangeWithInsertReplace) AsEditRangeWithInsertReplace() (EditRangeWithInsertReplace, bool) {
+	if u.editRangeWithInsertReplace == nil {
+		var zero EditRangeWithInsertReplace
+		return zero, false
+	}
+	return *u.editRangeWithInsertReplace, true
+}
+
+// SetEditRangeWithInsertReplace makes u hold v.
+func (u *OrRangeEditRangeWithInsertReplace) SetEditRangeWithInsertReplace(v EditRangeWithInsertReplace) {
+	*u = OrRangeEditRangeWithInsertReplace{editRangeWithInsertReplace: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrRangeEditRangeWithInsertReplace) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.rangeValue != nil:
+		return json.Marshal(*u.rangeValue)
+	case u.editRangeWithInsertReplace != nil:
+		return json.Marshal(*u.editRangeWithInsertReplace)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrRangeEditRangeWithInsertReplace) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrRangeEditRangeWithInsertReplace{}
+		return nil
+	}
+	var v0 Range
+	if decodeUnionVariant(data, &v0, []unionKey{{"start", ""}, {"end", ""}}, "insert", "replace") == nil {
+		u.SetRange(v0)
+		return nil
+	}
+	var v1 EditRangeWithInsertReplace
+	if decodeUnionVariant(data, &v1, []unionKey{{"insert", ""}, {"replace", ""}}, "start", "end") == nil {
+		u.SetEditRangeWithInsertReplace(v1)
+		return nil
+	}
+	return unionMismatch("OrRangeEditRangeWithInsertReplace", data)
+}
+
+// OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior holds one of Range, PrepareRenamePlaceholder, PrepareRenameDefaultBehavior. The zero value holds none and
+// is encoded as null.
+type OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior struct {
+	rangeValue *Range
+	prepareRenamePlaceholder *PrepareRenamePlaceholder
+	prepareRenameDefaultBehavior *PrepareRenameDefaultBehavior
+}
+
+// AsRange returns the Range held by u, if any.
+func (u OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) AsRange() (Range, bool) {
+	if u.rangeValue == nil {
+		var zero Range
+		return zero, false
+	}
+	return *u.rangeValue, true
+}
+
+// SetRange makes u hold v.
+func (u *OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) SetRange(v Range) {
+	*u = OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior{rangeValue: &v}
+}
+
+// AsPrepareRenamePlaceholder returns the PrepareRenamePlaceholder held by u, if any.
+func (u OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) AsPrepareRenamePlaceholder() (PrepareRenamePlaceholder, bool) {
+	if u.prepareRenamePlaceholder == nil {
+		var zero PrepareRenamePlaceholder
+		return zero, false
+	}
+	return *u.prepareRenamePlaceholder, true
+}
+
+// SetPrepareRenamePlaceholder makes u hold v.
+func (u *OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) SetPrepareRenamePlaceholder(v PrepareRenamePlaceholder) {
+	*u = OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior{prepareRenamePlaceholder: &v}
+}
+
+// AsPrepareRenameDefaultBehavior returns the PrepareRenameDefaultBehavior held by u, if any.
+func (u OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) AsPrepareRenameDefaultBehavior() (PrepareRenameDefaultBehavior, bool) {
+	if u.prepareRenameDefaultBehavior == nil {
+		var zero PrepareRenameDefaultBehavior
+		return zero, false
+	}
+	return *u.prepareRenameDefaultBehavior, true
+}
+
+// SetPrepareRenameDefaultBehavior makes u hold v.
+func (u *OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) SetPrepareRenameDefaultBehavior(v PrepareRenameDefaultBehavior) {
+	*u = OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior{prepareRenameDefaultBehavior: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.rangeValue != nil:
+		return json.Marshal(*u.rangeValue)
+	case u.prepareRenamePlaceholder != nil:
+		return json.Marshal(*u.prepareRenamePlaceholder)
+	case u.prepareRenameDefaultBehavior != nil:
+		return json.Marshal(*u.prepareRenameDefaultBehavior)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior{}
+		return nil
+	}
+	var v0 Range
+	if decodeUnionVariant(data, &v0, []unionKey{{"start", ""}, {"end", ""}}, "range", "placeholder", "defaultBehavior") == nil {
+		u.SetRange(v0)
+		return nil
+	}
+	var v1 PrepareRenamePlaceholder
+	if decodeUnionVariant(data, &v1, []unionKey{{"range", ""}, {"placeholder", ""}}, "start", "end", "defaultBehavior") == nil {
+		u.SetPrepareRenamePlaceholder(v1)
+		return nil
+	}
+	var v2 PrepareRenameDefaultBehavior
+	if decodeUnionVariant(data, &v2, []unionKey{{"defaultBehavior", ""}}, "start", "end", "range", "placeholder") == nil {
+		u.SetPrepareRenameDefaultBehavior(v2)
+		return nil
+	}
+	return unionMismatch("OrRangePrepareRenamePlaceholderPrepareRenameDefaultBehavior", data)
+}
+
+// OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport holds one of RelatedFullDocumentDiagnosticReport, RelatedUnchangedDocumentDiagnosticReport. The zero value holds none and
+// is encoded as null.
+type OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport struct {
+	relatedFullDocumentDiagnosticReport *RelatedFullDocumentDiagnosticReport
+	relatedUnchangedDocumentDiagnosticReport *RelatedUnchangedDocumentDiagnosticReport
+}
+
+// AsRelatedFullDocumentDiagnosticReport returns the RelatedFullDocumentDiagnosticReport held by u, if any.
+func (u OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport) AsRelatedFullDocumentDiagnosticReport() (RelatedFullDocumentDiagnosticReport, bool) {
+	if u.relatedFullDocumentDiagnosticReport == nil {
+		var zero RelatedFullDocumentDiagnosticReport
+		return zero, false
+	}
+	return *u.relatedFullDocumentDiagnosticReport, true
+}
+
+// SetRelatedFullDocumentDiagnosticReport makes u hold v.
+func (u *OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport) SetRelatedFullDocumentDiagnosticReport(v RelatedFullDocumentDiagnosticReport) {
+	*u = OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport{relatedFullDocumentDiagnosticReport: &v}
+}
+
+// AsRelatedUnchangedDocumentDiagnosticReport returns the RelatedUnchangedDocumentDiagnosticReport held by u, if any.
+func (u OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport) AsRelatedUnchangedDocumentDiagnosticReport() (RelatedUnchangedDocumentDiagnosticReport, bool) {
+	if u.relatedUnchangedDocumentDiagnosticReport == nil {
+		var zero RelatedUnchangedDocumentDiagnosticReport
+		return zero, false
+	}
+	return *u.relatedUnchangedDocumentDiagnosticReport, true
+}
+
+// SetRelatedUnchangedDocumentDiagnosticReport makes u hold v.
+func (u *OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport) SetRelatedUnchangedDocumentDiagnosticReport(v RelatedUnchangedDocumentDiagnosticReport) {
+	*u = OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport{relatedUnchangedDocumentDiagnosticReport: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.relatedFullDocumentDiagnosticReport != nil:
+		return json.Marshal(*u.relatedFullDocumentDiagnosticReport)
+	case u.relatedUnchangedDocumentDiagnosticReport != nil:
+		return json.Marshal(*u.relatedUnchangedDocumentDiagnosticReport)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport{}
+		return nil
+	}
+	var v0 RelatedFullDocumentDiagnosticReport
+	if decodeUnionVariant(data, &v0, []unionKey{{"kind", "\"full\""}, {"items", ""}}) == nil {
+		u.SetRelatedFullDocumentDiagnosticReport(v0)
+		return nil
+	}
+	var v1 RelatedUnchangedDocumentDiagnosticReport
+	if decodeUnionVariant(data, &v1, []unionKey{{"kind", "\"unchanged\""}, {"resultId", ""}}, "items") == nil {
+		u.SetRelatedUnchangedDocumentDiagnosticReport(v1)
+		return nil
+	}
+	return unionMismatch("OrRelatedFullDocumentDiagnosticReportRelatedUnchangedDocumentDiagnosticReport", data)
+}
+
+// OrSemanticTokensOptionsSemanticTokensRegistrationOptions holds one of SemanticTokensOptions, SemanticTokensRegistrationOptions. The zero value holds none and
+// is encoded as null.
+type OrSemanticTokensOptionsSemanticTokensRegistrationOptions struct {
+	semanticTokensOptions *SemanticTokensOptions
+	semanticTokensRegistrationOptions *SemanticTokensRegistrationOptions
+}
+
+// AsSemanticTokensOptions returns the SemanticTokensOptions held by u, if any.
+func (u OrSemanticTokensOptionsSemanticTokensRegistrationOptions) AsSemanticTokensOptions() (SemanticTokensOptions, bool) {
+	if u.semanticTokensOptions == nil {
+		var zero SemanticTokensOptions
+		return zero, false
+	}
+	return *u.semanticTokensOptions, true
+}
+
+// SetSemanticTokensOptions makes u hold v.
+func (u *OrSemanticTokensOptionsSemanticTokensRegistrationOptions) SetSemanticTokensOptions(v SemanticTokensOptions) {
+	*u = OrSemanticTokensOptionsSemanticTokensRegistrationOptions{semanticTokensOptions: &v}
+}
+
+// AsSemanticTokensRegistrationOptions returns the SemanticTokensRegistrationOptions held by u, if any.
+func (u OrSemanticTokensOptionsSemanticTokensRegistrationOptions) AsSemanticTokensRegistrationOptions() (SemanticTokensRegistrationOptions, bool) {
+	if u.semanticTokensRegistrationOptions == nil {
+		var zero SemanticTokensRegistrationOptions
+		return zero, false
+	}
+	return *u.semanticTokensRegistrationOptions, true
+}
+
+// SetSemanticTokensRegistrationOptions makes u hold v.
+func (u *OrSemanticTokensOptionsSemanticTokensRegistrationOptions) SetSemanticTokensRegistrationOptions(v SemanticTokensRegistrationOptions) {
+	*u = OrSemanticTokensOptionsSemanticTokensRegistrationOptions{semanticTokensRegistrationOptions: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrSemanticTokensOptionsSemanticTokensRegistrationOptions) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.semanticTokensOptions != nil:
+		return json.Marshal(*u.semanticTokensOptions)
+	case u.semanticTokensRegistrationOptions != nil:
+		return json.Marshal(*u.semanticTokensRegistrationOptions)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrSemanticTokensOptionsSemanticTokensRegistrationOptions) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrSemanticTokensOptionsSemanticTokensRegistrationOptions{}
+		return nil
+	}
+	var v0 SemanticTokensOptions
+	if decodeUnionVariant(data, &v0, []unionKey{{"legend", ""}}, "documentSelector", "id") == nil {
+		u.SetSemanticTokensOptions(v0)
+		return nil
+	}
+	var v1 SemanticTokensRegistrationOptions
+	if decodeUnionVariant(data, &v1, []unionKey{{"documentSelector", ""}, {"legend", ""}}) == nil {
+		u.SetSemanticTokensRegistrationOptions(v1)
+		return nil
+	}
+	return unionMismatch("OrSemanticTokensOptionsSemanticTokensRegistrationOptions", data)
+}
+
+// OrSemanticTokensSemanticTokensDelta holds one of SemanticTokens, SemanticTokensDelta. The zero value holds none and
+// is encoded as null.
+type OrSemanticTokensSemanticTokensDelta struct {
+	semanticTokens *SemanticTokens
+	semanticTokensDelta *SemanticTokensDelta
+}
+
+// AsSemanticTokens returns the SemanticTokens held by u, if any.
+func (u OrSemanticTokensSemanticTokensDelta) AsSemanticTokens() (SemanticTokens, bool) {
+	if u.semanticTokens == nil {
+		var zero SemanticTokens
+		return zero, false
+	}
+	return *u.semanticTokens, true
+}
+
+// SetSemanticTokens makes u hold v.
+func (u *OrSemanticTokensSemanticTokensDelta) SetSemanticTokens(v SemanticTokens) {
+	*u = OrSemanticTokensSemanticTokensDelta{semanticTokens: &v}
+}
+
+// AsSemanticTokensDelta returns the SemanticTokensDelta held by u, if any.
+func (u OrSemanticTokensSemanticTokensDelta) AsSemanticTokensDelta() (SemanticTokensDelta, bool) {
+	if u.semanticTokensDelta == nil {
+		var zero SemanticTokensDelta
+		return zero, false
+	}
+	return *u.semanticTokensDelta, true
+}
+
+// SetSemanticTokensDelta makes u hold v.
+func (u *OrSemanticTokensSemanticTokensDelta) SetSemanticTokensDelta(v SemanticTokensDelta) {
+	*u = OrSemanticTokensSemanticTokensDelta{semanticTokensDelta: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrSemanticTokensSemanticTokensDelta) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.semanticTokens != nil:
+		return json.Marshal(*u.semanticTokens)
+	case u.semanticTokensDelta != nil:
+		return json.Marshal(*u.semanticTokensDelta)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrSemanticTokensSemanticTokensDelta) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrSemanticTokensSemanticTokensDelta{}
+		return nil
+	}
+	var v0 SemanticTokens
+	if decodeUnionVariant(data, &v0, []unionKey{{"data", ""}}, "edits") == nil {
+		u.SetSemanticTokens(v0)
+		return nil
+	}
+	var v1 SemanticTokensDelta
+	if decodeUnionVariant(data, &v1, []unionKey{{"edits", ""}}, "data") == nil {
+		u.SetSemanticTokensDelta(v1)
+		return nil
+	}
+	return unionMismatch("OrSemanticTokensSemanticTokensDelta", data)
+}
+
+// OrStringAny holds one of string, any. The zero value holds none and
+// is encoded as null.
+type OrStringAny struct {
+	string *string
+	any *any
+}
+
+// AsString returns the string held by u, if any.
+func (u OrStringAny) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrStringAny) SetString(v string) {
+	*u = OrStringAny{string: &v}
+}
+
+// AsAny returns the any held by u, if any.
+func (u OrStringAny) AsAny() (any, bool) {
+	if u.any == nil {
+		var zero any
+		return zero, false
+	}
+	return *u.any, true
+}
+
+// SetAny makes u hold v.
+func (u *OrStringAny) SetAny(v any) {
+	*u = OrStringAny{any: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrStringAny) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	case u.any != nil:
+		return json.Marshal(*u.any)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrStringAny) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrStringAny{}
+		return nil
+	}
+	var v0 string
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetString(v0)
+		return nil
+	}
+	var v1 any
+	if decodeUnionVariant(data, &v1, nil) == nil {
+		u.SetAny(v1)
+		return nil
+	}
+	return unionMismatch("OrStringAny", data)
+}
+
+// OrStringBool holds one of string, bool. The zero value holds none and
+// is encoded as null.
+type OrStringBool struct {
+	string *string
+	bool *bool
+}
+
+// AsString returns the string held by u, if any.
+func (u OrStringBool) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrStringBool) SetString(v string) {
+	*u = OrStringBool{string: &v}
+}
+
+// AsBool returns the bool held by u, if any.
+func (u OrStringBool) AsBool() (bool, bool) {
+	if u.bool == nil {
+		var zero bool
+		return zero, false
+	}
+	return *u.bool, true
+}
+
+// SetBool makes u hold v.
+func (u *OrStringBool) SetBool(v bool) {
+	*u = OrStringBool{bool: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrStringBool) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	case u.bool != nil:
+		return json.Marshal(*u.bool)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrStringBool) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrStringBool{}
+		return nil
+	}
+	var v0 string
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetString(v0)
+		return nil
+	}
+	var v1 bool
+	if decodeUnionVariant(data, &v1, nil) == nil {
+		u.SetBool(v1)
+		return nil
+	}
+	return unionMismatch("OrStringBool", data)
+}
+
+// OrStringInlayHintLabelPartArray holds one of string, []InlayHintLabelPart. The zero value holds none and
+// is encoded as null.
+type OrStringInlayHintLabelPartArray struct {
+	string *string
+	inlayHintLabelPartArray *[]InlayHintLabelPart
+}
+
+// AsString returns the string held by u, if any.
+func (u OrStringInlayHintLabelPartArray) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrStringInlayHintLabelPartArray) SetString(v string) {
+	*u = OrStringInlayHintLabelPartArray{string: &v}
+}
+
+// AsInlayHintLabelPartArray returns the []InlayHintLabelPart held by u, if any.
+func (u OrStringInlayHintLabelPartArray) AsInlayHintLabelPartArray() ([]InlayHintLabelPart, bool) {
+	if u.inlayHintLabelPartArray == nil {
+		var zero []InlayHintLabelPart
+		return zero, false
+	}
+	return *u.inlayHintLabelPartArray, true
+}
+
+// SetInlayHintLabelPartArray makes u hold v.
+func (u *OrStringInlayHintLabelPartArray) SetInlayHintLabelPartArray(v []InlayHintLabelPart) {
+	*u = OrStringInlayHintLabelPartArray{inlayHintLabelPartArray: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrStringInlayHintLabelPartArray) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	case u.inlayHintLabelPartArray != nil:
+		return json.Marshal(*u.inlayHintLabelPartArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrStringInlayHintLabelPartArray) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrStringInlayHintLabelPartArray{}
+		return nil
+	}
+	var v0 string
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetString(v0)
+		return nil
+	}
+	var v1 []InlayHintLabelPart
+	if decodeUnionVariant(data, &v1, []unionKey{{"value", ""}}) == nil {
+		u.SetInlayHintLabelPartArray(v1)
+		return nil
+	}
+	return unionMismatch("OrStringInlayHintLabelPartArray", data)
+}
+
+// OrStringMarkedStringWithLanguage holds one of string, MarkedStringWithLanguage. The zero value holds none and
+// is encoded as null.
+type OrStringMarkedStringWithLanguage struct {
+	string *string
+	markedStringWithLanguage *MarkedStringWithLanguage
+}
+
+// AsString returns the string held by u, if any.
+func (u OrStringMarkedStringWithLanguage) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrStringMarkedStringWithLanguage) SetString(v string) {
+	*u = OrStringMarkedStringWithLanguage{string: &v}
+}
+
+// AsMarkedStringWithLanguage returns the MarkedStringWithLanguage held by u, if any.
+func (u OrStringMarkedStringWithLanguage) AsMarkedStringWithLanguage() (MarkedStringWithLanguage, bool) {
+	if u.markedStringWithLanguage == nil {
+		var zero MarkedStringWithLanguage
+		return zero, false
+	}
+	return *u.markedStringWithLanguage, true
+}
+
+// SetMarkedStringWithLanguage makes u hold v.
+func (u *OrStringMarkedStringWithLanguage) SetMarkedStringWithLanguage(v MarkedStringWithLanguage) {
+	*u = OrStringMarkedStringWithLanguage{markedStringWithLanguage: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrStringMarkedStringWithLanguage) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	case u.markedStringWithLanguage != nil:
+		return json.Marshal(*u.markedStringWithLanguage)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrStringMarkedStringWithLanguage) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrStringMarkedStringWithLanguage{}
+		return nil
+	}
+	var v0 string
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetString(v0)
+		return nil
+	}
+	var v1 MarkedStringWithLanguage
+	if decodeUnionVariant(data, &v1, []unionKey{{"language", ""}, {"value", ""}}) == nil {
+		u.SetMarkedStringWithLanguage(v1)
+		return nil
+	}
+	return unionMismatch("OrStringMarkedStringWithLanguage", data)
+}
+
+// OrStringMarkupContent holds one of string, MarkupContent. The zero value holds none and
+// is encoded as null.
+type OrStringMarkupContent struct {
+	string *string
+	markupContent *MarkupContent
+}
+
+// AsString returns the string held by u, if any.
+func (u OrStringMarkupContent) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrStringMarkupContent) SetString(v string) {
+	*u = OrStringMarkupContent{string: &v}
+}
+
+// AsMarkupContent returns the MarkupContent held by u, if any.
+func (u OrStringMarkupContent) AsMarkupContent() (MarkupContent, bool) {
+	if u.markupContent == nil {
+		var zero MarkupContent
+		return zero, false
+	}
+	return *u.markupContent, true
+}
+
+// SetMarkupContent makes u hold v.
+func (u *OrStringMarkupContent) SetMarkupContent(v MarkupContent) {
+	*u = OrStringMarkupContent{markupContent: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrStringMarkupContent) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	case u.markupContent != nil:
+		return json.Marshal(*u.markupContent)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrStringMarkupContent) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrStringMarkupContent{}
+		return nil
+	}
+	var v0 string
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetString(v0)
+		return nil
+	}
+	var v1 MarkupContent
+	if decodeUnionVariant(data, &v1, []unionKey{{"kind", ""}, {"value", ""}}) == nil {
+		u.SetMarkupContent(v1)
+		return nil
+	}
+	return unionMismatch("OrStringMarkupContent", data)
+}
+
+// OrStringNotebookDocumentFilter holds one of string, NotebookDocumentFilter. The zero value holds none and
+// is encoded as null.
+type OrStringNotebookDocumentFilter struct {
+	string *string
+	notebookDocumentFilter *NotebookDocumentFilter
+}
+
+// AsString returns the string held by u, if any.
+func (u OrStringNotebookDocumentFilter) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrStringNotebookDocumentFilter) SetString(v string) {
+	*u = OrStringNotebookDocumentFilter{string: &v}
+}
+
+// AsNotebookDocumentFilter returns the NotebookDocumentFilter held by u, if any.
+func (u OrStringNotebookDocumentFilter) AsNotebookDocumentFilter() (NotebookDocumentFilter, bool) {
+	if u.notebookDocumentFilter == nil {
+		var zero NotebookDocumentFilter
+		return zero, false
+	}
+	return *u.notebookDocumentFilter, true
+}
+
+// SetNotebookDocumentFilter makes u hold v.
+func (u *OrStringNotebookDocumentFilter) SetNotebookDocumentFilter(v NotebookDocumentFilter) {
+	*u = OrStringNotebookDocumentFilter{notebookDocumentFilter: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrStringNotebookDocumentFilter) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	case u.notebookDocumentFilter != nil:
+		return json.Marshal(*u.notebookDocumentFilter)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrStringNotebookDocumentFilter) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrStringNotebookDocumentFilter{}
+		return nil
+	}
+	var v0 string
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetString(v0)
+		return nil
+	}
+	var v1 NotebookDocumentFilter
+	if decodeUnionVariant(data, &v1, nil) == nil {
+		u.SetNotebookDocumentFilter(v1)
+		return nil
+	}
+	return unionMismatch("OrStringNotebookDocumentFilter", data)
+}
+
+// OrStringStringArray holds one of string, []string. The zero value holds none and
+// is encoded as null.
+type OrStringStringArray struct {
+	string *string
+	stringArray *[]string
+}
+
+// AsString returns the string held by u, if any.
+func (u OrStringStringArray) AsString() (string, bool) {
+	if u.string == nil {
+		var zero string
+		return zero, false
+	}
+	return *u.string, true
+}
+
+// SetString makes u hold v.
+func (u *OrStringStringArray) SetString(v string) {
+	*u = OrStringStringArray{string: &v}
+}
+
+// AsStringArray returns the []string held by u, if any.
+func (u OrStringStringArray) AsStringArray() ([]string, bool) {
+	if u.stringArray == nil {
+		var zero []string
+		return zero, false
+	}
+	return *u.stringArray, true
+}
+
+// SetStringArray makes u hold v.
+func (u *OrStringStringArray) SetStringArray(v []string) {
+	*u = OrStringStringArray{stringArray: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrStringStringArray) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.string != nil:
+		return json.Marshal(*u.string)
+	case u.stringArray != nil:
+		return json.Marshal(*u.stringArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrStringStringArray) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrStringStringArray{}
+		return nil
+	}
+	var v0 string
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetString(v0)
+		return nil
+	}
+	var v1 []string
+	if decodeUnionVariant(data, &v1, nil) == nil {
+		u.SetStringArray(v1)
+		return nil
+	}
+	return unionMismatch("OrStringStringArray", data)
+}
+
+// OrSymbolInformationArrayDocumentSymbolArray holds one of []SymbolInformation, []DocumentSymbol. The zero value holds none and
+// is encoded as null.
+type OrSymbolInformationArrayDocumentSymbolArray struct {
+	symbolInformationArray *[]SymbolInformation
+	documentSymbolArray *[]DocumentSymbol
+}
+
+// AsSymbolInformationArray returns the []SymbolInformation held by u, if any.
+func (u OrSymbolInformationArrayDocumentSymbolArray) AsSymbolInformationArray() ([]SymbolInformation, bool) {
+	if u.symbolInformationArray == nil {
+		var zero []SymbolInformation
+		return zero, false
+	}
+	return *u.symbolInformationArray, true
+}
+
+// SetSymbolInformationArray makes u hold v.
+func (u *OrSymbolInformationArrayDocumentSymbolArray) SetSymbolInformationArray(v []SymbolInformation) {
+	*u = OrSymbolInformationArrayDocumentSymbolArray{symbolInformationArray: &v}
+}
+
+// AsDocumentSymbolArray returns the []DocumentSymbol held by u, if any.
+func (u OrSymbolInformationArrayDocumentSymbolArray) AsDocumentSymbolArray() ([]DocumentSymbol, bool) {
+	if u.documentSymbolArray == nil {
+		var zero []DocumentSymbol
+		return zero, false
+	}
+	return *u.documentSymbolArray, true
+}
+
+// SetDocumentSymbolArray makes u hold v.
+func (u *OrSymbolInformationArrayDocumentSymbolArray) SetDocumentSymbolArray(v []DocumentSymbol) {
+	*u = OrSymbolInformationArrayDocumentSymbolArray{documentSymbolArray: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrSymbolInformationArrayDocumentSymbolArray) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.symbolInformationArray != nil:
+		return json.Marshal(*u.symbolInformationArray)
+	case u.documentSymbolArray != nil:
+		return json.Marshal(*u.documentSymbolArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrSymbolInformationArrayDocumentSymbolArray) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrSymbolInformationArrayDocumentSymbolArray{}
+		return nil
+	}
+	var v0 []SymbolInformation
+	if decodeUnionVariant(data, &v0, []unionKey{{"location", ""}, {"name", ""}, {"kind", ""}}, "detail", "range", "selectionRange", "children") == nil {
+		u.SetSymbolInformationArray(v0)
+		return nil
+	}
+	var v1 []DocumentSymbol
+	if decodeUnionVariant(data, &v1, []unionKey{{"name", ""}, {"kind", ""}, {"range", ""}, {"selectionRange", ""}}, "location", "containerName") == nil {
+		u.SetDocumentSymbolArray(v1)
+		return nil
+	}
+	return unionMismatch("OrSymbolInformationArrayDocumentSymbolArray", data)
+}
+
+// OrSymbolInformationArrayWorkspaceSymbolArray holds one of []SymbolInformation, []WorkspaceSymbol. The zero value holds none and
+// is encoded as null.
+type OrSymbolInformationArrayWorkspaceSymbolArray struct {
+	symbolInformationArray *[]SymbolInformation
+	workspaceSymbolArray *[]WorkspaceSymbol
+}
+
+// AsSymbolInformationArray returns the []SymbolInformation held by u, if any.
+func (u OrSymbolInformationArrayWorkspaceSymbolArray) AsSymbolInformationArray() ([]SymbolInformation, bool) {
+	if u.symbolInformationArray == nil {
+		var zero []SymbolInformation
+		return zero, false
+	}
+	return *u.symbolInformationArray, true
+}
+
+// SetSymbolInformationArray makes u hold v.
+func (u *OrSymbolInformationArrayWorkspaceSymbolArray) SetSymbolInformationArray(v []SymbolInformation) {
+	*u = OrSymbolInformationArrayWorkspaceSymbolArray{symbolInformationArray: &v}
+}
+
+// AsWorkspaceSymbolArray returns the []WorkspaceSymbol held by u, if any.
+func (u OrSymbolInformationArrayWorkspaceSymbolArray) AsWorkspaceSymbolArray() ([]WorkspaceSymbol, bool) {
+	if u.workspaceSymbolArray == nil {
+		var zero []WorkspaceSymbol
+		return zero, false
+	}
+	return *u.workspaceSymbolArray, true
+}
+
+// SetWorkspaceSymbolArray makes u hold v.
+func (u *OrSymbolInformationArrayWorkspaceSymbolArray) SetWorkspaceSymbolArray(v []WorkspaceSymbol) {
+	*u = OrSymbolInformationArrayWorkspaceSymbolArray{workspaceSymbolArray: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrSymbolInformationArrayWorkspaceSymbolArray) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.symbolInformationArray != nil:
+		return json.Marshal(*u.symbolInformationArray)
+	case u.workspaceSymbolArray != nil:
+		return json.Marshal(*u.workspaceSymbolArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrSymbolInformationArrayWorkspaceSymbolArray) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrSymbolInformationArrayWorkspaceSymbolArray{}
+		return nil
+	}
+	var v0 []SymbolInformation
+	if decodeUnionVariant(data, &v0, []unionKey{{"location", ""}, {"name", ""}, {"kind", ""}}, "data") == nil {
+		u.SetSymbolInformationArray(v0)
+		return nil
+	}
+	var v1 []WorkspaceSymbol
+	if decodeUnionVariant(data, &v1, []unionKey{{"location", ""}, {"name", ""}, {"kind", ""}}, "deprecated") == nil {
+		u.SetWorkspaceSymbolArray(v1)
+		return nil
+	}
+	return unionMismatch("OrSymbolInformationArrayWorkspaceSymbolArray", data)
+}
+
+// OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument holds one of TextDocumentContentChangePartial, TextDocumentContentChangeWholeDocument. The zero value holds none and
+// is encoded as null.
+type OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument struct {
+	textDocumentContentChangePartial *TextDocumentContentChangePartial
+	textDocumentContentChangeWholeDocument *TextDocumentContentChangeWholeDocument
+}
+
+// AsTextDocumentContentChangePartial returns the TextDocumentContentChangePartial held by u, if any.
+func (u OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument) AsTextDocumentContentChangePartial() (TextDocumentContentChangePartial, bool) {
+	if u.textDocumentContentChangePartial == nil {
+		var zero TextDocumentContentChangePartial
+		return zero, false
+	}
+	return *u.textDocumentContentChangePartial, true
+}
+
+// SetTextDocumentContentChangePartial makes u hold v.
+func (u *OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument) SetTextDocumentContentChangePartial(v TextDocumentContentChangePartial) {
+	*u = OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument{textDocumentContentChangePartial: &v}
+}
+
+// AsTextDocumentContentChangeWholeDocument returns the TextDocumentContentChangeWholeDocument held by u, if any.
+func (u OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument) AsTextDocumentContentChangeWholeDocument() (TextDocumentContentChangeWholeDocument, bool) {
+	if u.textDocumentContentChangeWholeDocument == nil {
+		var zero TextDocumentContentChangeWholeDocument
+		return zero, false
+	}
+	return *u.textDocumentContentChangeWholeDocument, true
+}
+
+// SetTextDocumentContentChangeWholeDocument makes u hold v.
+func (u *OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument) SetTextDocumentContentChangeWholeDocument(v TextDocumentContentChangeWholeDocument) {
+	*u = OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument{textDocumentContentChangeWholeDocument: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.textDocumentContentChangePartial != nil:
+		return json.Marshal(*u.textDocumentContentChangePartial)
+	case u.textDocumentContentChangeWholeDocument != nil:
+		return json.Marshal(*u.textDocumentContentChangeWholeDocument)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument{}
+		return nil
+	}
+	var v0 TextDocumentContentChangePartial
+	if decodeUnionVariant(data, &v0, []unionKey{{"range", ""}, {"text", ""}}) == nil {
+		u.SetTextDocumentContentChangePartial(v0)
+		return nil
+	}
+	var v1 TextDocumentContentChangeWholeDocument
+	if decodeUnionVariant(data, &v1, []unionKey{{"text", ""}}, "range", "rangeLength") == nil {
+		u.SetTextDocumentContentChangeWholeDocument(v1)
+		return nil
+	}
+	return unionMismatch("OrTextDocumentContentChangePartialTextDocumentContentChangeWholeDocument", data)
+}
+
+// OrTextDocumentEditCreateFileRenameFileDeleteFile holds one of TextDocumentEdit, CreateFile, RenameFile, DeleteFile. The zero value holds none and
+// is encoded as null.
+type OrTextDocumentEditCreateFileRenameFileDeleteFile struct {
+	textDocumentEdit *TextDocumentEdit
+	createFile *CreateFile
+	renameFile *RenameFile
+	deleteFile *DeleteFile
+}
+
+// AsTextDocumentEdit returns the TextDocumentEdit held by u, if any.
+func (u OrTextDocumentEditCreateFileRenameFileDeleteFile) AsTextDocumentEdit() (TextDocumentEdit, bool) {
+	if u.textDocumentEdit == nil {
+		var zero TextDocumentEdit
+		return zero, false
+	}
+	return *u.textDocumentEdit, true
+}
+
+// SetTextDocumentEdit makes u hold v.
+func (u *OrTextDocumentEditCreateFileRenameFileDeleteFile) SetTextDocumentEdit(v TextDocumentEdit) {
+	*u = OrTextDocumentEditCreateFileRenameFileDeleteFile{textDocumentEdit: &v}
+}
+
+// AsCreateFile returns the CreateFile held by u, if any.
+func (u OrTextDocumentEditCreateFileRenameFileDeleteFile) AsCreateFile() (CreateFile, bool) {
+	if u.createFile == nil {
+		var zero CreateFile
+		return zero, false
+	}
+	return *u.createFile, true
+}
+
+// SetCreateFile makes u hold v.
+func (u *OrTextDocumentEditCreateFileRenameFileDeleteFile) SetCreateFile(v CreateFile) {
+	*u = OrTextDocumentEditCreateFileRenameFileDeleteFile{createFile: &v}
+}
+
+// AsRenameFile returns the RenameFile held by u, if any.
+func (u OrTextDocumentEditCreateFileRenameFileDeleteFile) AsRenameFile() (RenameFile, bool) {
+	if u.renameFile == nil {
+		var zero RenameFile
+		return zero, false
+	}
+	return *u.renameFile, true
+}
+
+// SetRenameFile makes u hold v.
+func (u *OrTextDocumentEditCreateFileRenameFileDeleteFile) SetRenameFile(v RenameFile) {
+	*u = OrTextDocumentEditCreateFileRenameFileDeleteFile{renameFile: &v}
+}
+
+// AsDeleteFile returns the DeleteFile held by u, if any.
+func (u OrTextDocumentEditCreateFileRenameFileDeleteFile) AsDeleteFile() (DeleteFile, bool) {
+	if u.deleteFile == nil {
+		var zero DeleteFile
+		return zero, false
+	}
+	return *u.deleteFile, true
+}
+
+// SetDeleteFile makes u hold v.
+func (u *OrTextDocumentEditCreateFileRenameFileDeleteFile) SetDeleteFile(v DeleteFile) {
+	*u = OrTextDocumentEditCreateFileRenameFileDeleteFile{deleteFile: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrTextDocumentEditCreateFileRenameFileDeleteFile) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.textDocumentEdit != nil:
+		return json.Marshal(*u.textDocumentEdit)
+	case u.createFile != nil:
+		return json.Marshal(*u.createFile)
+	case u.renameFile != nil:
+		return json.Marshal(*u.renameFile)
+	case u.deleteFile != nil:
+		return json.Marshal(*u.deleteFile)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrTextDocumentEditCreateFileRenameFileDeleteFile) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrTextDocumentEditCreateFileRenameFileDeleteFile{}
+		return nil
+	}
+	var v0 TextDocumentEdit
+	if decodeUnionVariant(data, &v0, []unionKey{{"textDocument", ""}, {"edits", ""}}, "kind", "uri", "options", "annotationId", "oldUri", "newUri") == nil {
+		u.SetTextDocumentEdit(v0)
+		return nil
+	}
+	var v1 CreateFile
+	if decodeUnionVariant(data, &v1, []unionKey{{"kind", "\"create\""}, {"uri", ""}}, "textDocument", "edits", "oldUri", "newUri") == nil {
+		u.SetCreateFile(v1)
+		return nil
+	}
+	var v2 RenameFile
+	if decodeUnionVariant(data, &v2, []unionKey{{"kind", "\"rename\""}, {"oldUri", ""}, {"newUri", ""}}, "textDocument", "edits", "uri") == nil {
+		u.SetRenameFile(v2)
+		return nil
+	}
+	var v3 DeleteFile
+	if decodeUnionVariant(data, &v3, []unionKey{{"kind", "\"delete\""}, {"uri", ""}}, "textDocument", "edits", "oldUri", "newUri") == nil {
+		u.SetDeleteFile(v3)
+		return nil
+	}
+	return unionMismatch("OrTextDocumentEditCreateFileRenameFileDeleteFile", data)
+}
+
+// OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern holds one of TextDocumentFilterLanguage, TextDocumentFilterScheme, TextDocumentFilterPattern. The zero value holds none and
+// is encoded as null.
+type OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern struct {
+	textDocumentFilterLanguage *TextDocumentFilterLanguage
+	textDocumentFilterScheme *TextDocumentFilterScheme
+	textDocumentFilterPattern *TextDocumentFilterPattern
+}
+
+// AsTextDocumentFilterLanguage returns the TextDocumentFilterLanguage held by u, if any.
+func (u OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) AsTextDocumentFilterLanguage() (TextDocumentFilterLanguage, bool) {
+	if u.textDocumentFilterLanguage == nil {
+		var zero TextDocumentFilterLanguage
+		return zero, false
+	}
+	return *u.textDocumentFilterLanguage, true
+}
+
+// SetTextDocumentFilterLanguage makes u hold v.
+func (u *OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) SetTextDocumentFilterLanguage(v TextDocumentFilterLanguage) {
+	*u = OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern{textDocumentFilterLanguage: &v}
+}
+
+// AsTextDocumentFilterScheme returns the TextDocumentFilterScheme held by u, if any.
+func (u OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) AsTextDocumentFilterScheme() (TextDocumentFilterScheme, bool) {
+	if u.textDocumentFilterScheme == nil {
+		var zero TextDocumentFilterScheme
+		return zero, false
+	}
+	return *u.textDocumentFilterScheme, true
+}
+
+// SetTextDocumentFilterScheme makes u hold v.
+func (u *OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) SetTextDocumentFilterScheme(v TextDocumentFilterScheme) {
+	*u = OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern{textDocumentFilterScheme: &v}
+}
+
+// AsTextDocumentFilterPattern returns the TextDocumentFilterPattern held by u, if any.
+func (u OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) AsTextDocumentFilterPattern() (TextDocumentFilterPattern, bool) {
+	if u.textDocumentFilterPattern == nil {
+		var zero TextDocumentFilterPattern
+		return zero, false
+	}
+	return *u.textDocumentFilterPattern, true
+}
+
+// SetTextDocumentFilterPattern makes u hold v.
+func (u *OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) SetTextDocumentFilterPattern(v TextDocumentFilterPattern) {
+	*u = OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern{textDocumentFilterPattern: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.textDocumentFilterLanguage != nil:
+		return json.Marshal(*u.textDocumentFilterLanguage)
+	case u.textDocumentFilterScheme != nil:
+		return json.Marshal(*u.textDocumentFilterScheme)
+	case u.textDocumentFilterPattern != nil:
+		return json.Marshal(*u.textDocumentFilterPattern)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern{}
+		return nil
+	}
+	var v0 TextDocumentFilterLanguage
+	if decodeUnionVariant(data, &v0, []unionKey{{"language", ""}}) == nil {
+		u.SetTextDocumentFilterLanguage(v0)
+		return nil
+	}
+	var v1 TextDocumentFilterScheme
+	if decodeUnionVariant(data, &v1, []unionKey{{"scheme", ""}}) == nil {
+		u.SetTextDocumentFilterScheme(v1)
+		return nil
+	}
+	var v2 TextDocumentFilterPattern
+	if decodeUnionVariant(data, &v2, []unionKey{{"pattern", ""}}) == nil {
+		u.SetTextDocumentFilterPattern(v2)
+		return nil
+	}
+	return unionMismatch("OrTextDocumentFilterLanguageTextDocumentFilterSchemeTextDocumentFilterPattern", data)
+}
+
+// OrTextDocumentFilterNotebookCellTextDocumentFilter holds one of TextDocumentFilter, NotebookCellTextDocumentFilter. The zero value holds none and
+// is encoded as null.
+type OrTextDocumentFilterNotebookCellTextDocumentFilter struct {
+	textDocumentFilter *TextDocumentFilter
+	notebookCellTextDocumentFilter *NotebookCellTextDocumentFilter
+}
+
+// AsTextDocumentFilter returns the TextDocumentFilter held by u, if any.
+func (u OrTextDocumentFilterNotebookCellTextDocumentFilter) AsTextDocumentFilter() (TextDocumentFilter, bool) {
+	if u.textDocumentFilter == nil {
+		var zero TextDocumentFilter
+		return zero, false
+	}
+	return *u.textDocumentFilter, true
+}
+
+// SetTextDocumentFilter makes u hold v.
+func (u *OrTextDocumentFilterNotebookCellTextDocumentFilter) SetTextDocumentFilter(v TextDocumentFilter) {
+	*u = OrTextDocumentFilterNotebookCellTextDocumentFilter{textDocumentFilter: &v}
+}
+
+// AsNotebookCellTextDocumentFilter returns the NotebookCellTextDocumentFilter held by u, if any.
+func (u OrTextDocumentFilterNotebookCellTextDocumentFilter) AsNotebookCellTextDocumentFilter() (NotebookCellTextDocumentFilter, bool) {
+	if u.notebookCellTextDocumentFilter == nil {
+		var zero NotebookCellTextDocumentFilter
+		return zero, false
+	}
+	return *u.notebookCellTextDocumentFilter, true
+}
+
+// SetNotebookCellTextDocumentFilter makes u hold v.
+func (u *OrTextDocumentFilterNotebookCellTextDocumentFilter) SetNotebookCellTextDocumentFilter(v NotebookCellTextDocumentFilter) {
+	*u = OrTextDocumentFilterNotebookCellTextDocumentFilter{notebookCellTextDocumentFilter: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrTextDocumentFilterNotebookCellTextDocumentFilter) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.textDocumentFilter != nil:
+		return json.Marshal(*u.textDocumentFilter)
+	case u.notebookCellTextDocumentFilter != nil:
+		return json.Marshal(*u.notebookCellTextDocumentFilter)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrTextDocumentFilterNotebookCellTextDocumentFilter) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrTextDocumentFilterNotebookCellTextDocumentFilter{}
+		return nil
+	}
+	var v0 TextDocumentFilter
+	if decodeUnionVariant(data, &v0, nil, "notebook") == nil {
+		u.SetTextDocumentFilter(v0)
+		return nil
+	}
+	var v1 NotebookCellTextDocumentFilter
+	if decodeUnionVariant(data, &v1, []unionKey{{"notebook", ""}}, "scheme", "pattern") == nil {
+		u.SetNotebookCellTextDocumentFilter(v1)
+		return nil
+	}
+	return unionMismatch("OrTextDocumentFilterNotebookCellTextDocumentFilter", data)
+}
+
+// OrTextDocumentSyncOptionsTextDocumentSyncKind holds one of TextDocumentSyncOptions, TextDocumentSyncKind. The zero value holds none and
+// is encoded as null.
+type OrTextDocumentSyncOptionsTextDocumentSyncKind struct {
+	textDocumentSyncOptions *TextDocumentSyncOptions
+	textDocumentSyncKind *TextDocumentSyncKind
+}
+
+// AsTextDocumentSyncOptions returns the TextDocumentSyncOptions held by u, if any.
+func (u OrTextDocumentSyncOptionsTextDocumentSyncKind) AsTextDocumentSyncOptions() (TextDocumentSyncOptions, bool) {
+	if u.textDocumentSyncOptions == nil {
+		var zero TextDocumentSyncOptions
+		return zero, false
+	}
+	return *u.textDocumentSyncOptions, true
+}
+
+// SetTextDocumentSyncOptions makes u hold v.
+func (u *OrTextDocumentSyncOptionsTextDocumentSyncKind) SetTextDocumentSyncOptions(v TextDocumentSyncOptions) {
+	*u = OrTextDocumentSyncOptionsTextDocumentSyncKind{textDocumentSyncOptions: &v}
+}
+
+// AsTextDocumentSyncKind returns the TextDocumentSyncKind held by u, if any.
+func (u OrTextDocumentSyncOptionsTextDocumentSyncKind) AsTextDocumentSyncKind() (TextDocumentSyncKind, bool) {
+	if u.textDocumentSyncKind == nil {
+		var zero TextDocumentSyncKind
+		return zero, false
+	}
+	return *u.textDocumentSyncKind, true
+}
+
+// SetTextDocumentSyncKind makes u hold v.
+func (u *OrTextDocumentSyncOptionsTextDocumentSyncKind) SetTextDocumentSyncKind(v TextDocumentSyncKind) {
+	*u = OrTextDocumentSyncOptionsTextDocumentSyncKind{textDocumentSyncKind: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrTextDocumentSyncOptionsTextDocumentSyncKind) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.textDocumentSyncOptions != nil:
+		return json.Marshal(*u.textDocumentSyncOptions)
+	case u.textDocumentSyncKind != nil:
+		return json.Marshal(*u.textDocumentSyncKind)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrTextDocumentSyncOptionsTextDocumentSyncKind) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrTextDocumentSyncOptionsTextDocumentSyncKind{}
+		return nil
+	}
+	var v0 TextDocumentSyncOptions
+	if decodeUnionVariant(data, &v0, nil) == nil {
+		u.SetTextDocumentSyncOptions(v0)
+		return nil
+	}
+	var v1 TextDocumentSyncKind
+	if decodeUnionVariant(data, &v1, nil) == nil {
+		u.SetTextDocumentSyncKind(v1)
+		return nil
+	}
+	return unionMismatch("OrTextDocumentSyncOptionsTextDocumentSyncKind", data)
+}
+
+// OrTextEditAnnotatedTextEdit holds one of TextEdit, AnnotatedTextEdit. The zero value holds none and
+// is encoded as null.
+type OrTextEditAnnotatedTextEdit struct {
+	textEdit *TextEdit
+	annotatedTextEdit *AnnotatedTextEdit
+}
+
+// AsTextEdit returns the TextEdit held by u, if any.
+func (u OrTextEditAnnotatedTextEdit) AsTextEdit() (TextEdit, bool) {
+	if u.textEdit == nil {
+		var zero TextEdit
+		return zero, false
+	}
+	return *u.textEdit, true
+}
+
+// SetTextEdit makes u hold v.
+func (u *OrTextEditAnnotatedTextEdit) SetTextEdit(v TextEdit) {
+	*u = OrTextEditAnnotatedTextEdit{textEdit: &v}
+}
+
+// AsAnnotatedTextEdit returns the AnnotatedTextEdit held by u, if any.
+func (u OrTextEditAnnotatedTextEdit) AsAnnotatedTextEdit() (AnnotatedTextEdit, bool) {
+	if u.annotatedTextEdit == nil {
+		var zero AnnotatedTextEdit
+		return zero, false
+	}
+	return *u.annotatedTextEdit, true
+}
+
+// SetAnnotatedTextEdit makes u hold v.
+func (u *OrTextEditAnnotatedTextEdit) SetAnnotatedTextEdit(v AnnotatedTextEdit) {
+	*u = OrTextEditAnnotatedTextEdit{annotatedTextEdit: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrTextEditAnnotatedTextEdit) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.textEdit != nil:
+		return json.Marshal(*u.textEdit)
+	case u.annotatedTextEdit != nil:
+		return json.Marshal(*u.annotatedTextEdit)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrTextEditAnnotatedTextEdit) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrTextEditAnnotatedTextEdit{}
+		return nil
+	}
+	var v0 TextEdit
+	if decodeUnionVariant(data, &v0, []unionKey{{"range", ""}, {"newText", ""}}, "annotationId") == nil {
+		u.SetTextEdit(v0)
+		return nil
+	}
+	var v1 AnnotatedTextEdit
+	if decodeUnionVariant(data, &v1, []unionKey{{"annotationId", ""}, {"range", ""}, {"newText", ""}}) == nil {
+		u.SetAnnotatedTextEdit(v1)
+		return nil
+	}
+	return unionMismatch("OrTextEditAnnotatedTextEdit", data)
+}
+
+// OrTextEditInsertReplaceEdit holds one of TextEdit, InsertReplaceEdit. The zero value holds none and
+// is encoded as null.
+type OrTextEditInsertReplaceEdit struct {
+	textEdit *TextEdit
+	insertReplaceEdit *InsertReplaceEdit
+}
+
+// AsTextEdit returns the TextEdit held by u, if any.
+func (u OrTextEditInsertReplaceEdit) AsTextEdit() (TextEdit, bool) {
+	if u.textEdit == nil {
+		var zero TextEdit
+		return zero, false
+	}
+	return *u.textEdit, true
+}
+
+// SetTextEdit makes u hold v.
+func (u *OrTextEditInsertReplaceEdit) SetTextEdit(v TextEdit) {
+	*u = OrTextEditInsertReplaceEdit{textEdit: &v}
+}
+
+// AsInsertReplaceEdit returns the InsertReplaceEdit held by u, if any.
+func (u OrTextEditInsertReplaceEdit) AsInsertReplaceEdit() (InsertReplaceEdit, bool) {
+	if u.insertReplaceEdit == nil {
+		var zero InsertReplaceEdit
+		return zero, false
+	}
+	return *u.insertReplaceEdit, true
+}
+
+// SetInsertReplaceEdit makes u hold v.
+func (u *OrTextEditInsertReplaceEdit) SetInsertReplaceEdit(v InsertReplaceEdit) {
+	*u = OrTextEditInsertReplaceEdit{insertReplaceEdit: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrTextEditInsertReplaceEdit) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.textEdit != nil:
+		return json.Marshal(*u.textEdit)
+	case u.insertReplaceEdit != nil:
+		return json.Marshal(*u.insertReplaceEdit)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrTextEditInsertReplaceEdit) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrTextEditInsertReplaceEdit{}
+		return nil
+	}
+	var v0 TextEdit
+	if decodeUnionVariant(data, &v0, []unionKey{{"range", ""}, {"newText", ""}}, "insert", "replace") == nil {
+		u.SetTextEdit(v0)
+		return nil
+	}
+	var v1 InsertReplaceEdit
+	if decodeUnionVariant(data, &v1, []unionKey{{"newText", ""}, {"insert", ""}, {"replace", ""}}, "range") == nil {
+		u.SetInsertReplaceEdit(v1)
+		return nil
+	}
+	return unionMismatch("OrTextEditInsertReplaceEdit", data)
+}
+
+// OrWorkspaceFolderURI holds one of WorkspaceFolder, URI. The zero value holds none and
+// is encoded as null.
+type OrWorkspaceFolderURI struct {
+	workspaceFolder *WorkspaceFolder
+	uri *URI
+}
+
+// AsWorkspaceFolder returns the WorkspaceFolder held by u, if any.
+func (u OrWorkspaceFolderURI) AsWorkspaceFolder() (WorkspaceFolder, bool) {
+	if u.workspaceFolder == nil {
+		var zero WorkspaceFolder
+		return zero, false
+	}
+	return *u.workspaceFolder, true
+}
+
+// SetWorkspaceFolder makes u hold v.
+func (u *OrWorkspaceFolderURI) SetWorkspaceFolder(v WorkspaceFolder) {
+	*u = OrWorkspaceFolderURI{workspaceFolder: &v}
+}
+
+// AsURI returns the URI held by u, if any.
+func (u OrWorkspaceFolderURI) AsURI() (URI, bool) {
+	if u.uri == nil {
+		var zero URI
+		return zero, false
+	}
+	return *u.uri, true
+}
+
+// SetURI makes u hold v.
+func (u *OrWorkspaceFolderURI) SetURI(v URI) {
+	*u = OrWorkspaceFolderURI{uri: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrWorkspaceFolderURI) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.workspaceFolder != nil:
+		return json.Marshal(*u.workspaceFolder)
+	case u.uri != nil:
+		return json.Marshal(*u.uri)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrWorkspaceFolderURI) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrWorkspaceFolderURI{}
+		return nil
+	}
+	var v0 WorkspaceFolder
+	if decodeUnionVariant(data, &v0, []unionKey{{"uri", ""}, {"name", ""}}) == nil {
+		u.SetWorkspaceFolder(v0)
+		return nil
+	}
+	var v1 URI
+	if decodeUnionVariant(data, &v1, nil) == nil {
+		u.SetURI(v1)
+		return nil
+	}
+	return unionMismatch("OrWorkspaceFolderURI", data)
+}
+
+// OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport holds one of WorkspaceFullDocumentDiagnosticReport, WorkspaceUnchangedDocumentDiagnosticReport. The zero value holds none and
+// is encoded as null.
+type OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport struct {
+	workspaceFullDocumentDiagnosticReport *WorkspaceFullDocumentDiagnosticReport
+	workspaceUnchangedDocumentDiagnosticReport *WorkspaceUnchangedDocumentDiagnosticReport
+}
+
+// AsWorkspaceFullDocumentDiagnosticReport returns the WorkspaceFullDocumentDiagnosticReport held by u, if any.
+func (u OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport) AsWorkspaceFullDocumentDiagnosticReport() (WorkspaceFullDocumentDiagnosticReport, bool) {
+	if u.workspaceFullDocumentDiagnosticReport == nil {
+		var zero WorkspaceFullDocumentDiagnosticReport
+		return zero, false
+	}
+	return *u.workspaceFullDocumentDiagnosticReport, true
+}
+
+// SetWorkspaceFullDocumentDiagnosticReport makes u hold v.
+func (u *OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport) SetWorkspaceFullDocumentDiagnosticReport(v WorkspaceFullDocumentDiagnosticReport) {
+	*u = OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport{workspaceFullDocumentDiagnosticReport: &v}
+}
+
+// AsWorkspaceUnchangedDocumentDiagnosticReport returns the WorkspaceUnchangedDocumentDiagnosticReport held by u, if any.
+func (u OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport) AsWorkspaceUnchangedDocumentDiagnosticReport() (WorkspaceUnchangedDocumentDiagnosticReport, bool) {
+	if u.workspaceUnchangedDocumentDiagnosticReport == nil {
+		var zero WorkspaceUnchangedDocumentDiagnosticReport
+		return zero, false
+	}
+	return *u.workspaceUnchangedDocumentDiagnosticReport, true
+}
+
+// SetWorkspaceUnchangedDocumentDiagnosticReport makes u hold v.
+func (u *OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport) SetWorkspaceUnchangedDocumentDiagnosticReport(v WorkspaceUnchangedDocumentDiagnosticReport) {
+	*u = OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport{workspaceUnchangedDocumentDiagnosticReport: &v}
+}
+
+// MarshalJSON encodes the variant held by u, or null if it holds none.
+func (u OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.workspaceFullDocumentDiagnosticReport != nil:
+		return json.Marshal(*u.workspaceFullDocumentDiagnosticReport)
+	case u.workspaceUnchangedDocumentDiagnosticReport != nil:
+		return json.Marshal(*u.workspaceUnchangedDocumentDiagnosticReport)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes data as the first variant, in specification
+// order, that accepts it.
+func (u *OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*u = OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport{}
+		return nil
+	}
+	var v0 WorkspaceFullDocumentDiagnosticReport
+	if decodeUnionVariant(data, &v0, []unionKey{{"uri", ""}, {"version", ""}, {"kind", "\"full\""}, {"items", ""}}) == nil {
+		u.SetWorkspaceFullDocumentDiagnosticReport(v0)
+		return nil
+	}
+	var v1 WorkspaceUnchangedDocumentDiagnosticReport
+	if decodeUnionVariant(data, &v1, []unionKey{{"uri", ""}, {"version", ""}, {"kind", "\"unchanged\""}, {"resultId", ""}}, "items") == nil {
+		u.SetWorkspaceUnchangedDocumentDiagnosticReport(v1)
+		return nil
+	}
+	return unionMismatch("OrWorkspaceFullDocumentDiagnosticReportWorkspaceUnchangedDocumentDiagnosticReport", data)
+}
+
+// NewSetTraceParams returns a SetTraceParams for the "$/setTrace" notification.
+func NewSetTraceParams(value TraceValue) SetTraceParams {
+	return SetTraceParams{Value: value}
+}
+
+// NewDidSaveNotebookDocumentParams returns a DidSaveNotebookDocumentParams for the "notebookDocument/didSave" notification.
+func NewDidSaveNotebookDocumentParams(uri URI) DidSaveNotebookDocumentParams {
+	return DidSaveNotebookDocumentParams{NotebookDocument: NotebookDocumentIdentifier{URI: uri}}
+}
+
+// NewDidCloseTextDocumentParams returns a DidCloseTextDocumentParams for the "textDocument/didClose" notification.
+func NewDidCloseTextDocumentParams(uri DocumentURI) DidCloseTextDocumentParams {
+	return DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}}
+}
+
+// NewDidOpenTextDocumentParams returns a DidOpenTextDocumentParams for the "textDocument/didOpen" notification.
+func NewDidOpenTextDocumentParams(uri DocumentURI, languageID LanguageKind, version int32, text string) DidOpenTextDocumentParams {
+	return DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, LanguageId: languageID, Version: version, Text: text}}
+}
+
+// NewDidSaveTextDocumentParams returns a DidSaveTextDocumentParams for the "textDocument/didSave" notification.
+func NewDidSaveTextDocumentParams(uri DocumentURI) DidSaveTextDocumentParams {
+	return DidSaveTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}}
+}
+
+// NewWillSaveTextDocumentParams returns a WillSaveTextDocumentParams for the "textDocument/willSave" notification.
+func NewWillSaveTextDocumentParams(uri DocumentURI, reason TextDocumentSaveReason) WillSaveTextDocumentParams {
+	return WillSaveTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}, Reason: reason}
+}
+
 // Ensure json import is used.
 var _ = json.RawMessage{}
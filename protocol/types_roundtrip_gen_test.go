@@ -0,0 +1,140 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+// Code generated by go-lsp/cmd/generate; DO NOT EDIT.
+// LSP version: 3.17.0
+
+package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGeneratedRoundTrip_Position(t *testing.T) {
+	orig := Position{Line: 7, Character: 7}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got Position
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+func TestGeneratedRoundTrip_Range(t *testing.T) {
+	orig := Range{Start: Position{Line: 7, Character: 7}, End: Position{Line: 7, Character: 7}}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got Range
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+func TestGeneratedRoundTrip_Location(t *testing.T) {
+	orig := Location{URI: "file:///sample.go", Range: Range{Start: Position{Line: 7, Character: 7}, End: Position{Line: 7, Character: 7}}}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got Location
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+func TestGeneratedRoundTrip_TextEdit(t *testing.T) {
+	orig := TextEdit{Range: Range{Start: Position{Line: 7, Character: 7}, End: Position{Line: 7, Character: 7}}, NewText: "sample"}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got TextEdit
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+func TestGeneratedRoundTrip_TextDocumentItem(t *testing.T) {
+	orig := TextDocumentItem{URI: "file:///sample.go", LanguageId: LanguageKindABAP, Version: 7, Text: "sample"}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got TextDocumentItem
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+func TestGeneratedRoundTrip_MarkupContent(t *testing.T) {
+	orig := MarkupContent{Kind: MarkupKindPlainText, Value: "sample"}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got MarkupContent
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+func TestGeneratedRoundTrip_Diagnostic(t *testing.T) {
+	orig := Diagnostic{Range: Range{Start: Position{Line: 7, Character: 7}, End: Position{Line: 7, Character: 7}}, Message: "sample"}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got Diagnostic
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+func TestGeneratedRoundTrip_ServerInfo(t *testing.T) {
+	orig := ServerInfo{Name: "sample"}
+	data, err := json.Marshal(orig)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got ServerInfo
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(orig, got) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", got, orig)
+	}
+}
+
+// Ensure imports are used.
+var (
+	_ = json.Marshal
+	_ = reflect.DeepEqual
+	_ testing.TB
+)
@@ -93,10 +93,10 @@ func TestTypesJSONRoundTrip_InitializeResult(t *testing.T) {
 
 func TestTypesJSONRoundTrip_Hover(t *testing.T) {
 	orig := Hover{
-		Contents: MarkupContent{
+		Contents: NewMarkupHoverContents(MarkupContent{
 			Kind:  MarkupKindMarkdown,
 			Value: "# Hello\nWorld",
-		},
+		}),
 		Range: &Range{
 			Start: Position{Line: 0, Character: 0},
 			End:   Position{Line: 0, Character: 5},
@@ -112,9 +112,10 @@ func TestTypesJSONRoundTrip_Hover(t *testing.T) {
 	assert.Equal(t, uint32(0), got.Range.Start.Line)
 	assert.Equal(t, uint32(5), got.Range.End.Character)
 
-	contentsMap, ok := got.Contents.(map[string]any)
-	require.True(t, ok, "Contents should be map[string]any, got %T", got.Contents)
-	assert.Equal(t, string(MarkupKindMarkdown), contentsMap["kind"])
+	content, ok := got.Contents.AsMarkupContent()
+	require.True(t, ok, "Contents should hold a MarkupContent")
+	assert.Equal(t, MarkupKindMarkdown, content.Kind)
+	assert.Equal(t, "# Hello\nWorld", content.Value)
 }
 
 func TestTypesJSONRoundTrip_Diagnostic(t *testing.T) {
@@ -216,3 +217,16 @@ func TestOptionalPointerFields(t *testing.T) {
 		assert.Nil(t, got.Version)
 	})
 }
+
+func TestNotificationParamsConstructors(t *testing.T) {
+	open := NewDidOpenTextDocumentParams("file:///main.go", LanguageKind("go"), 1, "package main")
+	assert.Equal(t, TextDocumentItem{
+		URI:        "file:///main.go",
+		LanguageId: "go",
+		Version:    1,
+		Text:       "package main",
+	}, open.TextDocument)
+
+	closeParams := NewDidCloseTextDocumentParams("file:///main.go")
+	assert.Equal(t, DocumentURI("file:///main.go"), closeParams.TextDocument.URI)
+}
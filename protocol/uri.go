@@ -9,7 +9,11 @@ package protocol
 // See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#uri
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/url"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -32,6 +36,7 @@ type (
 //
 //	URIFromPath("/home/user/file.go") => "file:///home/user/file.go"
 //	URIFromPath("C:\\Users\\file.go") => "file:///C:/Users/file.go"  (Windows)
+//	URIFromPath("\\\\server\\share\\x") => "file://server/share/x"  (Windows UNC)
 func URIFromPath(path string) DocumentURI {
 	if path == "" {
 		return ""
@@ -40,6 +45,11 @@ func URIFromPath(path string) DocumentURI {
 	// Normalize to forward slashes.
 	path = filepath.ToSlash(path)
 
+	// A UNC path //server/share/... names the server as the URI's host.
+	if strings.HasPrefix(path, "//") {
+		return DocumentURI("file:" + path)
+	}
+
 	// On Windows, paths like "C:/..." need a leading slash in the URI.
 	if len(path) > 0 && path[0] != '/' {
 		path = "/" + path
@@ -68,8 +78,8 @@ func (u DocumentURI) Path() string {
 		path = "//" + parsed.Host + path
 	}
 
-	// On Windows, /C:/foo → C:\foo
-	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+	// On Windows, /C:/foo → C:\foo. Other paths keep their leading slash.
+	if runtime.GOOS == "windows" && hasDriveLetter(path) {
 		path = path[1:]
 	}
 
@@ -82,7 +92,211 @@ func (u DocumentURI) Filename() string {
 	return u.Path()
 }
 
+// Valid returns an error if u is not a well-formed absolute URI: if it
+// contains control characters, does not parse, or has no scheme. The empty
+// URI, the zero value of unset fields, is valid.
+func (u DocumentURI) Valid() error {
+	if u == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(string(u))
+	if err != nil {
+		return fmt.Errorf("invalid document URI %q: %w", string(u), errors.Unwrap(err))
+	}
+
+	if parsed.Scheme == "" {
+		return fmt.Errorf("invalid document URI %q: missing scheme", string(u)) //nolint:err113
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. It emits u with its path
+// percent-encoded as CanonicalKey encodes it, so file:///my file.go and
+// file:///c%3A/x.go are sent as file:///my%20file.go and file:///c:/x.go.
+// Unlike CanonicalKey it keeps the case, dot segments, query and fragment of
+// u. A URI that does not parse is emitted unchanged.
+func (u DocumentURI) MarshalJSON() ([]byte, error) {
+	text := string(u)
+
+	if parsed, err := url.Parse(text); err == nil { //nolint:noinlineerr
+		normalizeURL(parsed)
+		text = parsed.String()
+	}
+
+	return json.Marshal(text) //nolint:wrapcheck
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It rejects URIs for which Valid
+// returns an error, so that requests carrying them are answered with an
+// invalid params error.
+func (u *DocumentURI) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil { //nolint:noinlineerr
+		return fmt.Errorf("document URI: %w", err)
+	}
+
+	if err := DocumentURI(text).Valid(); err != nil { //nolint:noinlineerr
+		return err
+	}
+
+	*u = DocumentURI(text)
+
+	return nil
+}
+
 // IsFile reports whether the URI has a "file" scheme.
 func (u DocumentURI) IsFile() bool {
 	return strings.HasPrefix(string(u), "file://")
 }
+
+// CanonicalKey returns a stable key for the document u names, for keying
+// maps of documents. It re-encodes the path so that spellings such as
+// file:///c%3A/x.go and file:///c:/x.go share a key, and resolves "." and
+// ".." segments. For file URIs it also drops the query and fragment some
+// editors append, as in file:///x.go?version=2, and on Windows, where drive
+// letters are case-insensitive, lowercases the drive letter. Other schemes
+// keep their query and fragment, which may tell documents apart: a git: URI
+// names the revision in its query, and a vscode-notebook-cell: URI names
+// the cell in its fragment. A file URI that cannot be parsed is cut at its
+// first '?' or '#' instead.
+func (u DocumentURI) CanonicalKey() DocumentURI {
+	return u.canonicalKey(runtime.GOOS == "windows")
+}
+
+// Equal reports whether u and other name the same document, that is whether
+// they have the same CanonicalKey. Unlike ==, it is insensitive to the
+// percent-encoding and dot segments of the URIs, and to the query and
+// fragment of file URIs.
+func (u DocumentURI) Equal(other DocumentURI) bool {
+	return u.CanonicalKey() == other.CanonicalKey()
+}
+
+// canonicalKey is CanonicalKey, lowercasing drive letters if windows is set.
+func (u DocumentURI) canonicalKey(windows bool) DocumentURI {
+	parsed, err := url.Parse(string(u))
+	if err != nil {
+		if !strings.HasPrefix(strings.ToLower(string(u)), "file:") {
+			return u
+		}
+
+		key, _, _ := strings.Cut(string(u), "#")
+		key, _, _ = strings.Cut(key, "?")
+
+		return DocumentURI(key)
+	}
+
+	normalizeURL(parsed)
+
+	if parsed.Scheme == "file" {
+		dropQuery(parsed)
+	}
+
+	if strings.HasPrefix(parsed.Path, "/") {
+		cleaned := path.Clean(parsed.Path)
+		if strings.HasSuffix(parsed.Path, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+
+		parsed.Path = cleaned
+	}
+
+	if windows && parsed.Scheme == "file" && hasDriveLetter(parsed.Path) {
+		parsed.Path = "/" + strings.ToLower(parsed.Path[1:2]) + parsed.Path[2:]
+	}
+
+	return DocumentURI(parsed.String())
+}
+
+// hasDriveLetter reports whether the URI path p starts with a Windows drive
+// letter, as in /C:/Users.
+func hasDriveLetter(p string) bool {
+	return len(p) >= 3 && p[0] == '/' && p[2] == ':' && (len(p) == 3 || p[3] == '/') &&
+		('a' <= p[1] && p[1] <= 'z' || 'A' <= p[1] && p[1] <= 'Z')
+}
+
+// Dir returns the URI of the directory containing u, with the query and
+// fragment dropped: file:///a/b/c.go gives file:///a/b. A trailing slash is
+// ignored, so file:///a/b/ gives file:///a too. The root is its own
+// directory. URIs without a hierarchical path, such as untitled:Untitled-1,
+// and URIs that cannot be parsed are returned unchanged.
+func (u DocumentURI) Dir() DocumentURI {
+	parsed, ok := u.hierarchical()
+	if !ok {
+		return u
+	}
+
+	if parsed.Path != "/" {
+		parsed.Path = path.Dir(strings.TrimSuffix(parsed.Path, "/"))
+	}
+
+	return DocumentURI(parsed.String())
+}
+
+// Base returns the last segment of the decoded path of u, ignoring a
+// trailing slash: file:///a/my%20file.go gives "my file.go". It is "/" for
+// the root and empty for URIs without a hierarchical path or that cannot be
+// parsed.
+func (u DocumentURI) Base() string {
+	parsed, ok := u.hierarchical()
+	if !ok {
+		return ""
+	}
+
+	if parsed.Path == "/" {
+		return "/"
+	}
+
+	return path.Base(strings.TrimSuffix(parsed.Path, "/"))
+}
+
+// Join returns the URI of u's path joined with elem, with the query and
+// fragment dropped. The elements are decoded path segments, percent-encoded
+// as needed, and may contain slashes; "." and ".." segments are resolved as
+// by path.Join. A trailing slash on the last element is kept. URIs without a
+// hierarchical path and URIs that cannot be parsed are returned unchanged.
+func (u DocumentURI) Join(elem ...string) DocumentURI {
+	parsed, ok := u.hierarchical()
+	if !ok {
+		return u
+	}
+
+	joined := path.Join(append([]string{parsed.Path}, elem...)...)
+	if len(elem) > 0 && strings.HasSuffix(elem[len(elem)-1], "/") && joined != "/" {
+		joined += "/"
+	}
+
+	parsed.Path = joined
+
+	return DocumentURI(parsed.String())
+}
+
+// hierarchical parses u for path manipulation, dropping its query and
+// fragment. It reports false if u cannot be parsed or has no hierarchical
+// path.
+func (u DocumentURI) hierarchical() (*url.URL, bool) {
+	parsed, err := url.Parse(string(u))
+	if err != nil || parsed.Opaque != "" || !strings.HasPrefix(parsed.Path, "/") {
+		return nil, false
+	}
+
+	normalizeURL(parsed)
+	dropQuery(parsed)
+
+	return parsed, true
+}
+
+// normalizeURL makes u encode its path afresh, so that equivalent
+// percent-encodings print the same.
+func normalizeURL(u *url.URL) {
+	u.RawPath = ""
+}
+
+// dropQuery drops the query and fragment of u.
+func dropQuery(u *url.URL) {
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+}
@@ -4,11 +4,14 @@
 package protocol
 
 import (
+	"context"
+	"encoding/json"
 	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.lsp.dev/jsonrpc2"
 )
 
 func TestURIFromPath(t *testing.T) {
@@ -26,6 +29,7 @@ func TestURIFromPath(t *testing.T) {
 			"/home/user/my project/file.go",
 			"file:///home/user/my project/file.go",
 		},
+		{"UNC", "//server/share/x", "file://server/share/x"},
 	}
 
 	for _, tt := range tests {
@@ -50,6 +54,8 @@ func TestDocumentURI_Path(t *testing.T) {
 		{"unparseable URI", DocumentURI([]byte{0x7f}), string([]byte{0x7f})},
 		{"empty", "", ""},
 		{"file URI root", "file:///", "/"},
+		{"UNC", "file://server/share/x", "//server/share/x"},
+		{"drive-like path kept", "file:///c:/foo", "/c:/foo"},
 	}
 
 	for _, tt := range tests {
@@ -84,6 +90,263 @@ func TestDocumentURI_IsFile(t *testing.T) {
 	}
 }
 
+func TestDocumentURI_Valid(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     DocumentURI
+		wantErr string
+	}{
+		{"file", "file:///home/user/file.go", ""},
+		{"opaque", "untitled:Untitled-1", ""},
+		{"empty", "", ""},
+		{"control character", "file:///a\x00.go", "invalid control character"},
+		{"newline", "file:///a\n.go", "invalid control character"},
+		{"bad escape", "file:///a%zz.go", "invalid URL escape"},
+		{"missing scheme", "/home/user/file.go", "missing scheme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.uri.Valid()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDocumentURI_JSON(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		uri := DocumentURI("file:///home/user/my%20file.go")
+		data, err := json.Marshal(TextDocumentIdentifier{URI: uri})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"uri": "file:///home/user/my%20file.go"}`, string(data))
+
+		data, err = json.Marshal(DocumentURI("file:///C:/Users/f.go"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `"file:///C:/Users/f.go"`, string(data))
+
+		data, err = json.Marshal(DocumentURI("file:///a%zz.go"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `"file:///a%zz.go"`, string(data), "unparsable URIs are kept")
+
+		data, err = json.Marshal(DocumentURI("file:///home/user/my file.go"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `"file:///home/user/my%20file.go"`, string(data))
+
+		data, err = json.Marshal(DocumentURI("file:///C:/Users/Café/ünï.go?v=2#L1"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `"file:///C:/Users/Caf%C3%A9/%C3%BCn%C3%AF.go?v=2#L1"`, string(data),
+			"case, query and fragment are kept")
+
+		data, err = json.Marshal(DocumentURI("file:///c%3A/x.go"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `"file:///c:/x.go"`, string(data), "encoded like CanonicalKey")
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var doc TextDocumentIdentifier
+		require.NoError(t, json.Unmarshal([]byte(`{"uri": "file:///my%20file.go"}`), &doc))
+		assert.Equal(t, DocumentURI("file:///my%20file.go"), doc.URI)
+
+		err := json.Unmarshal([]byte(`{"uri": "file:///a\u0000.go"}`), &doc)
+		require.ErrorContains(t, err, "invalid control character")
+
+		err = json.Unmarshal([]byte(`{"uri": "main.go"}`), &doc)
+		require.ErrorContains(t, err, "missing scheme")
+
+		err = json.Unmarshal([]byte(`{"uri": 42}`), &doc)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid params reply", func(t *testing.T) {
+		params := json.RawMessage(`{
+			"textDocument": {"uri": "file:///a\u0007.go"},
+			"position": {"line": 0, "character": 0}
+		}`)
+		req, _ := jsonrpc2.NewCall(jsonrpc2.NewNumberID(1), MethodTextDocumentHover, params)
+
+		var replyErr error
+		replier := func(_ context.Context, _ any, err error) error {
+			replyErr = err
+			return nil
+		}
+
+		require.NoError(t, serverDispatch(context.Background(), &stubServer{}, replier, req))
+
+		var rpcErr *jsonrpc2.Error
+		require.ErrorAs(t, replyErr, &rpcErr)
+		assert.Equal(t, jsonrpc2.Code(CodeInvalidParams), rpcErr.Code)
+	})
+}
+
+func TestDocumentURI_CanonicalKey(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  DocumentURI
+		want DocumentURI
+	}{
+		{"plain", "file:///home/user/file.go", "file:///home/user/file.go"},
+		{"query", "file:///home/user/file.go?version=2", "file:///home/user/file.go"},
+		{"empty query", "file:///home/user/file.go?", "file:///home/user/file.go"},
+		{"fragment", "file:///home/user/file.go#L10", "file:///home/user/file.go"},
+		{"query and fragment", "file:///x.go?v=1#top", "file:///x.go"},
+		{"encoded drive colon", "file:///c%3A/src/x.go", "file:///c:/src/x.go"},
+		{"needless escape", "file:///home/%7Euser/a%2Db.go", "file:///home/~user/a-b.go"},
+		{"space", "file:///home/user/my%20file.go", "file:///home/user/my%20file.go"},
+		{"lowercase escape", "file:///home/user/my%2afile.go", "file:///home/user/my%2Afile.go"},
+		{"dot segments", "file:///home/user/./pkg/../a.go", "file:///home/user/a.go"},
+		{"trailing slash", "file:///home/user/./", "file:///home/user/"},
+		{"untitled", "untitled:Untitled-1", "untitled:Untitled-1"},
+		{"unparsable", "file:///bad%zz.go?v=2", "file:///bad%zz.go"},
+		{"unparsable other scheme", "git:/bad%zz.go?v=2", "git:/bad%zz.go?v=2"},
+		{
+			"git query kept",
+			`git:/home/user/./file.go?{"path":"/home/user/file.go","ref":"HEAD"}`,
+			`git:/home/user/file.go?{"path":"/home/user/file.go","ref":"HEAD"}`,
+		},
+		{
+			"notebook cell fragment kept",
+			"vscode-notebook-cell:/home/user/nb.ipynb#W1sZmlsZQ%3D%3D",
+			"vscode-notebook-cell:/home/user/nb.ipynb#W1sZmlsZQ%3D%3D",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.uri.CanonicalKey())
+		})
+	}
+
+	assert.Equal(t,
+		DocumentURI("file:///x.go?version=1").CanonicalKey(),
+		DocumentURI("file:///x.go?version=2").CanonicalKey())
+	assert.NotEqual(t,
+		DocumentURI(`git:/x.go?{"path":"/x.go","ref":"HEAD"}`).CanonicalKey(),
+		DocumentURI(`git:/x.go?{"path":"/x.go","ref":"~"}`).CanonicalKey(),
+		"git revisions are different documents")
+	assert.NotEqual(t,
+		DocumentURI("vscode-notebook-cell:/nb.ipynb#W0sZmlsZQ%3D%3D").CanonicalKey(),
+		DocumentURI("vscode-notebook-cell:/nb.ipynb#W1sZmlsZQ%3D%3D").CanonicalKey(),
+		"notebook cells are different documents")
+}
+
+func TestDocumentURI_CanonicalKeyWindows(t *testing.T) {
+	assert.Equal(t, DocumentURI("file:///c:/Foo/x.go"),
+		DocumentURI("file:///C:/Foo/x.go").canonicalKey(true))
+	assert.Equal(t, DocumentURI("file:///c:/Foo/x.go"),
+		DocumentURI("file:///C%3A/Foo/x.go").canonicalKey(true))
+	assert.Equal(t, DocumentURI("file:///c:"), DocumentURI("file:///C:").canonicalKey(true))
+	assert.Equal(t, DocumentURI("file:///C:/Foo/x.go"),
+		DocumentURI("file:///C:/Foo/x.go").canonicalKey(false), "drive letters are kept elsewhere")
+	assert.Equal(t, DocumentURI("file:///Cd:/x.go"),
+		DocumentURI("file:///Cd:/x.go").canonicalKey(true), "not a drive letter")
+}
+
+func TestDocumentURI_Equal(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b DocumentURI
+		want bool
+	}{
+		{"identical", "file:///home/a.go", "file:///home/a.go", true},
+		{"encoding", "file:///home/my%20a.go", "file:///home/my a.go", true},
+		{"escaped unreserved", "file:///home/%7Euser/a.go", "file:///home/~user/a.go", true},
+		{"dot segments", "file:///home/x/../a.go", "file:///home/./a.go", true},
+		{"query", "file:///home/a.go?v=2", "file:///home/a.go", true},
+		{"different files", "file:///home/a.go", "file:///home/b.go", false},
+		{"case", "file:///home/A.go", "file:///home/a.go", false},
+		{"scheme", "file:///home/a.go", "untitled:/home/a.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.a.Equal(tt.b))
+			assert.Equal(t, tt.want, tt.b.Equal(tt.a))
+		})
+	}
+
+	if runtime.GOOS == "windows" {
+		assert.True(t, DocumentURI("file:///C:/Foo").Equal("file:///c:/Foo"))
+	}
+}
+
+func TestDocumentURI_Dir(t *testing.T) {
+	tests := []struct {
+		uri, want DocumentURI
+	}{
+		{"file:///home/user/file.go", "file:///home/user"},
+		{"file:///home/user/", "file:///home"},
+		{"file:///home/user/file.go?v=2#L3", "file:///home/user"},
+		{"file:///my%20dir/file.go", "file:///my%20dir"},
+		{"file:///C:/src/x.go", "file:///C:/src"},
+		{"file://server/share/x.go", "file://server/share"},
+		{"file:///file.go", "file:///"},
+		{"file:///", "file:///"},
+		{"https://example.com/docs/a.md", "https://example.com/docs"},
+		{"untitled:Untitled-1", "untitled:Untitled-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.uri), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.uri.Dir())
+		})
+	}
+}
+
+func TestDocumentURI_Base(t *testing.T) {
+	tests := []struct {
+		uri  DocumentURI
+		want string
+	}{
+		{"file:///home/user/file.go", "file.go"},
+		{"file:///home/user/", "user"},
+		{"file:///home/my%20file.go?v=2", "my file.go"},
+		{"file:///", "/"},
+		{"https://example.com/docs/a.md", "a.md"},
+		{"untitled:Untitled-1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.uri), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.uri.Base())
+		})
+	}
+}
+
+func TestDocumentURI_Join(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  DocumentURI
+		elem []string
+		want DocumentURI
+	}{
+		{"single", "file:///home/user", []string{"file.go"}, "file:///home/user/file.go"},
+		{"several", "file:///home", []string{"user", "pkg", "a.go"}, "file:///home/user/pkg/a.go"},
+		{"with slashes", "file:///home", []string{"user/pkg/a.go"}, "file:///home/user/pkg/a.go"},
+		{"directory slash", "file:///home/", []string{"a.go"}, "file:///home/a.go"},
+		{"encoded", "file:///my%20dir", []string{"a b#1?.go"}, "file:///my%20dir/a%20b%231%3F.go"},
+		{"dot dot", "file:///home/user", []string{"..", "b", "a.go"}, "file:///home/b/a.go"},
+		{"trailing slash kept", "file:///home", []string{"pkg/"}, "file:///home/pkg/"},
+		{"query dropped", "file:///home?v=1", []string{"a.go"}, "file:///home/a.go"},
+		{"drive", "file:///C:/src", []string{"x.go"}, "file:///C:/src/x.go"},
+		{"host", "https://example.com/docs", []string{"a.md"}, "https://example.com/docs/a.md"},
+		{"no elements", "file:///home/./user/", nil, "file:///home/user"},
+		{"opaque", "untitled:Untitled-1", []string{"a"}, "untitled:Untitled-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.uri.Join(tt.elem...))
+		})
+	}
+
+	uri := DocumentURI("file:///home/user/pkg/a.go")
+	assert.Equal(t, uri, uri.Dir().Join(uri.Base()))
+}
+
 func TestURIRoundTrip(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("round-trip test for unix paths only")
@@ -93,6 +356,7 @@ func TestURIRoundTrip(t *testing.T) {
 		"/home/user/file.go",
 		"/tmp/a.txt",
 		"/",
+		"//server/share/x",
 	}
 
 	for _, path := range paths {
@@ -101,3 +365,51 @@ func TestURIRoundTrip(t *testing.T) {
 		require.Equal(t, path, got, "round-trip failed for path %q via uri %q", path, uri)
 	}
 }
+
+func TestDocumentURI_PathWindows(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("windows-only path tests")
+	}
+
+	tests := []struct {
+		name string
+		uri  DocumentURI
+		want string
+	}{
+		{"drive", "file:///C:/Users/f.go", `C:\Users\f.go`},
+		{"encoded drive colon", "file:///c%3A/Users/f.go", `c:\Users\f.go`},
+		{"drive root", "file:///C:/", `C:\`},
+		{"UNC", "file://server/share/x", `\\server\share\x`},
+		{"rooted without drive", "file:///Users/f.go", `\Users\f.go`},
+		{"not a drive", "file:///cd:/f.go", `\cd:\f.go`},
+		{"non-file URI", "https://example.com", "https://example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.uri.Path())
+		})
+	}
+}
+
+func TestURIRoundTripWindows(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("round-trip test for windows paths only")
+	}
+
+	tests := []struct {
+		path string
+		uri  DocumentURI
+	}{
+		{`C:\Users\f.go`, "file:///C:/Users/f.go"},
+		{`C:\`, "file:///C:/"},
+		{`\\server\share\x`, "file://server/share/x"},
+		{`\\server\share\dir\f.go`, "file://server/share/dir/f.go"},
+	}
+
+	for _, tt := range tests {
+		uri := URIFromPath(tt.path)
+		require.Equal(t, tt.uri, uri, "URIFromPath(%q)", tt.path)
+		require.Equal(t, tt.path, uri.Path(), "round-trip via %q", uri)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for registering file system watchers with
+// client/registerCapability (workspace/didChangeWatchedFiles) and for
+// matching their glob patterns.
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_didChangeWatchedFiles
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// WatchAll watches for file creation, change and deletion. It is what clients
+// assume when a FileSystemWatcher omits its kind.
+const WatchAll = WatchKindCreate | WatchKindChange | WatchKindDelete
+
+// NewFileWatcher returns a FileSystemWatcher for the glob pattern (e.g.
+// "**/*.go") reporting the events in kinds.
+func NewFileWatcher(pattern string, kinds WatchKind) FileSystemWatcher {
+	var glob GlobPattern
+	glob.SetPattern(pattern)
+
+	return FileSystemWatcher{
+		GlobPattern: glob,
+		Kind:        &kinds,
+	}
+}
+
+// NewRelativeWatcher returns a FileSystemWatcher for the glob pattern
+// interpreted relative to the folder base, reporting the events in kinds.
+// Prefer it over an absolute NewFileWatcher pattern for watchers scoped to a
+// workspace folder: the client can match it without walking the whole file
+// system, and the pattern needs no escaping of base. Requires the client to
+// announce workspace.didChangeWatchedFiles.relativePatternSupport.
+func NewRelativeWatcher(base DocumentURI, pattern string, kinds WatchKind) FileSystemWatcher {
+	return FileSystemWatcher{
+		GlobPattern: newRelativeGlob(URI(base), pattern),
+		Kind:        &kinds,
+	}
+}
+
+// NewWatchedFilesRegistration returns the Registration, to be sent with
+// client/registerCapability, that asks the client to report changes to files
+// matched by watchers. The id can later be used to unregister.
+func NewWatchedFilesRegistration(id string, watchers ...FileSystemWatcher) Registration {
+	if watchers == nil {
+		watchers = []FileSystemWatcher{}
+	}
+
+	var opts LSPAny = DidChangeWatchedFilesRegistrationOptions{Watchers: watchers}
+
+	return Registration{
+		ID:              id,
+		Method:          MethodWorkspaceDidChangeWatchedFiles,
+		RegisterOptions: &opts,
+	}
+}
+
+// NormalizeWatchPattern validates the GlobPattern p of a FileSystemWatcher
+// and returns it in canonical form: a Pattern string, or a RelativePattern
+// whose BaseURI is the base URI (a WorkspaceFolder base is replaced by its
+// URI). It returns an error if p holds neither form, if a relative pattern
+// has no base URI, or if the glob itself is malformed.
+func NormalizeWatchPattern(p GlobPattern) (GlobPattern, error) {
+	if pattern, ok := p.AsPattern(); ok {
+		return p, validateGlob(pattern)
+	}
+
+	rel, ok := p.AsRelativePattern()
+	if !ok {
+		return GlobPattern{}, errors.New("empty glob pattern") //nolint:err113
+	}
+
+	base, err := relativePatternBase(rel.BaseURI)
+	if err != nil {
+		return GlobPattern{}, err
+	}
+
+	if err := validateGlob(rel.Pattern); err != nil { //nolint:noinlineerr
+		return GlobPattern{}, err
+	}
+
+	return newRelativeGlob(base, rel.Pattern), nil
+}
+
+// newRelativeGlob returns a GlobPattern holding pattern relative to the URI
+// base.
+func newRelativeGlob(base URI, pattern string) GlobPattern {
+	var baseURI OrWorkspaceFolderURI
+	baseURI.SetURI(base)
+
+	var glob GlobPattern
+	glob.SetRelativePattern(RelativePattern{BaseURI: baseURI, Pattern: pattern})
+
+	return glob
+}
+
+// relativePatternBase returns the URI of a RelativePattern base, which is a
+// `WorkspaceFolder | URI` union.
+func relativePatternBase(base OrWorkspaceFolderURI) (URI, error) {
+	uri, _ := base.AsURI()
+	if folder, ok := base.AsWorkspaceFolder(); ok {
+		uri = folder.URI
+	}
+
+	if uri == "" {
+		return "", errors.New("relative pattern has no base URI") //nolint:err113
+	}
+
+	return uri, nil
+}
+
+// MatchGlob reports whether the path of uri matches the LSP glob pattern:
+//
+//   - `*` matches any run of characters within a path segment
+//   - `?` matches one character within a path segment
+//   - `**` matches any number of path segments, including none
+//   - `{a,b}` matches either alternative; groups may nest
+//   - `[0-9]` matches a character in the range, `[!0-9]` one outside it
+//
+// The whole path is matched, so patterns for files anywhere in the tree
+// start with `**/`, e.g. `**/*.go`. A malformed pattern matches nothing.
+func MatchGlob(pattern string, uri DocumentURI) bool {
+	name := string(uri)
+	if parsed, err := url.Parse(name); err == nil && parsed.Scheme != "" {
+		name = parsed.Path
+	}
+
+	alternatives, err := expandBraces(pattern)
+	if err != nil {
+		return false
+	}
+
+	nameSegs := strings.Split(name, "/")
+
+	for _, alt := range alternatives {
+		if matchSegments(strings.Split(alt, "/"), nameSegs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateGlob returns an error if pattern is not a well-formed glob.
+func validateGlob(pattern string) error {
+	if pattern == "" {
+		return errors.New("empty glob pattern") //nolint:err113
+	}
+
+	alternatives, err := expandBraces(pattern)
+	if err != nil {
+		return err
+	}
+
+	for _, alt := range alternatives {
+		for seg := range strings.SplitSeq(alt, "/") {
+			if _, err := path.Match(segmentPattern(seg), ""); err != nil { //nolint:noinlineerr
+				return fmt.Errorf("glob pattern %q: %w", pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchSegments matches path segments against pattern segments, letting a
+// "**" pattern segment consume any number of path segments.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(name); skip++ {
+				if matchSegments(pattern[1:], name[skip:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		if ok, err := path.Match(segmentPattern(pattern[0]), name[0]); err != nil || !ok {
+			return false
+		}
+
+		pattern, name = pattern[1:], name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// segmentPattern translates the glob syntax of a single path segment to
+// that of path.Match, which negates character classes with '^'.
+func segmentPattern(seg string) string {
+	return strings.ReplaceAll(seg, "[!", "[^")
+}
+
+// expandBraces expands the {a,b} groups of pattern, including nested ones,
+// into the list of plain glob alternatives.
+func expandBraces(pattern string) ([]string, error) {
+	open := strings.IndexByte(pattern, '{')
+	if open < 0 {
+		if strings.IndexByte(pattern, '}') >= 0 {
+			return nil, fmt.Errorf("glob pattern %q: unbalanced '}'", pattern) //nolint:err113
+		}
+
+		return []string{pattern}, nil
+	}
+
+	if strings.IndexByte(pattern[:open], '}') >= 0 {
+		return nil, fmt.Errorf("glob pattern %q: unbalanced '}'", pattern) //nolint:err113
+	}
+
+	depth, start := 0, open+1
+
+	var options []string
+
+	for i := open; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case ',':
+			if depth == 1 {
+				options = append(options, pattern[start:i])
+				start = i + 1
+			}
+		case '}':
+			depth--
+			if depth > 0 {
+				continue
+			}
+
+			options = append(options, pattern[start:i])
+
+			var out []string
+
+			for _, opt := range options {
+				expanded, err := expandBraces(pattern[:open] + opt + pattern[i+1:])
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, expanded...)
+			}
+
+			return out, nil
+		}
+	}
+
+	return nil, fmt.Errorf("glob pattern %q: unbalanced '{'", pattern) //nolint:err113
+}
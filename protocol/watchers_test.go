@@ -0,0 +1,137 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchAll(t *testing.T) {
+	assert.Equal(t, WatchKindCreate|WatchKindChange|WatchKindDelete, WatchAll)
+	assert.Equal(t, WatchKind(7), WatchAll)
+}
+
+func TestNewWatchedFilesRegistration(t *testing.T) {
+	reg := NewWatchedFilesRegistration("watch-go",
+		NewFileWatcher("**/*.go", WatchAll),
+		NewFileWatcher("**/go.mod", WatchKindChange|WatchKindDelete),
+	)
+
+	data, err := json.Marshal(reg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "watch-go",
+		"method": "workspace/didChangeWatchedFiles",
+		"registerOptions": {"watchers": [
+			{"globPattern": "**/*.go", "kind": 7},
+			{"globPattern": "**/go.mod", "kind": 6}
+		]}
+	}`, string(data))
+
+	data, err = json.Marshal(NewWatchedFilesRegistration("none"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"registerOptions":{"watchers":[]}`)
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		uri     DocumentURI
+		want    bool
+	}{
+		{"**/*.go", "file:///home/user/proj/main.go", true},
+		{"**/*.go", "file:///main.go", true},
+		{"**/*.go", "file:///home/user/proj/main.gox", false},
+		{"/home/*/proj/*.go", "file:///home/user/proj/main.go", true},
+		{"/home/*/proj/*.go", "file:///home/user/proj/cmd/main.go", false},
+		{"/home/**/main.go", "file:///home/main.go", true},
+		{"/home/**/main.go", "file:///home/a/b/c/main.go", true},
+		{"**/go.?um", "file:///proj/go.sum", true},
+		{"**/go.?um", "file:///proj/go.mod", false},
+		{"**/*.{go,mod}", "file:///proj/go.mod", true},
+		{"**/*.{go,mod}", "file:///proj/go.sum", false},
+		{"**/{cmd,internal/{a,b}}/*.go", "file:///proj/internal/b/x.go", true},
+		{"**/{cmd,internal/{a,b}}/*.go", "file:///proj/internal/c/x.go", false},
+		{"**/v[0-9].txt", "file:///proj/v7.txt", true},
+		{"**/v[0-9].txt", "file:///proj/vx.txt", false},
+		{"**/v[!0-9].txt", "file:///proj/vx.txt", true},
+		{"**/v[!0-9].txt", "file:///proj/v7.txt", false},
+		{"**/*.{go", "file:///proj/main.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+string(tt.uri), func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchGlob(tt.pattern, tt.uri))
+		})
+	}
+}
+
+func TestNewRelativeWatcher(t *testing.T) {
+	watcher := NewRelativeWatcher("file:///proj", "**/*.go", WatchKindCreate|WatchKindDelete)
+
+	data, err := json.Marshal(watcher)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"globPattern": {"baseUri": "file:///proj", "pattern": "**/*.go"},
+		"kind": 5
+	}`, string(data))
+
+	got, err := NormalizeWatchPattern(watcher.GlobPattern)
+	require.NoError(t, err)
+	assert.Equal(t, watcher.GlobPattern, got)
+
+	rel, ok := got.AsRelativePattern()
+	require.True(t, ok)
+	assert.Equal(t, "**/*.go", rel.Pattern)
+}
+
+func TestNormalizeWatchPattern(t *testing.T) {
+	decode := func(t *testing.T, raw string) GlobPattern {
+		t.Helper()
+
+		var p GlobPattern
+		require.NoError(t, json.Unmarshal([]byte(raw), &p))
+
+		return p
+	}
+
+	want := NewRelativeWatcher("file:///proj", "**/*.go", WatchAll).GlobPattern
+
+	for name, raw := range map[string]string{
+		"uri base":    `{"baseUri": "file:///proj", "pattern": "**/*.go"}`,
+		"folder base": `{"baseUri": {"uri": "file:///proj", "name": "proj"}, "pattern": "**/*.go"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeWatchPattern(decode(t, raw))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("string", func(t *testing.T) {
+		p := NewFileWatcher("**/*.{go,mod}", WatchAll).GlobPattern
+
+		got, err := NormalizeWatchPattern(p)
+		require.NoError(t, err)
+		assert.Equal(t, p, got)
+	})
+
+	for name, p := range map[string]GlobPattern{
+		"unbalanced brace":  NewFileWatcher("**/*.{go", WatchAll).GlobPattern,
+		"unbalanced class":  NewFileWatcher("**/v[0-9.txt", WatchAll).GlobPattern,
+		"empty":             NewFileWatcher("", WatchAll).GlobPattern,
+		"missing base":      decode(t, `{"baseUri": "", "pattern": "*.go"}`),
+		"zero value":        {},
+		"bad relative glob": NewRelativeWatcher("file:///proj", "}*.go", WatchAll).GlobPattern,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NormalizeWatchPattern(p)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for workspace/diagnostic, the workspace-wide
+// pull in which the client sends the result IDs it holds for every document
+// and the server answers with a full or unchanged report per document.
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_workspaceDiagnostics
+
+// WorkspaceDiagnosticBuilder assembles the WorkspaceDiagnosticReport of a
+// workspace/diagnostic request, one document at a time. The zero value is
+// an empty builder ready to use.
+type WorkspaceDiagnosticBuilder struct {
+	items []WorkspaceDocumentDiagnosticReport
+}
+
+// AddFull adds a full report of items for uri, tagged with resultID unless it
+// is empty. version is the version of the document the diagnostics were
+// computed for, or nil if the document is not open.
+func (b *WorkspaceDiagnosticBuilder) AddFull(
+	uri DocumentURI,
+	version *int32,
+	resultID string,
+	items []Diagnostic,
+) *WorkspaceDiagnosticBuilder {
+	if items == nil {
+		items = []Diagnostic{}
+	}
+
+	report := WorkspaceFullDocumentDiagnosticReport{
+		URI:      uri,
+		Version:  version,
+		Kind:     string(DocumentDiagnosticReportKindFull),
+		ResultId: nil,
+		Items:    items,
+	}
+
+	if resultID != "" {
+		report.ResultId = &resultID
+	}
+
+	var item WorkspaceDocumentDiagnosticReport
+	item.SetWorkspaceFullDocumentDiagnosticReport(report)
+	b.items = append(b.items, item)
+
+	return b
+}
+
+// AddUnchanged adds a report telling the client that the diagnostics it holds
+// for uri under resultID are still current. version is as for AddFull.
+func (b *WorkspaceDiagnosticBuilder) AddUnchanged(
+	uri DocumentURI,
+	version *int32,
+	resultID string,
+) *WorkspaceDiagnosticBuilder {
+	var item WorkspaceDocumentDiagnosticReport
+	item.SetWorkspaceUnchangedDocumentDiagnosticReport(WorkspaceUnchangedDocumentDiagnosticReport{
+		URI:      uri,
+		Version:  version,
+		Kind:     string(DocumentDiagnosticReportKindUnchanged),
+		ResultId: resultID,
+	})
+	b.items = append(b.items, item)
+
+	return b
+}
+
+// Report returns the report of the documents added so far. Its items list is
+// empty, not nil, if none were added.
+func (b *WorkspaceDiagnosticBuilder) Report() WorkspaceDiagnosticReport {
+	return WorkspaceDiagnosticReport{
+		Items: append([]WorkspaceDocumentDiagnosticReport{}, b.items...),
+	}
+}
+
+// PreviousResultIDs returns the result IDs the client holds, keyed by
+// document, as sent in the previousResultIds of the request. Pass them to
+// DiagnosticCache.ShouldRecompute to decide between AddFull and AddUnchanged.
+func (p *WorkspaceDiagnosticParams) PreviousResultIDs() map[DocumentURI]string {
+	ids := make(map[DocumentURI]string, len(p.PreviousResultIds))
+
+	for _, prev := range p.PreviousResultIds {
+		ids[prev.URI] = prev.Value
+	}
+
+	return ids
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceDiagnosticBuilder(t *testing.T) {
+	t.Run("full and unchanged", func(t *testing.T) {
+		var b WorkspaceDiagnosticBuilder
+
+		diags := []Diagnostic{{Message: "unused variable x"}}
+		report := b.
+			AddFull("file:///a.go", new(int32(3)), "a-2", diags).
+			AddUnchanged("file:///b.go", nil, "b-1").
+			AddFull("file:///c.go", nil, "", nil).
+			Report()
+
+		data, err := json.Marshal(report)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"items": [
+			{
+				"uri": "file:///a.go",
+				"version": 3,
+				"kind": "full",
+				"resultId": "a-2",
+				"items": [{
+					"range": {
+						"start": {"line": 0, "character": 0},
+						"end": {"line": 0, "character": 0}
+					},
+					"message": "unused variable x"
+				}]
+			},
+			{"uri": "file:///b.go", "version": null, "kind": "unchanged", "resultId": "b-1"},
+			{"uri": "file:///c.go", "version": null, "kind": "full", "items": []}
+		]}`, string(data))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var b WorkspaceDiagnosticBuilder
+
+		data, err := json.Marshal(b.Report())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"items": []}`, string(data))
+	})
+
+	t.Run("with previous result IDs", func(t *testing.T) {
+		cache := NewDiagnosticCache()
+		cache.Store("file:///a.go", "a-1", []Diagnostic{{Message: "old"}})
+		cache.Store("file:///b.go", "b-1", nil)
+
+		var params WorkspaceDiagnosticParams
+		require.NoError(t, json.Unmarshal([]byte(`{"previousResultIds": [
+			{"uri": "file:///a.go", "value": "a-1"},
+			{"uri": "file:///b.go", "value": "b-0"}
+		]}`), &params))
+
+		previous := params.PreviousResultIDs()
+		assert.Equal(t,
+			map[DocumentURI]string{"file:///a.go": "a-1", "file:///b.go": "b-0"}, previous)
+
+		var b WorkspaceDiagnosticBuilder
+
+		for _, uri := range []DocumentURI{"file:///a.go", "file:///b.go"} {
+			if cache.ShouldRecompute(uri, previous[uri]) {
+				b.AddFull(uri, nil, "b-1", nil)
+			} else {
+				b.AddUnchanged(uri, nil, previous[uri])
+			}
+		}
+
+		report := b.Report()
+		require.Len(t, report.Items, 2)
+		unchanged, ok := report.Items[0].AsWorkspaceUnchangedDocumentDiagnosticReport()
+		require.True(t, ok)
+		assert.Equal(t, WorkspaceUnchangedDocumentDiagnosticReport{
+			URI:      "file:///a.go",
+			Kind:     "unchanged",
+			ResultId: "a-1",
+		}, unchanged)
+
+		full, ok := report.Items[1].AsWorkspaceFullDocumentDiagnosticReport()
+		require.True(t, ok)
+		assert.Equal(t, "full", full.Kind)
+		assert.Equal(t, new("b-1"), full.ResultId)
+	})
+}
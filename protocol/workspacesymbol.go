@@ -0,0 +1,56 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+// This file provides helpers for WorkspaceSymbol, whose Location is the
+// `Location | LocationUriOnly` union. A server may answer workspace/symbol
+// with only the URI of each symbol (the lazy form) and compute the range
+// later in workspaceSymbol/resolve.
+
+import (
+	"errors"
+)
+
+// NewWorkspaceSymbol returns a WorkspaceSymbol with a full location.
+func NewWorkspaceSymbol(name string, kind SymbolKind, loc Location) WorkspaceSymbol {
+	return WorkspaceSymbol{ //nolint:exhaustruct
+		Name: name,
+		Kind: kind,
+	}.WithLocation(loc)
+}
+
+// NewLazyWorkspaceSymbol returns a WorkspaceSymbol locating the symbol by
+// uri only. Clients that announce workspace.symbol.resolveSupport for the
+// location.range property send it back in workspaceSymbol/resolve, where the
+// server fills in the range with WithLocation.
+func NewLazyWorkspaceSymbol(name string, kind SymbolKind, uri DocumentURI) WorkspaceSymbol {
+	sym := WorkspaceSymbol{ //nolint:exhaustruct
+		Name: name,
+		Kind: kind,
+	}
+	sym.Location.SetLocationUriOnly(LocationUriOnly{URI: uri})
+
+	return sym
+}
+
+// WithLocation returns a copy of s with its full location set to loc.
+func (s WorkspaceSymbol) WithLocation(loc Location) WorkspaceSymbol {
+	s.Location.SetLocation(loc)
+	return s
+}
+
+// SymbolLocation returns the symbol's location. For the lazy form it returns
+// a Location with an empty range and lazy set to true. It returns an error
+// if the location holds neither form.
+func (s WorkspaceSymbol) SymbolLocation() (loc Location, lazy bool, err error) {
+	if loc, ok := s.Location.AsLocation(); ok {
+		return loc, false, nil
+	}
+
+	if only, ok := s.Location.AsLocationUriOnly(); ok {
+		return Location{URI: only.URI}, true, nil //nolint:exhaustruct
+	}
+
+	return Location{}, false, errors.New("workspace symbol has no location") //nolint:err113
+}
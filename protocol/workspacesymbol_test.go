@@ -0,0 +1,89 @@
+// Copyright 2026 Bohdan Shtepan.
+// Licensed under the MIT License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip encodes s and decodes it back, as it would arrive at the peer.
+func roundTrip(t *testing.T, s WorkspaceSymbol) WorkspaceSymbol {
+	t.Helper()
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	var out WorkspaceSymbol
+	require.NoError(t, json.Unmarshal(data, &out))
+
+	return out
+}
+
+func TestNewWorkspaceSymbol(t *testing.T) {
+	loc := Location{
+		URI:   "file:///server.go",
+		Range: Range{Start: Position{Line: 12, Character: 5}, End: Position{Line: 12, Character: 11}},
+	}
+	sym := NewWorkspaceSymbol("Server", SymbolKindStruct, loc)
+
+	data, err := json.Marshal(sym)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "Server",
+		"kind": 23,
+		"location": {
+			"uri": "file:///server.go",
+			"range": {"start": {"line": 12, "character": 5}, "end": {"line": 12, "character": 11}}
+		}
+	}`, string(data))
+
+	for _, s := range []WorkspaceSymbol{sym, roundTrip(t, sym)} {
+		got, lazy, err := s.SymbolLocation()
+		require.NoError(t, err)
+		assert.False(t, lazy)
+		assert.Equal(t, loc, got)
+	}
+}
+
+func TestNewLazyWorkspaceSymbol(t *testing.T) {
+	sym := NewLazyWorkspaceSymbol("Server", SymbolKindStruct, "file:///server.go")
+
+	data, err := json.Marshal(sym)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "Server", "kind": 23, "location": {"uri": "file:///server.go"}}`, string(data))
+
+	for _, s := range []WorkspaceSymbol{sym, roundTrip(t, sym)} {
+		got, lazy, err := s.SymbolLocation()
+		require.NoError(t, err)
+		assert.True(t, lazy)
+		assert.Equal(t, Location{URI: "file:///server.go"}, got)
+	}
+}
+
+func TestWorkspaceSymbol_Resolve(t *testing.T) {
+	// The client echoes the lazy symbol in workspaceSymbol/resolve.
+	req := roundTrip(t, NewLazyWorkspaceSymbol("Server", SymbolKindStruct, "file:///server.go"))
+
+	loc, lazy, err := req.SymbolLocation()
+	require.NoError(t, err)
+	require.True(t, lazy)
+
+	loc.Range = Range{Start: Position{Line: 12, Character: 5}, End: Position{Line: 12, Character: 11}}
+	resolved := roundTrip(t, req.WithLocation(loc))
+
+	got, lazy, err := resolved.SymbolLocation()
+	require.NoError(t, err)
+	assert.False(t, lazy)
+	assert.Equal(t, loc, got)
+	assert.Equal(t, "Server", resolved.Name)
+}
+
+func TestWorkspaceSymbol_SymbolLocationInvalid(t *testing.T) {
+	_, _, err := WorkspaceSymbol{}.SymbolLocation()
+	require.Error(t, err)
+}